@@ -1,10 +1,14 @@
 package yak
 
 import (
+	"time"
+
 	"github.com/architectv/networking-course-project/backend/pkg/handlers"
+	"github.com/architectv/networking-course-project/backend/pkg/mail"
 	"github.com/architectv/networking-course-project/backend/pkg/repositories"
 	"github.com/architectv/networking-course-project/backend/pkg/repositories/postgres"
 	"github.com/architectv/networking-course-project/backend/pkg/services"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -12,6 +16,12 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+	multipartOverhead    = 1 << 20
+	defaultAvatarMaxSize = 2 << 20
+	defaultMailQueueSize = 100
+)
+
 func CreateApp() {
 	logrus.SetFormatter(new(logrus.JSONFormatter))
 
@@ -36,16 +46,126 @@ func CreateApp() {
 		logrus.Fatalf("failed to initialize db: %s", err.Error())
 	}
 
+	blobs, err := storage.New(storage.Config{
+		Driver:    viper.GetString("storage.driver"),
+		Path:      viper.GetString("storage.path"),
+		Endpoint:  viper.GetString("storage.endpoint"),
+		Bucket:    viper.GetString("storage.bucket"),
+		Region:    viper.GetString("storage.region"),
+		AccessKey: viper.GetString("storage.accessKey"),
+		SecretKey: viper.GetString("storage.secretKey"),
+	})
+	if err != nil {
+		logrus.Fatalf("failed to initialize storage: %s", err.Error())
+	}
+
+	limits := services.AttachmentLimits{
+		MaxSize: viper.GetInt64("attachments.maxSize"),
+		Types:   viper.GetStringSlice("attachments.types"),
+	}
+
+	avatarMaxSize := viper.GetInt64("avatars.maxSize")
+	if avatarMaxSize <= 0 {
+		avatarMaxSize = defaultAvatarMaxSize
+	}
+
+	transport, err := mail.New(mail.Config{
+		Driver:   viper.GetString("mail.transport"),
+		From:     viper.GetString("mail.from"),
+		Path:     viper.GetString("mail.file"),
+		Host:     viper.GetString("mail.smtp.host"),
+		Port:     viper.GetInt("mail.smtp.port"),
+		Username: viper.GetString("mail.smtp.username"),
+		Password: viper.GetString("mail.smtp.password"),
+	})
+	if err != nil {
+		logrus.Fatalf("failed to initialize mail: %s", err.Error())
+	}
+	mailConfig := services.MailConfig{
+		BaseURL: viper.GetString("mail.baseUrl"),
+		Secret:  viper.GetString("mail.secret"),
+	}
+	if transport != nil {
+		queueSize := viper.GetInt("mail.queueSize")
+		if queueSize <= 0 {
+			queueSize = defaultMailQueueSize
+		}
+		queue := mail.NewQueue(transport, queueSize, func(msg *mail.Message, err error) {
+			logrus.Errorf("failed to send mail to %s: %s", msg.To, err.Error())
+		})
+		defer queue.Close()
+		mailConfig.Transport = queue
+	}
+	if err := mailConfig.Validate(); err != nil {
+		logrus.Fatalf("failed to initialize mail: %s", err.Error())
+	}
+
 	repos := repositories.NewRepository(db)
-	services := services.NewService(repos)
+	services := services.NewService(repos, blobs, limits, avatarMaxSize, mailConfig)
 	handlers := handlers.NewHandler(services)
 
-	app := fiber.New()
+	if days := viper.GetInt("trash.purgeAfterDays"); days > 0 {
+		go purgeTrash(services, time.Duration(days)*24*time.Hour)
+	}
+	if viper.GetBool("mail.digest") && mailConfig.Transport != nil {
+		go sendDigests(services)
+	}
+	if mailConfig.Transport != nil {
+		go sendReminders(services)
+	}
+
+	config := fiber.Config{}
+	// Leave room for the multipart framing around the largest upload.
+	bodyLimit := limits.MaxSize
+	if avatarMaxSize > bodyLimit {
+		bodyLimit = avatarMaxSize
+	}
+	if bodyLimit += multipartOverhead; bodyLimit > fiber.DefaultBodyLimit {
+		config.BodyLimit = int(bodyLimit)
+	}
+	app := fiber.New(config)
 	app.Use(logger.New())
 	handlers.RegisterHandlers(app)
 	app.Listen(viper.GetString("port"))
 }
 
+// purgeTrash periodically drops the trash older than the retention period.
+func purgeTrash(services *services.Service, retention time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		before := time.Now().Add(-retention).Unix()
+		if err := services.Trash.Purge(before); err != nil {
+			logrus.Errorf("failed to purge trash: %s", err.Error())
+		}
+		<-ticker.C
+	}
+}
+
+// sendDigests mails the digests that are due every hour.
+func sendDigests(services *services.Service) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		if err := services.Mail.SendDigests(time.Now().Unix()); err != nil {
+			logrus.Errorf("failed to send digests: %s", err.Error())
+		}
+		<-ticker.C
+	}
+}
+
+// sendReminders mails the due date reminders every hour.
+func sendReminders(services *services.Service) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		if err := services.Mail.SendReminders(time.Now().Unix()); err != nil {
+			logrus.Errorf("failed to send reminders: %s", err.Error())
+		}
+		<-ticker.C
+	}
+}
+
 func initConfig() error {
 	viper.AddConfigPath("config")
 	viper.SetConfigName("config")
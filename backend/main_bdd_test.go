@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http/httptest"
+	"os"
 	"strconv"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 	"github.com/architectv/networking-course-project/backend/pkg/repositories"
 	"github.com/architectv/networking-course-project/backend/pkg/services"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/jmoiron/sqlx"
@@ -56,8 +58,15 @@ func Test_E2E_App(t *testing.T) {
 	}
 	defer db.Close()
 
+	uploads, err := ioutil.TempDir("", "uploads")
+	if err != nil {
+		log.Fatalf("failed to initialize storage: %s", err.Error())
+	}
+	defer os.RemoveAll(uploads)
+
 	repos := repositories.NewRepository(db)
-	services := services.NewService(repos)
+	services := services.NewService(repos, storage.NewLocal(uploads), services.AttachmentLimits{}, 1<<20,
+		services.MailConfig{})
 	handlers := handlers.NewHandler(services)
 
 	app := fiber.New()
@@ -0,0 +1,330 @@
+// Package filter parses task filter queries such as
+//
+//	label:bug -label:wontfix list:"In progress" updated:<7d title:~login
+//
+// A query is a list of terms separated by spaces, all of which must match.
+// A term is a field, an operator and a value, or a bare word that is looked
+// up in the title and the description. A leading minus negates the term.
+//
+// Custom fields of the board are named with the cf. prefix, as in
+// cf.severity:high or cf.due:<2021-05-01. The parser does not know them, so
+// the caller resolves these terms with the fields of the board.
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	FieldText        = ""
+	FieldLabel       = "label"
+	FieldList        = "list"
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldCreated     = "created"
+	FieldUpdated     = "updated"
+	FieldAccessed    = "accessed"
+	FieldPosition    = "position"
+	FieldCustom      = "cf"
+)
+
+const customPrefix = FieldCustom + "."
+
+const (
+	OpEqual          = ":"
+	OpContains       = "~"
+	OpLess           = "<"
+	OpLessOrEqual    = "<="
+	OpGreater        = ">"
+	OpGreaterOrEqual = ">="
+)
+
+var textFields = map[string]bool{
+	FieldLabel:       true,
+	FieldList:        true,
+	FieldTitle:       true,
+	FieldDescription: true,
+}
+
+var timeFields = map[string]bool{
+	FieldCreated:  true,
+	FieldUpdated:  true,
+	FieldAccessed: true,
+}
+
+var durationUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+var sortFields = map[string]bool{
+	FieldPosition: true,
+	FieldTitle:    true,
+	FieldCreated:  true,
+	FieldUpdated:  true,
+	FieldAccessed: true,
+}
+
+const dateLayout = "2006-01-02"
+
+// Query holds the terms and the order of the tasks, which is their position
+// in the list when Sort is nil.
+type Query struct {
+	Terms []*Term
+	Sort  *Sort
+}
+
+// Term is a single condition of a query. Time fields hold either a duration
+// relative to now or a date, which stands for the whole day in UTC.
+type Term struct {
+	Pos      int
+	Negated  bool
+	Field    string
+	Op       string
+	Value    string
+	Duration time.Duration
+	Date     time.Time
+	Number   float64
+	Custom   *Custom
+}
+
+func (t *Term) IsRelative() bool {
+	return t.Duration != 0
+}
+
+// ParseTime reads the value as a date or a duration, for custom fields that
+// turn out to hold dates.
+func (t *Term) ParseTime() error {
+	return parseTime(t)
+}
+
+// Custom names a custom field in a term or a sort. Id and Type are unknown
+// to the parser and set by the caller when it resolves the name.
+type Custom struct {
+	Name string
+	Id   int
+	Type string
+}
+
+// Sort orders tasks within their lists by a field, or by a custom field
+// when Custom is set. Tasks without a value come last either way.
+type Sort struct {
+	Field  string
+	Desc   bool
+	Custom *Custom
+}
+
+// ParseSort reads a field name with an optional leading minus for the
+// descending order, as in -updated or cf.severity. It returns nil for the
+// default order.
+func ParseSort(input string) (*Sort, error) {
+	if input == "" {
+		return nil, nil
+	}
+
+	sort := &Sort{Field: input}
+	if strings.HasPrefix(input, "-") {
+		sort.Desc = true
+		sort.Field = input[1:]
+	}
+	if strings.HasPrefix(strings.ToLower(sort.Field), customPrefix) && len(sort.Field) > len(customPrefix) {
+		sort.Custom = &Custom{Name: sort.Field[len(customPrefix):]}
+		sort.Field = FieldCustom
+		return sort, nil
+	}
+	if !sortFields[sort.Field] {
+		return nil, fmt.Errorf("Invalid sort %q", input)
+	}
+	return sort, nil
+}
+
+// Error points at the position of the invalid part of the query, counting
+// characters from 1.
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos)
+}
+
+func Parse(input string) (*Query, error) {
+	p := &parser{input: []rune(input)}
+	query := &Query{Terms: make([]*Term, 0)}
+	for {
+		p.skipSpaces()
+		if p.eof() {
+			return query, nil
+		}
+
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		query.Terms = append(query.Terms, term)
+	}
+}
+
+type parser struct {
+	input []rune
+	pos   int
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) term() (*Term, error) {
+	term := &Term{Pos: p.pos + 1, Op: OpContains}
+	if p.peek() == '-' {
+		term.Negated = true
+		p.pos++
+	}
+
+	start := p.pos
+	if p.peek() == '"' {
+		value, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		term.Value = value
+		return term, nil
+	}
+
+	for !p.eof() && p.peek() != ':' && !unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+	word := string(p.input[start:p.pos])
+	if p.peek() != ':' {
+		if word == "" {
+			return nil, p.errorf(start, "Expected a word after \"-\"")
+		}
+		term.Value = word
+		return term, nil
+	}
+
+	term.Field = strings.ToLower(word)
+	if strings.HasPrefix(term.Field, customPrefix) && len(word) > len(customPrefix) {
+		term.Field = FieldCustom
+		term.Custom = &Custom{Name: word[len(customPrefix):]}
+	} else if !textFields[term.Field] && !timeFields[term.Field] {
+		return nil, p.errorf(start, "Unknown field %q", word)
+	}
+	p.pos++
+
+	opPos := p.pos
+	term.Op = p.operator()
+	if textFields[term.Field] && term.Op != OpEqual && term.Op != OpContains {
+		return nil, p.errorf(opPos, "Operator %q is not supported by %s", term.Op, term.Field)
+	}
+	if timeFields[term.Field] && term.Op == OpContains {
+		return nil, p.errorf(opPos, "Operator %q is not supported by %s", term.Op, term.Field)
+	}
+
+	valuePos := p.pos
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, p.errorf(valuePos, "Expected a value for %s", strings.ToLower(word))
+	}
+	term.Value = value
+
+	if timeFields[term.Field] {
+		if err := parseTime(term); err != nil {
+			return nil, p.errorf(valuePos, "%s", err.Error())
+		}
+	}
+	return term, nil
+}
+
+func (p *parser) operator() string {
+	for _, op := range []string{OpLessOrEqual, OpGreaterOrEqual, OpLess, OpGreater, OpContains} {
+		if strings.HasPrefix(string(p.input[p.pos:]), op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return OpEqual
+}
+
+func (p *parser) value() (string, error) {
+	if p.peek() == '"' {
+		return p.quoted()
+	}
+
+	start := p.pos
+	for !p.eof() && !unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+	return string(p.input[start:p.pos]), nil
+}
+
+// quoted reads a double-quoted string where \" and \\ stand for the escaped
+// characters.
+func (p *parser) quoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var value strings.Builder
+	for !p.eof() {
+		switch r := p.peek(); r {
+		case '"':
+			p.pos++
+			return value.String(), nil
+		case '\\':
+			if p.pos+1 < len(p.input) {
+				p.pos++
+			}
+			value.WriteRune(p.peek())
+		default:
+			value.WriteRune(r)
+		}
+		p.pos++
+	}
+	return "", p.errorf(start, "Unterminated quoted string")
+}
+
+func parseTime(term *Term) error {
+	value := term.Value
+	if date, err := time.Parse(dateLayout, value); err == nil {
+		term.Date = date
+		return nil
+	}
+
+	unit, ok := durationUnits[value[len(value)-1]]
+	if !ok {
+		return fmt.Errorf("Invalid date or duration %q", value)
+	}
+	amount, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || amount <= 0 {
+		return fmt.Errorf("Invalid date or duration %q", value)
+	}
+	term.Duration = time.Duration(amount) * unit
+	return nil
+}
@@ -0,0 +1,138 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []*Term
+	}{
+		{
+			name:  "Full query",
+			input: `label:bug -label:wontfix list:"In progress" updated:<7d title:~login`,
+			expected: []*Term{
+				{Pos: 1, Field: FieldLabel, Op: OpEqual, Value: "bug"},
+				{Pos: 11, Negated: true, Field: FieldLabel, Op: OpEqual, Value: "wontfix"},
+				{Pos: 26, Field: FieldList, Op: OpEqual, Value: "In progress"},
+				{Pos: 45, Field: FieldUpdated, Op: OpLess, Value: "7d", Duration: 7 * 24 * time.Hour},
+				{Pos: 57, Field: FieldTitle, Op: OpContains, Value: "login"},
+			},
+		},
+		{
+			name:  "Words and dates",
+			input: ` deploy -"hot fix" created:>=2021-03-04 `,
+			expected: []*Term{
+				{Pos: 2, Op: OpContains, Value: "deploy"},
+				{Pos: 9, Negated: true, Op: OpContains, Value: "hot fix"},
+				{Pos: 20, Field: FieldCreated, Op: OpGreaterOrEqual, Value: "2021-03-04",
+					Date: time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:  "Escaped quote",
+			input: `title:"say \"hi\""`,
+			expected: []*Term{
+				{Pos: 1, Field: FieldTitle, Op: OpEqual, Value: `say "hi"`},
+			},
+		},
+		{
+			name:  "Custom fields",
+			input: `cf.Severity:high -CF.due:<2021-05-01`,
+			expected: []*Term{
+				{Pos: 1, Field: FieldCustom, Op: OpEqual, Value: "high",
+					Custom: &Custom{Name: "Severity"}},
+				{Pos: 18, Negated: true, Field: FieldCustom, Op: OpLess, Value: "2021-05-01",
+					Custom: &Custom{Name: "due"}},
+			},
+		},
+		{
+			name:     "Empty",
+			input:    "   ",
+			expected: []*Term{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := Parse(test.input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, query.Terms)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *Error
+	}{
+		{
+			name:     "Unknown field",
+			input:    "label:bug owner:alex",
+			expected: &Error{Pos: 11, Message: `Unknown field "owner"`},
+		},
+		{
+			name:     "Comparison of text",
+			input:    "title:<abc",
+			expected: &Error{Pos: 7, Message: `Operator "<" is not supported by title`},
+		},
+		{
+			name:     "Invalid duration",
+			input:    "updated:<7y",
+			expected: &Error{Pos: 10, Message: `Invalid date or duration "7y"`},
+		},
+		{
+			name:     "Missing value",
+			input:    "list: bug",
+			expected: &Error{Pos: 6, Message: "Expected a value for list"},
+		},
+		{
+			name:     "Custom field without a name",
+			input:    "cf.:high",
+			expected: &Error{Pos: 1, Message: `Unknown field "cf."`},
+		},
+		{
+			name:     "Unterminated quote",
+			input:    `list:"In progress`,
+			expected: &Error{Pos: 6, Message: "Unterminated quoted string"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.input)
+			assert.Equal(t, test.expected, err)
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *Sort
+		isError  bool
+	}{
+		{input: "", expected: nil},
+		{input: "title", expected: &Sort{Field: FieldTitle}},
+		{input: "-updated", expected: &Sort{Field: FieldUpdated, Desc: true}},
+		{input: "-cf.severity", expected: &Sort{Field: FieldCustom, Desc: true,
+			Custom: &Custom{Name: "severity"}}},
+		{input: "owner", isError: true},
+		{input: "cf.", isError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			sort, err := ParseSort(test.input)
+			assert.Equal(t, test.isError, err != nil)
+			assert.Equal(t, test.expected, sort)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerAttachmentsHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/boards/:bid/lists/:lid/tasks/:tid/attachments", apiVX.userIdentity)
+	group.Get("/", apiVX.urlIdsValidation, apiVX.getAttachments)
+	group.Post("/", apiVX.urlIdsValidation, apiVX.createAttachment)
+	group.Get("/:aid", apiVX.urlIdsValidation, apiVX.downloadAttachment)
+	group.Delete("/:aid", apiVX.urlIdsValidation, apiVX.deleteAttachment)
+}
+
+func (apiVX *ApiV1) getAttachments(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Attachment.GetAll(userId, projectId, boardId, listId, taskId)
+	return Send(ctx, response)
+}
+
+// createAttachment takes the file as the "file" field of a multipart form.
+func (apiVX *ApiV1) createAttachment(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		response.Error(fiber.StatusBadRequest, "Invalid file")
+		return Send(ctx, response)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+	defer file.Close()
+
+	upload := &models.AttachmentUpload{
+		Name:    header.Filename,
+		Size:    header.Size,
+		Content: file,
+	}
+	response = apiVX.services.Attachment.Create(userId, projectId, boardId, listId, taskId, upload)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) downloadAttachment(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	attachmentId, err := strconv.Atoi(ctx.Params("aid"))
+	if err != nil || attachmentId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid attachmentId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Attachment.Download(userId, projectId, boardId, listId, taskId, attachmentId)
+	if response.Code != fiber.StatusOK {
+		return Send(ctx, response)
+	}
+
+	// The stream is closed once it has been sent.
+	attachment := response.Data.(*models.AttachmentContent)
+	ctx.Attachment(attachment.Name)
+	ctx.Set(fiber.HeaderContentType, attachment.ContentType)
+	return ctx.SendStream(attachment.Content, int(attachment.Size))
+}
+
+func (apiVX *ApiV1) deleteAttachment(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	attachmentId, err := strconv.Atoi(ctx.Params("aid"))
+	if err != nil || attachmentId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid attachmentId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Attachment.Delete(userId, projectId, boardId, listId, taskId, attachmentId)
+	return Send(ctx, response)
+}
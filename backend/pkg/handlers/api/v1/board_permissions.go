@@ -14,6 +14,17 @@ func (apiVX *ApiV1) registerBoardPermsHandlers(router fiber.Router) {
 	group.Get("/", apiVX.urlIdsValidation, apiVX.getBoardPerms)
 	group.Put("/", apiVX.urlIdsValidation, apiVX.updateBoardPerms)
 	group.Delete("/", apiVX.urlIdsValidation, apiVX.deleteBoardPerms)
+	group.Put("/deny", apiVX.urlIdsValidation, apiVX.denyBoardPerms)
+	group.Delete("/deny", apiVX.urlIdsValidation, apiVX.deleteBoardDeny)
+
+	groupPerms := router.Group("/projects/:pid/boards/:bid/groups/:gid/permissions", apiVX.userIdentity)
+	groupPerms.Post("/", apiVX.urlIdsValidation, apiVX.createBoardGroupPerms)
+	groupPerms.Get("/", apiVX.urlIdsValidation, apiVX.getBoardGroupPerms)
+	groupPerms.Put("/", apiVX.urlIdsValidation, apiVX.updateBoardGroupPerms)
+	groupPerms.Delete("/", apiVX.urlIdsValidation, apiVX.deleteBoardGroupPerms)
+
+	owner := router.Group("/projects/:pid/boards/:bid/owner", apiVX.userIdentity)
+	owner.Post("/", apiVX.urlIdsValidation, apiVX.transferBoard)
 }
 
 func (apiVX *ApiV1) getBoardPerms(ctx *fiber.Ctx) error {
@@ -159,3 +170,250 @@ func (apiVX *ApiV1) updateBoardPerms(ctx *fiber.Ctx) error {
 		permissions)
 	return Send(ctx, response)
 }
+
+func (apiVX *ApiV1) denyBoardPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	memberId, err := strconv.Atoi(ctx.Params("member_id"))
+	if err != nil || memberId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid memberId")
+		return Send(ctx, response)
+	}
+
+	permissions := &models.Permission{}
+	if err := ctx.BodyParser(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardPerms.Deny(userId, projectId, boardId, memberId, permissions)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteBoardDeny(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	memberId, err := strconv.Atoi(ctx.Params("member_id"))
+	if err != nil || memberId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid memberId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardPerms.DeleteDeny(userId, projectId, boardId, memberId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getBoardGroupPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardPerms.GetForGroup(userId, projectId, boardId, groupId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createBoardGroupPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	permissions := &models.Permission{}
+	if err := ctx.BodyParser(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardPerms.CreateForGroup(userId, projectId, boardId, groupId, permissions)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) updateBoardGroupPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	permissions := &models.UpdatePermission{}
+	if err := ctx.BodyParser(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardPerms.UpdateForGroup(userId, projectId, boardId, groupId, permissions)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteBoardGroupPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardPerms.DeleteForGroup(userId, projectId, boardId, groupId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) transferBoard(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	input := &models.TransferOwnership{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardPerms.TransferOwnership(userId, projectId, boardId, input)
+	return Send(ctx, response)
+}
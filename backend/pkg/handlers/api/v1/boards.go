@@ -13,9 +13,16 @@ func (apiVX *ApiV1) registerBoardsHandlers(router fiber.Router) {
 	group.Get("/", apiVX.urlIdsValidation, apiVX.getBoards)
 	group.Post("/", apiVX.urlIdsValidation, apiVX.createBoard)
 	group.Get("/:bid", apiVX.urlIdsValidation, apiVX.getBoard)
+	group.Get("/:bid/snapshot", apiVX.urlIdsValidation, apiVX.getBoardSnapshot)
+	group.Post("/:bid/tasks/bulk", apiVX.urlIdsValidation, apiVX.bulkTasks)
 	group.Get("/:bid/members", apiVX.urlIdsValidation, apiVX.getBoardMembers)
+	group.Get("/:bid/members/:uid/effective-permissions", apiVX.urlIdsValidation, apiVX.getBoardEffectivePerms)
 	group.Put("/:bid", apiVX.urlIdsValidation, apiVX.updateBoard)
 	group.Delete("/:bid", apiVX.urlIdsValidation, apiVX.deleteBoard)
+	group.Post("/:bid/archive", apiVX.urlIdsValidation, apiVX.archiveBoard)
+	group.Post("/:bid/unarchive", apiVX.urlIdsValidation, apiVX.unarchiveBoard)
+	group.Post("/:bid/clone", apiVX.urlIdsValidation, apiVX.cloneBoard)
+	group.Post("/import/trello", apiVX.urlIdsValidation, apiVX.importTrelloBoard)
 }
 
 func (apiVX *ApiV1) getBoards(ctx *fiber.Ctx) error {
@@ -32,7 +39,8 @@ func (apiVX *ApiV1) getBoards(ctx *fiber.Ctx) error {
 		return Send(ctx, response)
 	}
 
-	response = apiVX.services.Board.GetAll(userId, projectId)
+	archived := ctx.Query("archived") == "true"
+	response = apiVX.services.Board.GetAll(userId, projectId, archived)
 	return Send(ctx, response)
 }
 
@@ -60,6 +68,62 @@ func (apiVX *ApiV1) getBoard(ctx *fiber.Ctx) error {
 	return Send(ctx, response)
 }
 
+func (apiVX *ApiV1) getBoardSnapshot(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	archived := ctx.Query("archived") == "true"
+	response = apiVX.services.Task.GetSnapshot(userId, projectId, boardId, ctx.Query("filter"),
+		ctx.Query("sort"), archived)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) bulkTasks(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	input := &models.BulkTasks{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Task.Bulk(userId, projectId, boardId, input)
+	return Send(ctx, response)
+}
+
 func (apiVX *ApiV1) createBoard(ctx *fiber.Ctx) error {
 	response := &models.ApiResponse{}
 	userId, err := getUserId(ctx)
@@ -171,3 +235,126 @@ func (apiVX *ApiV1) getBoardMembers(ctx *fiber.Ctx) error {
 	response = apiVX.services.Board.GetMembers(userId, projectId, boardId)
 	return Send(ctx, response)
 }
+
+func (apiVX *ApiV1) getBoardEffectivePerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	memberId, err := strconv.Atoi(ctx.Params("uid"))
+	if err != nil || memberId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid memberId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardPerms.GetEffective(userId, projectId, boardId, memberId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) archiveBoard(ctx *fiber.Ctx) error {
+	return apiVX.setBoardArchived(ctx, true)
+}
+
+func (apiVX *ApiV1) unarchiveBoard(ctx *fiber.Ctx) error {
+	return apiVX.setBoardArchived(ctx, false)
+}
+
+func (apiVX *ApiV1) setBoardArchived(ctx *fiber.Ctx, archived bool) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Board.SetArchived(userId, projectId, boardId, archived)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) cloneBoard(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.CloneBoard{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Board.Clone(userId, projectId, boardId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) importTrelloBoard(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	export := &models.TrelloBoard{}
+	if err := ctx.BodyParser(export); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	title := ctx.Query("title")
+	dryRun := ctx.Query("dryRun") == "true"
+	response = apiVX.services.Board.ImportTrello(userId, projectId, title, export, dryRun)
+	return Send(ctx, response)
+}
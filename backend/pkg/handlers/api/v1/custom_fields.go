@@ -0,0 +1,247 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerCustomFieldsHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/boards/:bid/fields", apiVX.userIdentity)
+	group.Get("/", apiVX.urlIdsValidation, apiVX.getCustomFields)
+	group.Post("/", apiVX.urlIdsValidation, apiVX.createCustomField)
+	group.Put("/:fid", apiVX.urlIdsValidation, apiVX.updateCustomField)
+	group.Delete("/:fid", apiVX.urlIdsValidation, apiVX.deleteCustomField)
+
+	values := router.Group("/projects/:pid/boards/:bid/lists/:lid/tasks/:tid/fields", apiVX.userIdentity)
+	values.Put("/:fid", apiVX.urlIdsValidation, apiVX.setCustomValue)
+	values.Delete("/:fid", apiVX.urlIdsValidation, apiVX.deleteCustomValue)
+}
+
+func (apiVX *ApiV1) getCustomFields(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.CustomField.GetAll(userId, projectId, boardId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createCustomField(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	field := &models.CustomField{}
+	if err := ctx.BodyParser(field); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(field); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.CustomField.Create(userId, projectId, boardId, field)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) updateCustomField(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.UpdateCustomField{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	fieldId, err := strconv.Atoi(ctx.Params("fid"))
+	if err != nil || fieldId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid fieldId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.CustomField.Update(userId, projectId, boardId, fieldId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteCustomField(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	fieldId, err := strconv.Atoi(ctx.Params("fid"))
+	if err != nil || fieldId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid fieldId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.CustomField.Delete(userId, projectId, boardId, fieldId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) setCustomValue(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	value := &models.CustomValue{}
+	if err := ctx.BodyParser(value); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(value); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	fieldId, err := strconv.Atoi(ctx.Params("fid"))
+	if err != nil || fieldId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid fieldId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.CustomField.SetValue(userId, projectId, boardId, listId, taskId, fieldId, value)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteCustomValue(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	fieldId, err := strconv.Atoi(ctx.Params("fid"))
+	if err != nil || fieldId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid fieldId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.CustomField.DeleteValue(userId, projectId, boardId, listId, taskId, fieldId)
+	return Send(ctx, response)
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerExportHandlers(router fiber.Router) {
+	group := router.Group("/projects", apiVX.userIdentity)
+	group.Get("/:pid/export", apiVX.exportProject)
+	group.Post("/import", apiVX.importProject)
+}
+
+// exportProject sends the document itself rather than an api response so
+// that it can be passed to the import as is.
+func (apiVX *ApiV1) exportProject(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Export.Export(userId, projectId)
+	if response.Code != fiber.StatusOK {
+		return Send(ctx, response)
+	}
+
+	ctx.Attachment(fmt.Sprintf("project-%d.json", projectId))
+	return ctx.JSON(response.Data)
+}
+
+func (apiVX *ApiV1) importProject(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	archive := &models.ProjectExport{}
+	if err := ctx.BodyParser(archive); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(archive); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Export.Import(userId, archive)
+	return Send(ctx, response)
+}
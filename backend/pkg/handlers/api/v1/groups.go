@@ -0,0 +1,236 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerGroupsHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/groups", apiVX.userIdentity)
+	group.Get("/", apiVX.getGroups)
+	group.Post("/", apiVX.createGroup)
+	group.Get("/:gid", apiVX.getGroup)
+	group.Put("/:gid", apiVX.updateGroup)
+	group.Delete("/:gid", apiVX.deleteGroup)
+	group.Get("/:gid/members", apiVX.getGroupMembers)
+	group.Post("/:gid/members/:member_id", apiVX.addGroupMember)
+	group.Delete("/:gid/members/:member_id", apiVX.deleteGroupMember)
+}
+
+func (apiVX *ApiV1) getGroups(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Group.GetAll(userId, projectId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getGroup(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Group.GetById(userId, projectId, groupId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createGroup(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	input := &models.Group{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Group.Create(userId, projectId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) updateGroup(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	input := &models.UpdateGroup{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Group.Update(userId, projectId, groupId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteGroup(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Group.Delete(userId, projectId, groupId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getGroupMembers(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Group.GetMembers(userId, projectId, groupId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) addGroupMember(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	memberNickname := ctx.Params("member_id")
+	if memberNickname == "" {
+		response.Error(fiber.StatusBadRequest, "memberNickname is empty")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Group.AddMember(userId, projectId, groupId, memberNickname)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteGroupMember(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	memberId, err := strconv.Atoi(ctx.Params("member_id"))
+	if err != nil || memberId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid memberId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Group.DeleteMember(userId, projectId, groupId, memberId)
+	return Send(ctx, response)
+}
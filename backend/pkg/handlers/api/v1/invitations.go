@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerInvitationsHandlers(router fiber.Router) {
+	project := router.Group("/projects/:pid/invitations", apiVX.userIdentity)
+	project.Get("/", apiVX.getProjectInvitations)
+	project.Post("/", apiVX.inviteByEmail)
+	project.Delete("/:iid", apiVX.revokeInvitation)
+
+	user := router.Group("/users/invitations", apiVX.userIdentity)
+	user.Get("/", apiVX.getUserInvitations)
+	user.Post("/:iid/accept", apiVX.acceptInvitation)
+	user.Post("/:iid/decline", apiVX.declineInvitation)
+}
+
+func (apiVX *ApiV1) getProjectInvitations(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Invitation.GetAll(userId, projectId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) inviteByEmail(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	input := &models.EmailInvitation{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+	if input.Permissions == nil {
+		input.Permissions = &models.Permission{}
+	}
+
+	response = apiVX.services.ProjectPerms.InviteByEmail(userId, projectId, input.Email, input.Permissions)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) revokeInvitation(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	invitationId, err := strconv.Atoi(ctx.Params("iid"))
+	if err != nil || invitationId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid invitationId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Invitation.Delete(userId, projectId, invitationId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getUserInvitations(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Invitation.GetAllForUser(userId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) acceptInvitation(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	invitationId, err := strconv.Atoi(ctx.Params("iid"))
+	if err != nil || invitationId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid invitationId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Invitation.Accept(userId, invitationId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) declineInvitation(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	invitationId, err := strconv.Atoi(ctx.Params("iid"))
+	if err != nil || invitationId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid invitationId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Invitation.Decline(userId, invitationId)
+	return Send(ctx, response)
+}
@@ -186,7 +186,7 @@ func (apiVX *ApiV1) createLabelInTask(ctx *fiber.Ctx) error {
 		return Send(ctx, response)
 	}
 
-	response = apiVX.services.Label.CreateInTask(userId, projectId, boardId, taskId, labelId)
+	response = apiVX.services.Label.CreateInTask(userId, projectId, boardId, listId, taskId, labelId)
 	return Send(ctx, response)
 }
 
@@ -269,7 +269,7 @@ func (apiVX *ApiV1) deleLabelteInTask(ctx *fiber.Ctx) error {
 		return Send(ctx, response)
 	}
 
-	response = apiVX.services.Label.DeleteInTask(userId, projectId, boardId, taskId, labelId)
+	response = apiVX.services.Label.DeleteInTask(userId, projectId, boardId, listId, taskId, labelId)
 	return Send(ctx, response)
 }
 
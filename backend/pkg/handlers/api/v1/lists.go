@@ -15,6 +15,9 @@ func (apiVX *ApiV1) registerListsHandlers(router fiber.Router) {
 	group.Get("/:lid", apiVX.urlIdsValidation, apiVX.getList)
 	group.Patch("/:lid", apiVX.urlIdsValidation, apiVX.updateList)
 	group.Delete("/:lid", apiVX.urlIdsValidation, apiVX.deleteList)
+	group.Post("/:lid/archive", apiVX.urlIdsValidation, apiVX.archiveList)
+	group.Post("/:lid/unarchive", apiVX.urlIdsValidation, apiVX.unarchiveList)
+	group.Post("/:lid/move", apiVX.urlIdsValidation, apiVX.moveList)
 }
 
 func (apiVX *ApiV1) getLists(ctx *fiber.Ctx) error {
@@ -37,7 +40,8 @@ func (apiVX *ApiV1) getLists(ctx *fiber.Ctx) error {
 		return Send(ctx, response)
 	}
 
-	response = apiVX.services.TaskList.GetAll(userId, projectId, boardId)
+	archived := ctx.Query("archived") == "true"
+	response = apiVX.services.TaskList.GetAll(userId, projectId, boardId, archived)
 	return Send(ctx, response)
 }
 
@@ -176,3 +180,82 @@ func (apiVX *ApiV1) deleteList(ctx *fiber.Ctx) error {
 	response = apiVX.services.TaskList.Delete(userId, projectId, boardId, listId)
 	return Send(ctx, response)
 }
+
+func (apiVX *ApiV1) archiveList(ctx *fiber.Ctx) error {
+	return apiVX.setListArchived(ctx, true)
+}
+
+func (apiVX *ApiV1) unarchiveList(ctx *fiber.Ctx) error {
+	return apiVX.setListArchived(ctx, false)
+}
+
+func (apiVX *ApiV1) setListArchived(ctx *fiber.Ctx, archived bool) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.TaskList.SetArchived(userId, projectId, boardId, listId, archived)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) moveList(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	input := &models.MoveTaskList{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.TaskList.Move(userId, projectId, boardId, listId, input)
+	return Send(ctx, response)
+}
@@ -0,0 +1,215 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerNotificationsHandlers(router fiber.Router) {
+	group := router.Group("/users/notifications", apiVX.userIdentity)
+	group.Get("/", apiVX.getNotifications)
+	group.Post("/read", apiVX.markAllNotificationsRead)
+	group.Post("/:nid/read", apiVX.markNotificationRead)
+	group.Get("/preferences", apiVX.getNotificationPreferences)
+	group.Put("/preferences", apiVX.updateNotificationPreferences)
+	group.Get("/email", apiVX.getMailSettings)
+	group.Put("/email", apiVX.updateMailSettings)
+
+	watches := router.Group("/users/watches", apiVX.userIdentity)
+	watches.Get("/", apiVX.getWatches)
+	watches.Post("/", apiVX.watch)
+	watches.Delete("/:type/:id", apiVX.unwatch)
+
+	// Linked from the messages, the token stands in for the session.
+	router.Get("/users/unsubscribe", apiVX.unsubscribe)
+}
+
+func (apiVX *ApiV1) getNotifications(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	var limit, offset int
+	params := map[string]*int{
+		"limit":  &limit,
+		"offset": &offset,
+	}
+	for name, value := range params {
+		if ctx.Query(name) == "" {
+			continue
+		}
+		if *value, err = strconv.Atoi(ctx.Query(name)); err != nil {
+			response.Error(fiber.StatusBadRequest, "Invalid "+name)
+			return Send(ctx, response)
+		}
+	}
+
+	unread := ctx.Query("unread") == "true"
+	response = apiVX.services.Notification.GetAll(userId, unread, limit, offset)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) markAllNotificationsRead(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Notification.MarkAllRead(userId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) markNotificationRead(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	notificationId, err := strconv.Atoi(ctx.Params("nid"))
+	if err != nil || notificationId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid notificationId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Notification.MarkRead(userId, notificationId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getNotificationPreferences(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Notification.GetPreferences(userId)
+	return Send(ctx, response)
+}
+
+// updateNotificationPreferences takes a map of the events to change, the
+// others stay as they are.
+func (apiVX *ApiV1) updateNotificationPreferences(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	preferences := make(map[string]bool)
+	if err := ctx.BodyParser(&preferences); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Notification.UpdatePreferences(userId, preferences)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getWatches(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Notification.GetWatches(userId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) watch(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.Watch{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Notification.Watch(userId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) unwatch(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	objectId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil || objectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid objectId")
+		return Send(ctx, response)
+	}
+
+	watch := &models.Watch{ObjectType: ctx.Params("type"), ObjectId: objectId}
+	response = apiVX.services.Notification.Unwatch(userId, watch)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getMailSettings(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Mail.GetSettings(userId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) updateMailSettings(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.UpdateMailSettings{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Mail.UpdateSettings(userId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) unsubscribe(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := strconv.Atoi(ctx.Query("user"))
+	if err != nil || userId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid userId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Mail.Unsubscribe(userId, ctx.Query("list"), ctx.Query("token"))
+	return Send(ctx, response)
+}
@@ -14,6 +14,16 @@ func (apiVX *ApiV1) registerProjectPermsHandlers(router fiber.Router) {
 	group.Get("/", apiVX.getProjectPerms)
 	group.Put("/", apiVX.updateProjectPerms)
 	group.Delete("/", apiVX.deleteProjectPerms)
+
+	groupPerms := router.Group("/projects/:pid/groups/:gid/permissions", apiVX.userIdentity)
+	groupPerms.Post("/", apiVX.createProjectGroupPerms)
+	groupPerms.Get("/", apiVX.getProjectGroupPerms)
+	groupPerms.Put("/", apiVX.updateProjectGroupPerms)
+	groupPerms.Delete("/", apiVX.deleteProjectGroupPerms)
+
+	owner := router.Group("/projects/:pid/owner", apiVX.userIdentity)
+	owner.Post("/", apiVX.transferProject)
+	owner.Get("/transfers", apiVX.getProjectTransfers)
 }
 
 func (apiVX *ApiV1) getProjectPerms(ctx *fiber.Ctx) error {
@@ -135,3 +145,167 @@ func (apiVX *ApiV1) updateProjectPerms(ctx *fiber.Ctx) error {
 		permissions)
 	return Send(ctx, response)
 }
+
+func (apiVX *ApiV1) getProjectGroupPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.ProjectPerms.GetForGroup(userId, projectId, groupId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createProjectGroupPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	permissions := &models.Permission{}
+	if err := ctx.BodyParser(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.ProjectPerms.CreateForGroup(userId, projectId, groupId, permissions)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) updateProjectGroupPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	permissions := &models.UpdatePermission{}
+	if err := ctx.BodyParser(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(permissions); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.ProjectPerms.UpdateForGroup(userId, projectId, groupId, permissions)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteProjectGroupPerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	groupId, err := strconv.Atoi(ctx.Params("gid"))
+	if err != nil || groupId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid groupId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.ProjectPerms.DeleteForGroup(userId, projectId, groupId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) transferProject(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	input := &models.TransferOwnership{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.ProjectPerms.TransferOwnership(userId, projectId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getProjectTransfers(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.ProjectPerms.GetTransfers(userId, projectId)
+	return Send(ctx, response)
+}
@@ -14,6 +14,7 @@ func (apiVX *ApiV1) registerProjectsHandlers(router fiber.Router) {
 	group.Post("/", apiVX.createProject)
 	group.Get("/:pid", apiVX.getProject)
 	group.Get("/:pid/members", apiVX.getProjectMembers)
+	group.Get("/:pid/members/:uid/effective-permissions", apiVX.getProjectEffectivePerms)
 	group.Put("/:pid", apiVX.updateProject)
 	group.Delete("/:pid", apiVX.deleteProject)
 }
@@ -136,3 +137,27 @@ func (apiVX *ApiV1) getProjectMembers(ctx *fiber.Ctx) error {
 	response = apiVX.services.Project.GetMembers(userId, projectId)
 	return Send(ctx, response)
 }
+
+func (apiVX *ApiV1) getProjectEffectivePerms(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	memberId, err := strconv.Atoi(ctx.Params("uid"))
+	if err != nil || memberId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid memberId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.ProjectPerms.GetEffective(userId, projectId, memberId)
+	return Send(ctx, response)
+}
@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerRelationsHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/boards/:bid/lists/:lid/tasks/:tid/relations", apiVX.userIdentity)
+	group.Get("/", apiVX.urlIdsValidation, apiVX.getRelations)
+	group.Post("/", apiVX.urlIdsValidation, apiVX.createRelation)
+	group.Delete("/:rid", apiVX.urlIdsValidation, apiVX.deleteRelation)
+}
+
+func (apiVX *ApiV1) getRelations(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Relation.GetAll(userId, projectId, boardId, listId, taskId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createRelation(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	input := &models.CreateTaskRelation{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Relation.Create(userId, projectId, boardId, listId, taskId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteRelation(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	relationId, err := strconv.Atoi(ctx.Params("rid"))
+	if err != nil || relationId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid relationId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Relation.Delete(userId, projectId, boardId, listId, taskId, relationId)
+	return Send(ctx, response)
+}
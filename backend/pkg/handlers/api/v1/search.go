@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerSearchHandlers(router fiber.Router) {
+	group := router.Group("/search", apiVX.userIdentity)
+	group.Get("/", apiVX.search)
+}
+
+func (apiVX *ApiV1) search(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	query := &models.SearchQuery{Text: ctx.Query("q")}
+	params := map[string]*int{
+		"projectId": &query.ProjectId,
+		"boardId":   &query.BoardId,
+		"limit":     &query.Limit,
+		"offset":    &query.Offset,
+	}
+	for name, value := range params {
+		if ctx.Query(name) == "" {
+			continue
+		}
+		if *value, err = strconv.Atoi(ctx.Query(name)); err != nil {
+			response.Error(fiber.StatusBadRequest, "Invalid "+name)
+			return Send(ctx, response)
+		}
+	}
+
+	response = apiVX.services.Search.Search(userId, query)
+	return Send(ctx, response)
+}
@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/services"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	sharePasswordHeader = "X-Share-Password"
+	shareCtx            = "share"
+)
+
+func (apiVX *ApiV1) registerSharesHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/boards/:bid/shares", apiVX.userIdentity)
+	group.Get("/", apiVX.urlIdsValidation, apiVX.getBoardShares)
+	group.Post("/", apiVX.urlIdsValidation, apiVX.createBoardShare)
+	group.Delete("/:sid", apiVX.urlIdsValidation, apiVX.deleteBoardShare)
+
+	shared := router.Group("/shared/:token")
+	shared.Get("/", apiVX.shareIdentity, apiVX.getSharedBoard)
+	shared.Get("/snapshot", apiVX.shareIdentity, apiVX.getSharedSnapshot)
+	shared.Get("/lists", apiVX.shareIdentity, apiVX.getSharedLists)
+	shared.Get("/lists/:lid", apiVX.shareIdentity, apiVX.getSharedList)
+	shared.Get("/lists/:lid/tasks", apiVX.shareIdentity, apiVX.getSharedTasks)
+	shared.Get("/lists/:lid/tasks/:tid", apiVX.shareIdentity, apiVX.getSharedTask)
+	shared.Get("/lists/:lid/tasks/:tid/labels", apiVX.shareIdentity, apiVX.getSharedTaskLabels)
+	shared.Get("/labels", apiVX.shareIdentity, apiVX.getSharedLabels)
+}
+
+func (apiVX *ApiV1) getBoardShares(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardShare.GetAll(userId, projectId, boardId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createBoardShare(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	input := &models.BoardShareInput{}
+	if len(ctx.Body()) != 0 {
+		if err := ctx.BodyParser(input); err != nil {
+			response.Error(fiber.StatusBadRequest, err.Error())
+			return Send(ctx, response)
+		}
+	}
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardShare.Create(userId, projectId, boardId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteBoardShare(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	shareId, err := strconv.Atoi(ctx.Params("sid"))
+	if err != nil || shareId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid shareId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.BoardShare.Delete(userId, projectId, boardId, shareId)
+	return Send(ctx, response)
+}
+
+// shareIdentity replaces userIdentity on the public routes: the share link
+// stands in for the user and its board for the board ids of the url.
+func (apiVX *ApiV1) shareIdentity(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+
+	share, err := apiVX.services.BoardShare.ParseShareToken(ctx.Params("token"), ctx.Get(sharePasswordHeader))
+	if err != nil {
+		response.Error(fiber.StatusUnauthorized, err.Error())
+		return Send(ctx, response)
+	}
+
+	listId, _ := strconv.Atoi(ctx.Params("lid"))
+	taskId, _ := strconv.Atoi(ctx.Params("tid"))
+	urlIds := &models.UrlIds{
+		ProjectId: share.ProjectId,
+		BoardId:   share.BoardId,
+		ListId:    listId,
+		TaskId:    taskId,
+	}
+
+	response = apiVX.services.UrlValidator.Validation(urlIds)
+	if response.Code != fiber.StatusOK {
+		return Send(ctx, response)
+	}
+
+	ctx.Locals(shareCtx, share)
+	return ctx.Next()
+}
+
+func getShare(ctx *fiber.Ctx) (*models.BoardShare, int) {
+	share := ctx.Locals(shareCtx).(*models.BoardShare)
+	return share, services.GuestPrincipal(share.Id)
+}
+
+func (apiVX *ApiV1) getSharedBoard(ctx *fiber.Ctx) error {
+	share, guestId := getShare(ctx)
+	response := apiVX.services.Board.GetById(guestId, share.ProjectId, share.BoardId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSharedLists(ctx *fiber.Ctx) error {
+	share, guestId := getShare(ctx)
+	archived := ctx.Query("archived") == "true"
+	response := apiVX.services.TaskList.GetAll(guestId, share.ProjectId, share.BoardId, archived)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSharedSnapshot(ctx *fiber.Ctx) error {
+	share, guestId := getShare(ctx)
+	archived := ctx.Query("archived") == "true"
+	response := apiVX.services.Task.GetSnapshot(guestId, share.ProjectId, share.BoardId,
+		ctx.Query("filter"), ctx.Query("sort"), archived)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSharedList(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	share, guestId := getShare(ctx)
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.TaskList.GetById(guestId, share.ProjectId, share.BoardId, listId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSharedTasks(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	share, guestId := getShare(ctx)
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	archived := ctx.Query("archived") == "true"
+	response = apiVX.services.Task.GetAll(guestId, share.ProjectId, share.BoardId, listId,
+		ctx.Query("filter"), ctx.Query("sort"), archived)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSharedTask(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	share, guestId := getShare(ctx)
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Task.GetById(guestId, share.ProjectId, share.BoardId, listId, taskId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSharedTaskLabels(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	share, guestId := getShare(ctx)
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Label.GetAllInTask(guestId, share.ProjectId, share.BoardId, taskId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSharedLabels(ctx *fiber.Ctx) error {
+	share, guestId := getShare(ctx)
+	response := apiVX.services.Label.GetAll(guestId, share.ProjectId, share.BoardId)
+	return Send(ctx, response)
+}
@@ -0,0 +1,283 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerSprintsHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/boards/:bid/sprints", apiVX.userIdentity)
+	group.Get("/", apiVX.urlIdsValidation, apiVX.getSprints)
+	group.Post("/", apiVX.urlIdsValidation, apiVX.createSprint)
+	group.Get("/:sid", apiVX.urlIdsValidation, apiVX.getSprint)
+	group.Put("/:sid", apiVX.urlIdsValidation, apiVX.updateSprint)
+	group.Delete("/:sid", apiVX.urlIdsValidation, apiVX.deleteSprint)
+	group.Post("/:sid/start", apiVX.urlIdsValidation, apiVX.startSprint)
+	group.Post("/:sid/complete", apiVX.urlIdsValidation, apiVX.completeSprint)
+	group.Get("/:sid/burndown", apiVX.urlIdsValidation, apiVX.getSprintBurndown)
+}
+
+func (apiVX *ApiV1) getSprints(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Sprint.GetAll(userId, projectId, boardId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSprint(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	sprintId, err := strconv.Atoi(ctx.Params("sid"))
+	if err != nil || sprintId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid sprintId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Sprint.GetById(userId, projectId, boardId, sprintId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createSprint(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	sprint := &models.Sprint{}
+	if err := ctx.BodyParser(sprint); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(sprint); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Sprint.Create(userId, projectId, boardId, sprint)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) updateSprint(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.UpdateSprint{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	sprintId, err := strconv.Atoi(ctx.Params("sid"))
+	if err != nil || sprintId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid sprintId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Sprint.Update(userId, projectId, boardId, sprintId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteSprint(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	sprintId, err := strconv.Atoi(ctx.Params("sid"))
+	if err != nil || sprintId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid sprintId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Sprint.Delete(userId, projectId, boardId, sprintId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) startSprint(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	sprintId, err := strconv.Atoi(ctx.Params("sid"))
+	if err != nil || sprintId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid sprintId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Sprint.Start(userId, projectId, boardId, sprintId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) completeSprint(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.CompleteSprint{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	sprintId, err := strconv.Atoi(ctx.Params("sid"))
+	if err != nil || sprintId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid sprintId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Sprint.Complete(userId, projectId, boardId, sprintId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getSprintBurndown(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	sprintId, err := strconv.Atoi(ctx.Params("sid"))
+	if err != nil || sprintId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid sprintId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Sprint.Burndown(userId, projectId, boardId, sprintId)
+	return Send(ctx, response)
+}
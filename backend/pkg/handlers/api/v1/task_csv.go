@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerTaskCsvHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/boards/:bid/tasks.csv", apiVX.userIdentity)
+	group.Get("/", apiVX.urlIdsValidation, apiVX.exportTasksCsv)
+	group.Post("/", apiVX.urlIdsValidation, apiVX.importTasksCsv)
+}
+
+func (apiVX *ApiV1) exportTasksCsv(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.TaskCsv.Export(userId, projectId, boardId)
+	if response.Code != fiber.StatusOK {
+		return Send(ctx, response)
+	}
+
+	ctx.Attachment(fmt.Sprintf("board-%d-tasks.csv", boardId))
+	ctx.Type("csv", "utf-8")
+	return ctx.SendString(response.Data.(string))
+}
+
+// importTasksCsv takes the CSV as the request body.
+func (apiVX *ApiV1) importTasksCsv(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	createLists := ctx.Query("createLists") == "true"
+	response = apiVX.services.TaskCsv.Import(userId, projectId, boardId, ctx.Body(), createLists)
+	return Send(ctx, response)
+}
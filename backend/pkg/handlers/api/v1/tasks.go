@@ -16,6 +16,7 @@ func (apiVX *ApiV1) registerTasksHandlers(router fiber.Router) {
 	group.Get("/:tid", apiVX.urlIdsValidation, apiVX.getTask)
 	group.Put("/:tid", apiVX.urlIdsValidation, apiVX.updateTask)
 	group.Delete("/:tid", apiVX.urlIdsValidation, apiVX.deleteTask)
+	group.Post("/:tid/move", apiVX.urlIdsValidation, apiVX.moveTask)
 }
 
 func (apiVX *ApiV1) getTasks(ctx *fiber.Ctx) error {
@@ -44,7 +45,9 @@ func (apiVX *ApiV1) getTasks(ctx *fiber.Ctx) error {
 		return Send(ctx, response)
 	}
 
-	response = apiVX.services.Task.GetAll(userId, projectId, boardId, listId)
+	archived := ctx.Query("archived") == "true"
+	response = apiVX.services.Task.GetAll(userId, projectId, boardId, listId, ctx.Query("filter"),
+		ctx.Query("sort"), archived)
 	return Send(ctx, response)
 }
 
@@ -207,3 +210,50 @@ func (apiVX *ApiV1) deleteTask(ctx *fiber.Ctx) error {
 	response = apiVX.services.Task.Delete(userId, projectId, boardId, listId, taskId)
 	return Send(ctx, response)
 }
+
+func (apiVX *ApiV1) moveTask(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	input := &models.MoveTask{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Task.Move(userId, projectId, boardId, listId, taskId, input)
+	return Send(ctx, response)
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerTemplatesHandlers(router fiber.Router) {
+	router.Post("/projects/:pid/boards/:bid/template", apiVX.userIdentity,
+		apiVX.urlIdsValidation, apiVX.createTemplate)
+
+	group := router.Group("/projects/:pid/templates", apiVX.userIdentity)
+	group.Get("/", apiVX.getTemplates)
+	group.Get("/:tmid", apiVX.getTemplate)
+	group.Delete("/:tmid", apiVX.deleteTemplate)
+}
+
+func (apiVX *ApiV1) createTemplate(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.SaveTemplate{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Template.Create(userId, projectId, boardId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getTemplates(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Template.GetAll(userId, projectId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getTemplate(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	templateId, err := strconv.Atoi(ctx.Params("tmid"))
+	if err != nil || templateId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid templateId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Template.GetById(userId, projectId, templateId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteTemplate(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	templateId, err := strconv.Atoi(ctx.Params("tmid"))
+	if err != nil || templateId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid templateId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Template.Delete(userId, projectId, templateId)
+	return Send(ctx, response)
+}
@@ -0,0 +1,343 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerTimeHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/boards/:bid/lists/:lid/tasks/:tid/time", apiVX.userIdentity)
+	group.Get("/", apiVX.urlIdsValidation, apiVX.getTaskTime)
+	group.Put("/estimate", apiVX.urlIdsValidation, apiVX.updateTimeEstimate)
+	group.Post("/logs", apiVX.urlIdsValidation, apiVX.createTimeLog)
+	group.Delete("/logs/:logid", apiVX.urlIdsValidation, apiVX.deleteTimeLog)
+	group.Post("/timer", apiVX.urlIdsValidation, apiVX.startTimer)
+
+	tracking := router.Group("/time", apiVX.userIdentity)
+	tracking.Get("/timer", apiVX.getTimer)
+	tracking.Post("/timer/stop", apiVX.stopTimer)
+	tracking.Get("/report", apiVX.getTimeReport)
+	tracking.Get("/report.csv", apiVX.exportTimeReport)
+}
+
+func (apiVX *ApiV1) getTaskTime(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Time.Get(userId, projectId, boardId, listId, taskId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) updateTimeEstimate(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	input := &models.UpdateTimeEstimate{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Time.UpdateEstimate(userId, projectId, boardId, listId, taskId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createTimeLog(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	input := &models.CreateTimeLog{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Time.CreateLog(userId, projectId, boardId, listId, taskId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteTimeLog(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	logId, err := strconv.Atoi(ctx.Params("logid"))
+	if err != nil || logId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid logId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Time.DeleteLog(userId, projectId, boardId, listId, taskId, logId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) startTimer(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	listId, err := strconv.Atoi(ctx.Params("lid"))
+	if err != nil || listId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid listId")
+		return Send(ctx, response)
+	}
+
+	taskId, err := strconv.Atoi(ctx.Params("tid"))
+	if err != nil || taskId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid taskId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Time.StartTimer(userId, projectId, boardId, listId, taskId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getTimer(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Time.GetTimer(userId)
+	return Send(ctx, response)
+}
+
+// stopTimer takes an optional note for the logged time.
+func (apiVX *ApiV1) stopTimer(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.StopTimer{}
+	if len(ctx.Body()) != 0 {
+		if err := ctx.BodyParser(input); err != nil {
+			response.Error(fiber.StatusBadRequest, err.Error())
+			return Send(ctx, response)
+		}
+	}
+
+	response = apiVX.services.Time.StopTimer(userId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getTimeReport(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	query, err := timeReportQuery(ctx)
+	if err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Time.Report(userId, query)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) exportTimeReport(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	query, err := timeReportQuery(ctx)
+	if err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Time.ExportReport(userId, query)
+	if response.Code != fiber.StatusOK {
+		return Send(ctx, response)
+	}
+
+	ctx.Attachment("time-report.csv")
+	ctx.Type("csv", "utf-8")
+	return ctx.SendString(response.Data.(string))
+}
+
+// timeReportQuery takes the ids and the unix time range from the query string.
+func timeReportQuery(ctx *fiber.Ctx) (*models.TimeReportQuery, error) {
+	query := &models.TimeReportQuery{}
+	params := map[string]*int{
+		"projectId": &query.ProjectId,
+		"boardId":   &query.BoardId,
+		"userId":    &query.UserId,
+	}
+	for name, value := range params {
+		if ctx.Query(name) == "" {
+			continue
+		}
+		var err error
+		if *value, err = strconv.Atoi(ctx.Query(name)); err != nil {
+			return nil, fmt.Errorf("Invalid %s", name)
+		}
+	}
+
+	dates := map[string]*int64{
+		"from": &query.From,
+		"to":   &query.To,
+	}
+	for name, value := range dates {
+		if ctx.Query(name) == "" {
+			continue
+		}
+		var err error
+		if *value, err = strconv.ParseInt(ctx.Query(name), 10, 64); err != nil {
+			return nil, fmt.Errorf("Invalid %s", name)
+		}
+	}
+	return query, nil
+}
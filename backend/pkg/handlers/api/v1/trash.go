@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var trashItemTypes = map[string]string{
+	"boards": models.TrashBoard,
+	"lists":  models.TrashList,
+	"tasks":  models.TrashTask,
+}
+
+func (apiVX *ApiV1) registerTrashHandlers(router fiber.Router) {
+	project := router.Group("/projects/:pid/trash", apiVX.userIdentity)
+	project.Get("/", apiVX.getTrash)
+	project.Post("/:type/:iid/restore", apiVX.restoreTrashItem)
+
+	user := router.Group("/trash/projects", apiVX.userIdentity)
+	user.Get("/", apiVX.getTrashProjects)
+	user.Post("/:pid/restore", apiVX.restoreTrashProject)
+}
+
+func (apiVX *ApiV1) getTrash(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Trash.GetAll(userId, projectId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) restoreTrashItem(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	itemType, ok := trashItemTypes[ctx.Params("type")]
+	if !ok {
+		response.Error(fiber.StatusBadRequest, "Invalid item type")
+		return Send(ctx, response)
+	}
+
+	itemId, err := strconv.Atoi(ctx.Params("iid"))
+	if err != nil || itemId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid itemId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Trash.Restore(userId, projectId, itemType, itemId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getTrashProjects(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Trash.GetProjects(userId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) restoreTrashProject(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.Trash.RestoreProject(userId, projectId)
+	return Send(ctx, response)
+}
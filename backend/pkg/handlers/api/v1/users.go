@@ -2,10 +2,12 @@ package v1
 
 import (
 	"errors"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/services"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/gofiber/fiber/v2"
@@ -19,6 +21,8 @@ func (apiVX *ApiV1) registerUsersHandlers(router fiber.Router) {
 	group.Post("/signin", apiVX.signIn)
 	group.Get("/signout", apiVX.userIdentity, apiVX.signOut)
 	group.Put("/update", apiVX.userIdentity, apiVX.update)
+	group.Put("/avatar", apiVX.userIdentity, apiVX.uploadAvatar)
+	group.Get("/:uid/avatar/:version", apiVX.getAvatar)
 }
 
 func (apiVX *ApiV1) getUsers(ctx *fiber.Ctx) error {
@@ -63,6 +67,61 @@ func (apiVX *ApiV1) update(ctx *fiber.Ctx) error {
 	return Send(ctx, response)
 }
 
+// uploadAvatar takes the image as the "file" field of a multipart form.
+func (apiVX *ApiV1) uploadAvatar(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	id, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusUnauthorized, err.Error())
+		return Send(ctx, response)
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		response.Error(fiber.StatusBadRequest, "Invalid file")
+		return Send(ctx, response)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+	defer file.Close()
+
+	response = apiVX.services.Avatar.Upload(id, file)
+	return Send(ctx, response)
+}
+
+// getAvatar is public so that the avatar URL works in an img tag. The
+// version changes with every upload, hence the long caching.
+func (apiVX *ApiV1) getAvatar(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := strconv.Atoi(ctx.Params("uid"))
+	if err != nil || userId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid userId")
+		return Send(ctx, response)
+	}
+
+	size := services.DefaultAvatarSize
+	if param := ctx.Query("size"); param != "" {
+		size, err = strconv.Atoi(param)
+		if err != nil {
+			response.Error(fiber.StatusBadRequest, "Invalid size")
+			return Send(ctx, response)
+		}
+	}
+
+	response = apiVX.services.Avatar.Get(userId, ctx.Params("version"), size)
+	if response.Code != fiber.StatusOK {
+		return Send(ctx, response)
+	}
+
+	ctx.Set(fiber.HeaderContentType, "image/png")
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=31536000, immutable")
+	return ctx.SendStream(response.Data.(io.ReadCloser))
+}
+
 func (apiVX *ApiV1) signIn(ctx *fiber.Ctx) error {
 	response := &models.ApiResponse{}
 	type signInInput struct {
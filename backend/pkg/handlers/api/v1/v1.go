@@ -32,6 +32,21 @@ func (apiVX *ApiV1) RegisterHandlers(router fiber.Router) {
 	apiVX.registerTasksHandlers(v1)
 	apiVX.registerUsersHandlers(v1)
 	apiVX.registerLabelsHandlers(v1)
+	apiVX.registerGroupsHandlers(v1)
+	apiVX.registerInvitationsHandlers(v1)
+	apiVX.registerSharesHandlers(v1)
+	apiVX.registerTemplatesHandlers(v1)
+	apiVX.registerViewsHandlers(v1)
+	apiVX.registerExportHandlers(v1)
+	apiVX.registerTaskCsvHandlers(v1)
+	apiVX.registerSearchHandlers(v1)
+	apiVX.registerTrashHandlers(v1)
+	apiVX.registerRelationsHandlers(v1)
+	apiVX.registerTimeHandlers(v1)
+	apiVX.registerSprintsHandlers(v1)
+	apiVX.registerCustomFieldsHandlers(v1)
+	apiVX.registerAttachmentsHandlers(v1)
+	apiVX.registerNotificationsHandlers(v1)
 }
 
 func Send(ctx *fiber.Ctx, r *models.ApiResponse) error {
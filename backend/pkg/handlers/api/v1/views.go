@@ -0,0 +1,235 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (apiVX *ApiV1) registerViewsHandlers(router fiber.Router) {
+	group := router.Group("/projects/:pid/boards/:bid/views", apiVX.userIdentity)
+	group.Get("/", apiVX.urlIdsValidation, apiVX.getViews)
+	group.Post("/", apiVX.urlIdsValidation, apiVX.createView)
+	group.Delete("/default", apiVX.urlIdsValidation, apiVX.deleteDefaultView)
+	group.Get("/:vid", apiVX.urlIdsValidation, apiVX.getView)
+	group.Put("/:vid", apiVX.urlIdsValidation, apiVX.updateView)
+	group.Delete("/:vid", apiVX.urlIdsValidation, apiVX.deleteView)
+	group.Post("/:vid/default", apiVX.urlIdsValidation, apiVX.setDefaultView)
+}
+
+func (apiVX *ApiV1) getViews(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.View.GetAll(userId, projectId, boardId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) getView(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	viewId, err := strconv.Atoi(ctx.Params("vid"))
+	if err != nil || viewId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid viewId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.View.GetById(userId, projectId, boardId, viewId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) createView(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	view := &models.BoardView{}
+	if err := ctx.BodyParser(view); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(view); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.View.Create(userId, projectId, boardId, view)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) updateView(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	input := &models.UpdateBoardView{}
+	if err := ctx.BodyParser(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	if _, err := govalidator.ValidateStruct(input); err != nil {
+		response.Error(fiber.StatusBadRequest, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	viewId, err := strconv.Atoi(ctx.Params("vid"))
+	if err != nil || viewId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid viewId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.View.Update(userId, projectId, boardId, viewId, input)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteView(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	viewId, err := strconv.Atoi(ctx.Params("vid"))
+	if err != nil || viewId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid viewId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.View.Delete(userId, projectId, boardId, viewId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) setDefaultView(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	viewId, err := strconv.Atoi(ctx.Params("vid"))
+	if err != nil || viewId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid viewId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.View.SetDefault(userId, projectId, boardId, viewId)
+	return Send(ctx, response)
+}
+
+func (apiVX *ApiV1) deleteDefaultView(ctx *fiber.Ctx) error {
+	response := &models.ApiResponse{}
+	userId, err := getUserId(ctx)
+	if err != nil {
+		response.Error(fiber.StatusInternalServerError, err.Error())
+		return Send(ctx, response)
+	}
+
+	projectId, err := strconv.Atoi(ctx.Params("pid"))
+	if err != nil || projectId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid projectId")
+		return Send(ctx, response)
+	}
+
+	boardId, err := strconv.Atoi(ctx.Params("bid"))
+	if err != nil || boardId == 0 {
+		response.Error(fiber.StatusBadRequest, "Invalid boardId")
+		return Send(ctx, response)
+	}
+
+	response = apiVX.services.View.DeleteDefault(userId, projectId, boardId)
+	return Send(ctx, response)
+}
@@ -0,0 +1,100 @@
+// Package imaging decodes uploaded images and scales them down to square
+// thumbnails without dependencies beyond the standard library.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+)
+
+var (
+	ErrInvalidImage = errors.New("Invalid image")
+	ErrTooLarge     = errors.New("Image dimensions are too large")
+)
+
+// Decode reads a PNG, JPEG or GIF image, checking the dimensions from the
+// header before the pixels are allocated.
+func Decode(data []byte, maxSide int) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if config.Width > maxSide || config.Height > maxSide {
+		return nil, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	return img, nil
+}
+
+// Square crops the centre square of the image and scales it to size×size,
+// averaging the source pixels that fall into each target pixel.
+func Square(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(image.Point{
+		X: bounds.Min.X + (bounds.Dx()-side)/2,
+		Y: bounds.Min.Y + (bounds.Dy()-side)/2,
+	})
+
+	src := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(src, src.Bounds(), img, crop.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := span(y, size, side)
+		for x := 0; x < size; x++ {
+			x0, x1 := span(x, size, side)
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(src.Pix[offset])
+					g += uint32(src.Pix[offset+1])
+					b += uint32(src.Pix[offset+2])
+					a += uint32(src.Pix[offset+3])
+					n++
+					offset += 4
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// span returns the source range covered by the target pixel i, at least one
+// pixel wide when scaling up.
+func span(i, size, side int) (int, int) {
+	from := i * side / size
+	to := (i + 1) * side / size
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
+
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
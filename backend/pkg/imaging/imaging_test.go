@@ -0,0 +1,53 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	data := encode(t, image.NewRGBA(image.Rect(0, 0, 40, 20)))
+
+	img, err := Decode(data, 64)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 40, 20), img.Bounds())
+
+	_, err = Decode(data, 32)
+	assert.Equal(t, ErrTooLarge, err)
+
+	_, err = Decode([]byte("not an image"), 64)
+	assert.Equal(t, ErrInvalidImage, err)
+}
+
+func TestSquare(t *testing.T) {
+	// A wide image: red margins around a blue centre square.
+	img := image.NewRGBA(image.Rect(0, 0, 30, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 30; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 10 && x < 20 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	for _, size := range []int{4, 10, 16} {
+		thumb := Square(img, size)
+		assert.Equal(t, image.Rect(0, 0, size, size), thumb.Bounds())
+		assert.Equal(t, color.RGBA{B: 255, A: 255}, thumb.RGBAAt(0, 0))
+		assert.Equal(t, color.RGBA{B: 255, A: 255}, thumb.RGBAAt(size-1, size-1))
+	}
+}
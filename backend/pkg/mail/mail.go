@@ -0,0 +1,178 @@
+// Package mail sends multipart text and HTML messages through a pluggable
+// transport: SMTP, or a file or stdout during development.
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// Headers are added as they are, e.g. List-Unsubscribe.
+	Headers map[string]string
+}
+
+type Transport interface {
+	Send(msg *Message) error
+}
+
+type Config struct {
+	Driver   string
+	From     string
+	Path     string
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+// New returns nil for an empty driver, which turns mail off.
+func New(cfg Config) (Transport, error) {
+	switch cfg.Driver {
+	case "":
+		return nil, nil
+	case "smtp":
+		return NewSMTP(cfg), nil
+	case "stdout":
+		return NewWriter(os.Stdout, cfg.From), nil
+	case "file":
+		file, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, err
+		}
+		return NewWriter(file, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+// Bytes renders the message as a multipart/alternative MIME document.
+func (m *Message) Bytes(from string) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeHeader := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, headerValue.Replace(value))
+	}
+	writeHeader("From", from)
+	writeHeader("To", m.To)
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", headerValue.Replace(m.Subject)))
+	writeHeader("Date", time.Now().Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+	names := make([]string, 0, len(m.Headers))
+	for name := range m.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeHeader(name, m.Headers[name])
+	}
+	writeHeader("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+// Writer writes the messages one after another, for development.
+type Writer struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+func NewWriter(w io.Writer, from string) *Writer {
+	return &Writer{w: w, from: from}
+}
+
+func (w *Writer) Send(msg *Message) error {
+	data, err := msg.Bytes(w.from)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = fmt.Fprintf(w.w, "%s\r\n\r\n", data)
+	return err
+}
+
+// headerValue keeps a value on one header line.
+var headerValue = strings.NewReplacer("\r", "", "\n", " ")
+
+var ErrQueueFull = errors.New("Mail queue is full")
+
+// Queue sends the messages in the background so that callers never wait
+// for the transport. Send fails at once when the queue is full.
+type Queue struct {
+	transport Transport
+	messages  chan *Message
+	done      chan struct{}
+	onError   func(msg *Message, err error)
+}
+
+func NewQueue(transport Transport, size int, onError func(msg *Message, err error)) *Queue {
+	q := &Queue{
+		transport: transport,
+		messages:  make(chan *Message, size),
+		done:      make(chan struct{}),
+		onError:   onError,
+	}
+	go q.run()
+	return q
+}
+
+func (q *Queue) Send(msg *Message) error {
+	select {
+	case q.messages <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close waits for the queued messages to be sent.
+func (q *Queue) Close() {
+	close(q.messages)
+	<-q.done
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+	for msg := range q.messages {
+		if err := q.transport.Send(msg); err != nil && q.onError != nil {
+			q.onError(msg, err)
+		}
+	}
+}
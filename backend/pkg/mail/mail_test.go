@@ -0,0 +1,155 @@
+package mail
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpServer is a minimal in-process SMTP server keeping what it receives.
+type smtpServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	received []string
+	wg       sync.WaitGroup
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpServer{listener: listener}
+	go s.serve()
+	return s
+}
+
+func (s *smtpServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpServer) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case command == "DATA":
+			reply("354 go ahead")
+			var data bytes.Buffer
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.mu.Lock()
+			s.received = append(s.received, data.String())
+			s.mu.Unlock()
+			reply("250 ok")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *smtpServer) close() []string {
+	s.listener.Close()
+	s.wg.Wait()
+	return s.received
+}
+
+var testTemplate = MustTemplate("test", "Hello {{.Name}}", "Hi {{.Name}}",
+	"<p>Hi {{.Name}}</p>")
+
+func TestTemplate(t *testing.T) {
+	msg, err := testTemplate.Render("a@example.com", map[string]string{"Name": "<b>Ann</b>"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello <b>Ann</b>", msg.Subject)
+	assert.Equal(t, "Hi <b>Ann</b>", msg.Text)
+	assert.Equal(t, "<p>Hi &lt;b&gt;Ann&lt;/b&gt;</p>", msg.HTML)
+}
+
+func TestSMTP(t *testing.T) {
+	server := newSMTPServer(t)
+	addr := server.listener.Addr().(*net.TCPAddr)
+	transport := NewSMTP(Config{Host: "127.0.0.1", Port: addr.Port, From: "Yak <yak@localhost>"})
+
+	msg, err := testTemplate.Render("ann@example.com", map[string]string{"Name": "Ann\r\nBcc: x"})
+	require.NoError(t, err)
+	msg.Headers = map[string]string{"List-Unsubscribe": "<http://localhost/unsubscribe>"}
+	require.NoError(t, transport.Send(msg))
+
+	received := server.close()
+	require.Len(t, received, 1)
+	parsed, err := mail.ReadMessage(strings.NewReader(received[0]))
+	require.NoError(t, err)
+	assert.Equal(t, "Yak <yak@localhost>", parsed.Header.Get("From"))
+	assert.Equal(t, "ann@example.com", parsed.Header.Get("To"))
+	assert.Equal(t, "Hello Ann Bcc: x", parsed.Header.Get("Subject"))
+	assert.Equal(t, "", parsed.Header.Get("Bcc"))
+	assert.Equal(t, "<http://localhost/unsubscribe>", parsed.Header.Get("List-Unsubscribe"))
+	body, err := ioutil.ReadAll(parsed.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "<p>Hi Ann")
+}
+
+type blockingTransport struct {
+	release chan struct{}
+	sent    []*Message
+}
+
+func (b *blockingTransport) Send(msg *Message) error {
+	<-b.release
+	b.sent = append(b.sent, msg)
+	return nil
+}
+
+func TestQueue(t *testing.T) {
+	transport := &blockingTransport{release: make(chan struct{})}
+	q := NewQueue(transport, 1, nil)
+
+	// The worker takes the first message and waits, the second one fills
+	// the queue.
+	require.NoError(t, q.Send(&Message{To: "1"}))
+	for len(q.messages) != 0 {
+		time.Sleep(time.Millisecond)
+	}
+	require.NoError(t, q.Send(&Message{To: "2"}))
+	assert.Equal(t, ErrQueueFull, q.Send(&Message{To: "3"}))
+
+	close(transport.release)
+	q.Close()
+	assert.Len(t, transport.sent, 2)
+}
@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"fmt"
+	"net/mail"
+	"net/smtp"
+)
+
+type SMTP struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTP(cfg Config) *SMTP {
+	s := &SMTP{addr: fmt.Sprintf("%s:%d", cfg.Host, cfg.Port), from: cfg.From}
+	if cfg.Username != "" {
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return s
+}
+
+func (s *SMTP) Send(msg *Message) error {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+	data, err := msg.Bytes(s.from)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.addr, s.auth, from.Address, []string{to.Address}, data)
+}
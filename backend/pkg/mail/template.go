@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// Template renders the subject and the text part with text/template and
+// the HTML part with html/template, all from the same data.
+type Template struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+func MustTemplate(name, subject, text, html string) *Template {
+	return &Template{
+		subject: texttemplate.Must(texttemplate.New(name + ".subject").Parse(subject)),
+		text:    texttemplate.Must(texttemplate.New(name + ".text").Parse(text)),
+		html:    htmltemplate.Must(htmltemplate.New(name + ".html").Parse(html)),
+	}
+}
+
+func (t *Template) Render(to string, data interface{}) (*Message, error) {
+	var subject, text, html bytes.Buffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return nil, err
+	}
+	if err := t.text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := t.html.Execute(&html, data); err != nil {
+		return nil, err
+	}
+	return &Message{To: to, Subject: subject.String(), Text: text.String(), HTML: html.String()}, nil
+}
@@ -0,0 +1,31 @@
+package models
+
+type AccessSources struct {
+	UserId         int
+	ProjectId      int
+	BoardId        int
+	IsProjectOwner bool
+	IsBoardOwner   bool
+	IsShareGuest   bool
+	IsArchived     bool
+	Project        *Permission
+	ProjectGroups  *Permission
+	BoardDefault   *Permission
+	BoardAllow     *Permission
+	BoardGroups    *Permission
+	BoardDeny      *Permission
+}
+
+type PermissionRight struct {
+	Name    string `json:"name"`
+	Granted bool   `json:"granted"`
+	Source  string `json:"source"`
+}
+
+type EffectivePermissions struct {
+	UserId      int                `json:"userId"`
+	ProjectId   int                `json:"projectId"`
+	BoardId     int                `json:"boardId,omitempty"`
+	Permissions *Permission        `json:"permissions"`
+	Rights      []*PermissionRight `json:"rights"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "io"
+
+type Attachment struct {
+	Id          int    `json:"id"`
+	TaskId      int    `json:"taskId" db:"task_id"`
+	UploaderId  int    `json:"uploaderId" db:"uploader_id"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType" db:"content_type"`
+	Size        int64  `json:"size"`
+	StorageKey  string `json:"-" db:"storage_key"`
+	Created     int64  `json:"created"`
+}
+
+type AttachmentUpload struct {
+	Name    string
+	Size    int64
+	Content io.Reader
+}
+
+// AttachmentContent is the data of a download response, the handler closes
+// the content once it is sent.
+type AttachmentContent struct {
+	*Attachment
+	Content io.ReadCloser
+}
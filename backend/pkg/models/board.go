@@ -7,6 +7,8 @@ type Board struct {
 	DefaultPermissions *Permission `json:"defaultPermissions,omitempty"`
 	Datetimes          *Datetimes  `json:"datetimes,omitempty"`
 	Title              string      `json:"title"`
+	Archived           bool        `json:"archived"`
+	TemplateId         int         `json:"templateId,omitempty"`
 }
 
 type UpdateBoard struct {
@@ -0,0 +1,59 @@
+package models
+
+const (
+	FieldText        = "text"
+	FieldNumber      = "number"
+	FieldDate        = "date"
+	FieldSelect      = "select"
+	FieldMultiSelect = "multiselect"
+	FieldUser        = "user"
+	FieldCheckbox    = "checkbox"
+)
+
+var FieldTypes = map[string]bool{
+	FieldText:        true,
+	FieldNumber:      true,
+	FieldDate:        true,
+	FieldSelect:      true,
+	FieldMultiSelect: true,
+	FieldUser:        true,
+	FieldCheckbox:    true,
+}
+
+// CustomField is a field the admins of a board add to its tasks. Its name
+// refers to it in filters and sorts, as in cf.severity:high. Options are
+// the choices of select fields.
+type CustomField struct {
+	Id       int            `json:"id"`
+	BoardId  int            `json:"boardId" db:"board_id"`
+	Name     string         `json:"name" valid:"matches(^[\\p{L}\\p{N}_-]+$),length(1|30),required"`
+	Type     string         `json:"type" valid:"required"`
+	Position int            `json:"position"`
+	Options  []*FieldOption `json:"options,omitempty"`
+}
+
+type FieldOption struct {
+	Id   int    `json:"id"`
+	Name string `json:"name" valid:"length(1|50),required"`
+}
+
+// UpdateCustomField replaces the options of select fields: options with
+// an id are renamed and reordered, those without are added, and the missing
+// ones are deleted along with their values.
+type UpdateCustomField struct {
+	Name    *string         `json:"name" valid:"matches(^[\\p{L}\\p{N}_-]+$),length(1|30)"`
+	Options *[]*FieldOption `json:"options"`
+}
+
+// CustomValue is the value of a custom field of a task, held in the member
+// matching the type of the field.
+type CustomValue struct {
+	TaskId    int      `json:"-"`
+	FieldId   int      `json:"fieldId"`
+	Text      *string  `json:"text,omitempty"`
+	Number    *float64 `json:"number,omitempty"`
+	Date      *int64   `json:"date,omitempty"`
+	Checked   *bool    `json:"checked,omitempty"`
+	UserId    *int     `json:"userId,omitempty"`
+	OptionIds []int    `json:"optionIds,omitempty"`
+}
@@ -0,0 +1,49 @@
+package models
+
+// ExportVersion is bumped whenever the layout of ProjectExport changes in a
+// way older importers cannot read.
+const ExportVersion = 1
+
+// ProjectExport is a self-contained copy of a project. Users are referred to
+// by nickname and objects by their position in the document, so it can be
+// imported on another instance.
+type ProjectExport struct {
+	Version            int             `json:"version"`
+	Exported           int64           `json:"exported"`
+	Title              string          `json:"title" valid:"length(1|50)"`
+	Description        string          `json:"description,omitempty"`
+	DefaultPermissions *Permission     `json:"defaultPermissions"`
+	Datetimes          *Datetimes      `json:"datetimes,omitempty"`
+	Members            []*MemberExport `json:"members"`
+	Boards             []*BoardExport  `json:"boards"`
+}
+
+type MemberExport struct {
+	Nickname    string      `json:"nickname"`
+	Permissions *Permission `json:"permissions"`
+}
+
+// BoardExport keeps the ids of the labels so that tasks can refer to them;
+// they are replaced with new ones on import.
+type BoardExport struct {
+	Title              string          `json:"title"`
+	Archived           bool            `json:"archived"`
+	DefaultPermissions *Permission     `json:"defaultPermissions"`
+	Datetimes          *Datetimes      `json:"datetimes,omitempty"`
+	Members            []*MemberExport `json:"members"`
+	Labels             []*LabelContent `json:"labels"`
+	Lists              []*ListExport   `json:"lists"`
+}
+
+type ListExport struct {
+	Title    string        `json:"title"`
+	Archived bool          `json:"archived"`
+	Tasks    []*TaskExport `json:"tasks"`
+}
+
+type TaskExport struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Datetimes   *Datetimes `json:"datetimes,omitempty"`
+	LabelIds    []int      `json:"labelIds,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type Group struct {
+	Id          int    `json:"id,omitempty"`
+	ProjectId   int    `json:"projectId,omitempty"`
+	OwnerId     int    `json:"ownerId,omitempty"`
+	Title       string `json:"title" valid:"length(1|50)"`
+	Description string `json:"description,omitempty"`
+}
+
+type UpdateGroup struct {
+	Title       *string `json:"title" valid:"length(1|50)"`
+	Description *string `json:"description,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+type Invitation struct {
+	Id           int         `json:"id"`
+	ProjectId    int         `json:"projectId"`
+	ProjectTitle string      `json:"projectTitle,omitempty"`
+	BoardId      int         `json:"boardId,omitempty"`
+	BoardTitle   string      `json:"boardTitle,omitempty"`
+	GroupId      int         `json:"groupId,omitempty"`
+	GroupTitle   string      `json:"groupTitle,omitempty"`
+	InviterId    int         `json:"inviterId"`
+	InviteeId    int         `json:"inviteeId,omitempty"`
+	Nickname     string      `json:"nickname,omitempty"`
+	Email        string      `json:"email,omitempty"`
+	Token        string      `json:"-"`
+	Permissions  *Permission `json:"permissions"`
+	Created      int64       `json:"created"`
+	Expires      int64       `json:"expires"`
+}
+
+type EmailInvitation struct {
+	Email       string      `json:"email" valid:"email,required"`
+	Permissions *Permission `json:"permissions"`
+}
@@ -5,9 +5,25 @@ type TaskList struct {
 	BoardId  int    `json:"boardId" db:"board_id"`
 	Title    string `json:"title"`
 	Position int    `json:"position" valid:"type(int)"`
+	Archived bool   `json:"archived"`
+	Done     bool   `json:"done"`
 }
 
 type UpdateTaskList struct {
 	Title    *string `json:"title"`
 	Position *int    `json:"position" valid:"type(*int)"`
+	Done     *bool   `json:"done"`
+}
+
+// MoveTaskList puts the list to the position on the board, which may be in
+// another project; the list goes to the end of the board without a position.
+type MoveTaskList struct {
+	BoardId  int  `json:"boardId" valid:"required"`
+	Position *int `json:"position"`
+}
+
+// ListSnapshot is a list together with its tasks.
+type ListSnapshot struct {
+	*TaskList
+	Tasks []*Task `json:"tasks"`
 }
@@ -0,0 +1,34 @@
+package models
+
+const (
+	MailNotifications = "notifications"
+	MailDigest        = "digest"
+)
+
+type MailSettings struct {
+	Notifications bool `json:"notifications"`
+	Digest        bool `json:"digest"`
+}
+
+type UpdateMailSettings struct {
+	Notifications *bool `json:"notifications"`
+	Digest        *bool `json:"digest"`
+}
+
+type MailRecipient struct {
+	Id         int
+	Nickname   string
+	Email      string
+	Settings   MailSettings
+	DigestSent int64
+}
+
+// Reminder is a task due soon, mailed to its assignee.
+type Reminder struct {
+	TaskId    int
+	ProjectId int
+	BoardId   int
+	UserId    int
+	Title     string
+	Due       int64
+}
@@ -5,5 +5,8 @@ type Member struct {
 	Nickname    string      `json:"nickname" valid:"length(3|32)"`
 	Avatar      string      `json:"avatar"`
 	IsOwner     bool        `json:"isOwner"`
+	IsDirect    bool        `json:"isDirect"`
+	Groups      []int       `json:"groups,omitempty"`
+	Source      string      `json:"source,omitempty"`
 	Permissions *Permission `json:"permissions"`
 }
@@ -0,0 +1,7 @@
+package models
+
+// Mention is a user mentioned in a task as @nickname.
+type Mention struct {
+	UserId   int    `json:"userId" db:"user_id"`
+	Nickname string `json:"nickname"`
+}
@@ -0,0 +1,64 @@
+package models
+
+const (
+	WatchProject = "project"
+	WatchBoard   = "board"
+	WatchList    = "list"
+	WatchTask    = "task"
+)
+
+const (
+	EventBoardCreated  = "board.created"
+	EventListCreated   = "list.created"
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskMoved     = "task.moved"
+	EventTaskDeleted   = "task.deleted"
+	EventTaskAssigned  = "task.assigned"
+	EventTaskMentioned = "task.mentioned"
+)
+
+// Events are all the events users can be notified about, each one enabled
+// unless the user turns it off.
+var Events = []string{
+	EventBoardCreated,
+	EventListCreated,
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskMoved,
+	EventTaskDeleted,
+	EventTaskAssigned,
+	EventTaskMentioned,
+}
+
+// Event is something that happened to an object, the ids of the objects
+// above it are set as well. Watchers of any of them are notified. UserId is
+// the user the event is about, such as the assignee or the one mentioned,
+// who is also mailed.
+type Event struct {
+	Type      string
+	ActorId   int
+	UserId    int
+	ProjectId int
+	BoardId   int
+	ListId    int
+	TaskId    int
+}
+
+type Notification struct {
+	Id        int    `json:"id"`
+	Event     string `json:"event"`
+	ActorId   int    `json:"actorId,omitempty" db:"actor_id"`
+	ProjectId int    `json:"projectId" db:"project_id"`
+	BoardId   int    `json:"boardId,omitempty" db:"board_id"`
+	ListId    int    `json:"listId,omitempty" db:"list_id"`
+	TaskId    int    `json:"taskId,omitempty" db:"task_id"`
+	Title     string `json:"title"`
+	Created   int64  `json:"created"`
+	Read      bool   `json:"read"`
+}
+
+type Watch struct {
+	ObjectType string `json:"objectType" db:"object_type" valid:"required"`
+	ObjectId   int    `json:"objectId" db:"object_id" valid:"required"`
+}
@@ -0,0 +1,17 @@
+package models
+
+type OwnershipTransfer struct {
+	Id         int   `json:"id"`
+	ProjectId  int   `json:"projectId"`
+	BoardId    int   `json:"boardId,omitempty"`
+	OldOwnerId int   `json:"oldOwnerId"`
+	NewOwnerId int   `json:"newOwnerId"`
+	AuthorId   int   `json:"authorId"`
+	Created    int64 `json:"created"`
+}
+
+type TransferOwnership struct {
+	NewOwnerId int `json:"newOwnerId" valid:"required"`
+	// Permissions left to the old owner, who stays an admin when they are omitted.
+	OldOwnerPermissions *Permission `json:"oldOwnerPermissions"`
+}
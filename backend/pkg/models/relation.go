@@ -0,0 +1,27 @@
+package models
+
+const (
+	RelationBlocks    = "blocks"
+	RelationBlockedBy = "blockedBy"
+	RelationRelates   = "relates"
+)
+
+// TaskRelation is a link seen from one of its tasks, Task is the other one.
+type TaskRelation struct {
+	Id   int          `json:"id"`
+	Kind string       `json:"kind"`
+	Task *RelatedTask `json:"task"`
+}
+
+type RelatedTask struct {
+	Id      int    `json:"id"`
+	Title   string `json:"title"`
+	ListId  int    `json:"listId"`
+	BoardId int    `json:"boardId"`
+	Done    bool   `json:"done"`
+}
+
+type CreateTaskRelation struct {
+	Kind   string `json:"kind" valid:"required"`
+	TaskId int    `json:"taskId" valid:"required"`
+}
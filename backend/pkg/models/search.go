@@ -0,0 +1,29 @@
+package models
+
+const (
+	SearchProject = "project"
+	SearchBoard   = "board"
+	SearchTask    = "task"
+	SearchLabel   = "label"
+)
+
+type SearchQuery struct {
+	Text      string
+	ProjectId int
+	BoardId   int
+	Limit     int
+	Offset    int
+}
+
+// SearchHit is a found object. Snippet is the matched text with the found
+// words wrapped in <mark> tags.
+type SearchHit struct {
+	Type      string  `json:"type"`
+	Id        int     `json:"id"`
+	ProjectId int     `json:"projectId"`
+	BoardId   int     `json:"boardId,omitempty"`
+	ListId    int     `json:"listId,omitempty"`
+	Title     string  `json:"title"`
+	Snippet   string  `json:"snippet"`
+	Rank      float64 `json:"rank"`
+}
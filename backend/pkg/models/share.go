@@ -0,0 +1,18 @@
+package models
+
+type BoardShare struct {
+	Id          int    `json:"id"`
+	ProjectId   int    `json:"projectId"`
+	BoardId     int    `json:"boardId"`
+	OwnerId     int    `json:"ownerId"`
+	Token       string `json:"token"`
+	Password    string `json:"-"`
+	HasPassword bool   `json:"hasPassword"`
+	Created     int64  `json:"created"`
+	Expires     int64  `json:"expires,omitempty"`
+}
+
+type BoardShareInput struct {
+	Password string `json:"password" valid:"length(0|32)"`
+	Expires  int64  `json:"expires"`
+}
@@ -0,0 +1,61 @@
+package models
+
+const (
+	SprintPlanned   = "planned"
+	SprintActive    = "active"
+	SprintCompleted = "completed"
+)
+
+// Sprint is an iteration of a board planned from Start to End. Started and
+// Completed are when it actually was.
+type Sprint struct {
+	Id        int    `json:"id"`
+	BoardId   int    `json:"boardId" db:"board_id"`
+	Name      string `json:"name" valid:"length(1|64)"`
+	Goal      string `json:"goal"`
+	Start     int64  `json:"start" db:"start_date" valid:"required"`
+	End       int64  `json:"end" db:"end_date" valid:"required"`
+	State     string `json:"state"`
+	Started   int64  `json:"started,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
+
+type UpdateSprint struct {
+	Name  *string `json:"name" valid:"length(1|64)"`
+	Goal  *string `json:"goal"`
+	Start *int64  `json:"start"`
+	End   *int64  `json:"end"`
+}
+
+// CompleteSprint takes the planned sprint the unfinished tasks go to, the
+// backlog if it is 0.
+type CompleteSprint struct {
+	CarryOverTo int `json:"carryOverTo"`
+}
+
+type SprintTask struct {
+	Id     int    `json:"id"`
+	ListId int    `json:"listId" db:"list_id"`
+	Title  string `json:"title"`
+	Points int    `json:"points"`
+	Done   bool   `json:"done"`
+}
+
+// TaskSnapshot is the state of a task that matters to sprints at a time.
+type TaskSnapshot struct {
+	TaskId   int   `db:"task_id"`
+	SprintId int   `db:"sprint_id"`
+	Points   int   `db:"points"`
+	Done     bool  `db:"done"`
+	Created  int64 `db:"created"`
+}
+
+// BurndownPoint is the state of a sprint at the end of a day, in points.
+// Ideal is the remaining work on a straight line from the start to the end.
+type BurndownPoint struct {
+	Date      int64   `json:"date"`
+	Scope     int     `json:"scope"`
+	Completed int     `json:"completed"`
+	Remaining int     `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
@@ -1,12 +1,20 @@
 package models
 
 type Task struct {
-	Id          int        `json:"_id,omitempty"`
-	ListId      int        `json:"listId"`
-	Title       string     `json:"title"`
-	Description string     `json:"description,omitempty"`
-	Datetimes   *Datetimes `json:"datetimes,omitempty"`
-	Position    int        `json:"position" valid:"type(int)"`
+	Id          int            `json:"_id,omitempty"`
+	ListId      int            `json:"listId"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Datetimes   *Datetimes     `json:"datetimes,omitempty"`
+	Position    int            `json:"position" valid:"type(int)"`
+	Archived    bool           `json:"archived"`
+	IsBlocked   bool           `json:"isBlocked"`
+	AssigneeId  int            `json:"assigneeId,omitempty"`
+	SprintId    int            `json:"sprintId,omitempty"`
+	Points      int            `json:"points"`
+	Due         int64          `json:"due,omitempty"`
+	Mentions    []*Mention     `json:"mentions,omitempty"`
+	Fields      []*CustomValue `json:"fields,omitempty"`
 }
 
 type UpdateTask struct {
@@ -15,4 +23,16 @@ type UpdateTask struct {
 	Description *string          `json:"description,omitempty"`
 	Datetimes   *UpdateDatetimes `json:"datetimes,omitempty"`
 	Position    *int             `json:"position" valid:"type(*int)"`
+	AssigneeId  *int             `json:"assigneeId"`
+	SprintId    *int             `json:"sprintId"`
+	Points      *int             `json:"points"`
+	// Due is a unix time, 0 clears it.
+	Due *int64 `json:"due"`
+}
+
+// MoveTask puts the task to the position in the list, which may be on
+// another board; the task goes to the end of the list without a position.
+type MoveTask struct {
+	ListId   int  `json:"listId" valid:"required"`
+	Position *int `json:"position"`
 }
@@ -0,0 +1,36 @@
+package models
+
+const (
+	BulkMove        = "move"
+	BulkAddLabel    = "addLabel"
+	BulkRemoveLabel = "removeLabel"
+	BulkDelete      = "delete"
+	BulkArchive     = "archive"
+	BulkUnarchive   = "unarchive"
+)
+
+const (
+	BulkStatusOk      = "ok"
+	BulkStatusFailed  = "failed"
+	BulkStatusSkipped = "skipped"
+)
+
+type BulkTasks struct {
+	Operations []*BulkTaskOperation `json:"operations"`
+}
+
+// BulkTaskOperation moves the task to the position in the list when op is
+// move; the task goes to the end of the list without a position.
+type BulkTaskOperation struct {
+	Op       string `json:"op"`
+	TaskId   int    `json:"taskId"`
+	ListId   int    `json:"listId,omitempty"`
+	Position *int   `json:"position,omitempty"`
+	LabelId  int    `json:"labelId,omitempty"`
+}
+
+type BulkTaskResult struct {
+	TaskId int    `json:"taskId"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// TaskRecord is a task with the titles of its list and labels, as written to
+// a CSV export.
+type TaskRecord struct {
+	ListTitle   string
+	Position    int
+	Title       string
+	Description string
+	Labels      []string
+	Datetimes   *Datetimes
+}
+
+type CsvRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,49 @@
+package models
+
+// BoardContent is a snapshot of the lists, labels, custom fields and
+// optionally tasks of a board, which clones and templates are created from.
+// Fields keep their original ids, like labels.
+type BoardContent struct {
+	Lists  []*ListContent  `json:"lists"`
+	Labels []*LabelContent `json:"labels"`
+	Fields []*CustomField  `json:"fields,omitempty"`
+}
+
+type ListContent struct {
+	Title string         `json:"title"`
+	Tasks []*TaskContent `json:"tasks,omitempty"`
+}
+
+// LabelContent keeps the id of the original label so that tasks of the
+// snapshot can refer to it.
+type LabelContent struct {
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+	Color uint32 `json:"color"`
+}
+
+type TaskContent struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	LabelIds    []int          `json:"labelIds,omitempty"`
+	Values      []*CustomValue `json:"values,omitempty"`
+}
+
+type BoardTemplate struct {
+	Id        int           `json:"id"`
+	ProjectId int           `json:"projectId"`
+	OwnerId   int           `json:"ownerId"`
+	Title     string        `json:"title"`
+	Created   int64         `json:"created"`
+	Content   *BoardContent `json:"content,omitempty"`
+}
+
+type SaveTemplate struct {
+	Title string `json:"title" valid:"length(1|50),required"`
+}
+
+type CloneBoard struct {
+	Title     string `json:"title" valid:"length(0|50)"`
+	ProjectId int    `json:"projectId"`
+	WithTasks bool   `json:"withTasks"`
+}
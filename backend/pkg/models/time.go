@@ -0,0 +1,65 @@
+package models
+
+// TimeEstimate is the time planned for a task in seconds. Remaining goes
+// down as time is logged.
+type TimeEstimate struct {
+	Original  int64 `json:"original"`
+	Remaining int64 `json:"remaining"`
+}
+
+type UpdateTimeEstimate struct {
+	Original  *int64 `json:"original"`
+	Remaining *int64 `json:"remaining"`
+}
+
+// TimeLog is work on a task, Duration in seconds done on Date.
+type TimeLog struct {
+	Id       int    `json:"id"`
+	TaskId   int    `json:"taskId" db:"task_id"`
+	UserId   int    `json:"userId" db:"user_id"`
+	Nickname string `json:"nickname"`
+	Duration int64  `json:"duration"`
+	Date     int64  `json:"date"`
+	Note     string `json:"note"`
+}
+
+type CreateTimeLog struct {
+	Duration int64  `json:"duration" valid:"required"`
+	Date     int64  `json:"date"`
+	Note     string `json:"note"`
+}
+
+// Timer is the work a user is timing, a user times one task at a time.
+type Timer struct {
+	TaskId  int   `json:"taskId" db:"task_id"`
+	Started int64 `json:"started"`
+}
+
+type StopTimer struct {
+	Note string `json:"note"`
+}
+
+// TimeReportQuery narrows a report to the logs of a project, a board or a
+// user done from From to To inclusive.
+type TimeReportQuery struct {
+	ProjectId int
+	BoardId   int
+	UserId    int
+	From      int64
+	To        int64
+}
+
+type TimeReportEntry struct {
+	Id        int    `json:"id"`
+	Date      int64  `json:"date"`
+	UserId    int    `json:"userId" db:"user_id"`
+	Nickname  string `json:"nickname"`
+	ProjectId int    `json:"projectId" db:"project_id"`
+	Project   string `json:"project"`
+	BoardId   int    `json:"boardId" db:"board_id"`
+	Board     string `json:"board"`
+	TaskId    int    `json:"taskId" db:"task_id"`
+	Task      string `json:"task"`
+	Duration  int64  `json:"duration"`
+	Note      string `json:"note"`
+}
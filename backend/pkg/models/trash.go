@@ -0,0 +1,21 @@
+package models
+
+const (
+	TrashProject = "project"
+	TrashBoard   = "board"
+	TrashList    = "list"
+	TrashTask    = "task"
+)
+
+type TrashItem struct {
+	Type          string `json:"type"`
+	Id            int    `json:"id"`
+	ProjectId     int    `json:"projectId"`
+	BoardId       int    `json:"boardId,omitempty"`
+	ListId        int    `json:"listId,omitempty"`
+	OwnerId       int    `json:"-"`
+	Title         string `json:"title"`
+	Position      int    `json:"position"`
+	DeletedAt     int64  `json:"deletedAt"`
+	ParentDeleted bool   `json:"parentDeleted"`
+}
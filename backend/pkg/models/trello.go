@@ -0,0 +1,46 @@
+package models
+
+// TrelloBoard holds the part of a Trello board JSON export that is imported.
+type TrelloBoard struct {
+	Name   string         `json:"name"`
+	Lists  []*TrelloList  `json:"lists"`
+	Cards  []*TrelloCard  `json:"cards"`
+	Labels []*TrelloLabel `json:"labels"`
+}
+
+type TrelloList struct {
+	Id     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type TrelloCard struct {
+	Id       string   `json:"id"`
+	IdList   string   `json:"idList"`
+	Name     string   `json:"name"`
+	Desc     string   `json:"desc"`
+	Closed   bool     `json:"closed"`
+	Pos      float64  `json:"pos"`
+	Due      string   `json:"due"`
+	IdLabels []string `json:"idLabels"`
+}
+
+type TrelloLabel struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TrelloImportReport describes what an import creates, or would create on a
+// dry run, and what it leaves out.
+type TrelloImportReport struct {
+	Title           string   `json:"title"`
+	Lists           int      `json:"lists"`
+	Tasks           int      `json:"tasks"`
+	Labels          int      `json:"labels"`
+	SkippedLists    int      `json:"skippedLists"`
+	SkippedCards    int      `json:"skippedCards"`
+	DueDates        int      `json:"dueDates"`
+	TruncatedTitles []string `json:"truncatedTitles,omitempty"`
+}
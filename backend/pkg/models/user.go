@@ -9,6 +9,9 @@ type User struct {
 	Phone     string `json:"phone" valid:"numeric"`
 	Password  string `json:"password" valid:"length(6|32)"`
 	Avatar    string `json:"avatar"`
+	// InvitationToken redeems the email invitation it was sent with on
+	// sign up.
+	InvitationToken string `json:"invitationToken,omitempty" db:"-"`
 }
 
 type UpdateUser struct {
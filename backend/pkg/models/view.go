@@ -0,0 +1,26 @@
+package models
+
+// BoardView is a named filter of a board. ListIds limits the visible lists,
+// all of them are shown when it is empty. Shared views are visible to every
+// member of the board, the others only to their owner. Sort takes the sort
+// orders of the tasks endpoints, such as -updated or cf.severity.
+type BoardView struct {
+	Id        int    `json:"id"`
+	BoardId   int    `json:"boardId"`
+	OwnerId   int    `json:"ownerId"`
+	Title     string `json:"title" valid:"length(1|50),required"`
+	Filter    string `json:"filter"`
+	Sort      string `json:"sort"`
+	ListIds   []int  `json:"listIds"`
+	Shared    bool   `json:"shared"`
+	IsDefault bool   `json:"isDefault"`
+	Created   int64  `json:"created"`
+}
+
+type UpdateBoardView struct {
+	Title   *string `json:"title" valid:"length(1|50)"`
+	Filter  *string `json:"filter"`
+	Sort    *string `json:"sort"`
+	ListIds *[]int  `json:"listIds"`
+	Shared  *bool   `json:"shared"`
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Access)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccess is a mock of Access interface.
+type MockAccess struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessMockRecorder
+}
+
+// MockAccessMockRecorder is the mock recorder for MockAccess.
+type MockAccessMockRecorder struct {
+	mock *MockAccess
+}
+
+// NewMockAccess creates a new mock instance.
+func NewMockAccess(ctrl *gomock.Controller) *MockAccess {
+	mock := &MockAccess{ctrl: ctrl}
+	mock.recorder = &MockAccessMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccess) EXPECT() *MockAccessMockRecorder {
+	return m.recorder
+}
+
+// GetBoardSources mocks base method.
+func (m *MockAccess) GetBoardSources(arg0, arg1 int) (*models.AccessSources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardSources", arg0, arg1)
+	ret0, _ := ret[0].(*models.AccessSources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardSources indicates an expected call of GetBoardSources.
+func (mr *MockAccessMockRecorder) GetBoardSources(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardSources", reflect.TypeOf((*MockAccess)(nil).GetBoardSources), arg0, arg1)
+}
+
+// GetBoardsSources mocks base method.
+func (m *MockAccess) GetBoardsSources(arg0, arg1 int) ([]*models.AccessSources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsSources", arg0, arg1)
+	ret0, _ := ret[0].([]*models.AccessSources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsSources indicates an expected call of GetBoardsSources.
+func (mr *MockAccessMockRecorder) GetBoardsSources(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsSources", reflect.TypeOf((*MockAccess)(nil).GetBoardsSources), arg0, arg1)
+}
+
+// GetProjectSources mocks base method.
+func (m *MockAccess) GetProjectSources(arg0, arg1 int) (*models.AccessSources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectSources", arg0, arg1)
+	ret0, _ := ret[0].(*models.AccessSources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectSources indicates an expected call of GetProjectSources.
+func (mr *MockAccessMockRecorder) GetProjectSources(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectSources", reflect.TypeOf((*MockAccess)(nil).GetProjectSources), arg0, arg1)
+}
+
+// GetReadableProjects mocks base method.
+func (m *MockAccess) GetReadableProjects(arg0 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadableProjects", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadableProjects indicates an expected call of GetReadableProjects.
+func (mr *MockAccessMockRecorder) GetReadableProjects(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadableProjects", reflect.TypeOf((*MockAccess)(nil).GetReadableProjects), arg0)
+}
+
+// GetShareSources mocks base method.
+func (m *MockAccess) GetShareSources(arg0, arg1 int, arg2 int64) (*models.AccessSources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareSources", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.AccessSources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareSources indicates an expected call of GetShareSources.
+func (mr *MockAccessMockRecorder) GetShareSources(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareSources", reflect.TypeOf((*MockAccess)(nil).GetShareSources), arg0, arg1, arg2)
+}
+
+// GetUsersSources mocks base method.
+func (m *MockAccess) GetUsersSources(arg0 int, arg1 []int) ([]*models.AccessSources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersSources", arg0, arg1)
+	ret0, _ := ret[0].([]*models.AccessSources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersSources indicates an expected call of GetUsersSources.
+func (mr *MockAccessMockRecorder) GetUsersSources(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersSources", reflect.TypeOf((*MockAccess)(nil).GetUsersSources), arg0, arg1)
+}
+
+// IsListArchived mocks base method.
+func (m *MockAccess) IsListArchived(arg0 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsListArchived", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsListArchived indicates an expected call of IsListArchived.
+func (mr *MockAccessMockRecorder) IsListArchived(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsListArchived", reflect.TypeOf((*MockAccess)(nil).IsListArchived), arg0)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Attachment)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachment) Create(arg0 *models.Attachment) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachment)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockAttachment) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachment)(nil).Delete), arg0)
+}
+
+// GetAll mocks base method.
+func (m *MockAttachment) GetAll(arg0 int) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAttachmentMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAttachment)(nil).GetAll), arg0)
+}
+
+// GetById mocks base method.
+func (m *MockAttachment) GetById(arg0 int) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockAttachmentMockRecorder) GetById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAttachment)(nil).GetById), arg0)
+}
@@ -49,6 +49,21 @@ func (mr *MockBoardMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoard)(nil).Create), arg0, arg1)
 }
 
+// CreateWithContent mocks base method.
+func (m *MockBoard) CreateWithContent(arg0 int, arg1 *models.Board, arg2 *models.BoardContent) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithContent indicates an expected call of CreateWithContent.
+func (mr *MockBoardMockRecorder) CreateWithContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithContent", reflect.TypeOf((*MockBoard)(nil).CreateWithContent), arg0, arg1, arg2)
+}
+
 // Delete mocks base method.
 func (m *MockBoard) Delete(arg0 int) error {
 	m.ctrl.T.Helper()
@@ -64,18 +79,18 @@ func (mr *MockBoardMockRecorder) Delete(arg0 interface{}) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockBoard) GetAll(arg0, arg1 int) ([]*models.Board, error) {
+func (m *MockBoard) GetAll(arg0 int) ([]*models.Board, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAll", arg0)
 	ret0, _ := ret[0].([]*models.Board)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockBoardMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockBoardMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBoard)(nil).GetAll), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBoard)(nil).GetAll), arg0)
 }
 
 // GetBoardsCountByOwnerId mocks base method.
@@ -108,6 +123,21 @@ func (mr *MockBoardMockRecorder) GetById(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockBoard)(nil).GetById), arg0)
 }
 
+// GetContent mocks base method.
+func (m *MockBoard) GetContent(arg0 int, arg1 bool) (*models.BoardContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContent", arg0, arg1)
+	ret0, _ := ret[0].(*models.BoardContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContent indicates an expected call of GetContent.
+func (mr *MockBoardMockRecorder) GetContent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockBoard)(nil).GetContent), arg0, arg1)
+}
+
 // GetMembers mocks base method.
 func (m *MockBoard) GetMembers(arg0 int) ([]*models.Member, error) {
 	m.ctrl.T.Helper()
@@ -123,19 +153,18 @@ func (mr *MockBoardMockRecorder) GetMembers(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockBoard)(nil).GetMembers), arg0)
 }
 
-// GetPermissions mocks base method.
-func (m *MockBoard) GetPermissions(arg0, arg1 int) (*models.Permission, error) {
+// SetArchived mocks base method.
+func (m *MockBoard) SetArchived(arg0 int, arg1 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPermissions", arg0, arg1)
-	ret0, _ := ret[0].(*models.Permission)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetArchived", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetPermissions indicates an expected call of GetPermissions.
-func (mr *MockBoardMockRecorder) GetPermissions(arg0, arg1 interface{}) *gomock.Call {
+// SetArchived indicates an expected call of SetArchived.
+func (mr *MockBoardMockRecorder) SetArchived(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissions", reflect.TypeOf((*MockBoard)(nil).GetPermissions), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArchived", reflect.TypeOf((*MockBoard)(nil).SetArchived), arg0, arg1)
 }
 
 // Update mocks base method.
@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: CustomField)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCustomField is a mock of CustomField interface.
+type MockCustomField struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldMockRecorder
+}
+
+// MockCustomFieldMockRecorder is the mock recorder for MockCustomField.
+type MockCustomFieldMockRecorder struct {
+	mock *MockCustomField
+}
+
+// NewMockCustomField creates a new mock instance.
+func NewMockCustomField(ctrl *gomock.Controller) *MockCustomField {
+	mock := &MockCustomField{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomField) EXPECT() *MockCustomFieldMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomField) Create(arg0 *models.CustomField) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomFieldMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomField)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockCustomField) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomFieldMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomField)(nil).Delete), arg0)
+}
+
+// DeleteValue mocks base method.
+func (m *MockCustomField) DeleteValue(arg0, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteValue", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteValue indicates an expected call of DeleteValue.
+func (mr *MockCustomFieldMockRecorder) DeleteValue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValue", reflect.TypeOf((*MockCustomField)(nil).DeleteValue), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockCustomField) GetAll(arg0 int) ([]*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCustomFieldMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomField)(nil).GetAll), arg0)
+}
+
+// GetById mocks base method.
+func (m *MockCustomField) GetById(arg0 int) (*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0)
+	ret0, _ := ret[0].(*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCustomFieldMockRecorder) GetById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCustomField)(nil).GetById), arg0)
+}
+
+// GetValues mocks base method.
+func (m *MockCustomField) GetValues(arg0 []int) ([]*models.CustomValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValues", arg0)
+	ret0, _ := ret[0].([]*models.CustomValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValues indicates an expected call of GetValues.
+func (mr *MockCustomFieldMockRecorder) GetValues(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValues", reflect.TypeOf((*MockCustomField)(nil).GetValues), arg0)
+}
+
+// SetValue mocks base method.
+func (m *MockCustomField) SetValue(arg0 *models.CustomValue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetValue", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetValue indicates an expected call of SetValue.
+func (mr *MockCustomFieldMockRecorder) SetValue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValue", reflect.TypeOf((*MockCustomField)(nil).SetValue), arg0)
+}
+
+// Update mocks base method.
+func (m *MockCustomField) Update(arg0 int, arg1 *models.UpdateCustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomFieldMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomField)(nil).Update), arg0, arg1)
+}
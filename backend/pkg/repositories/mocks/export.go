@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Export)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExport is a mock of Export interface.
+type MockExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportMockRecorder
+}
+
+// MockExportMockRecorder is the mock recorder for MockExport.
+type MockExportMockRecorder struct {
+	mock *MockExport
+}
+
+// NewMockExport creates a new mock instance.
+func NewMockExport(ctrl *gomock.Controller) *MockExport {
+	mock := &MockExport{ctrl: ctrl}
+	mock.recorder = &MockExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExport) EXPECT() *MockExportMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockExport) Export(arg0 int) (*models.ProjectExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0)
+	ret0, _ := ret[0].(*models.ProjectExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockExportMockRecorder) Export(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockExport)(nil).Export), arg0)
+}
+
+// Import mocks base method.
+func (m *MockExport) Import(arg0 int, arg1 *models.ProjectExport, arg2, arg3 int64) (int, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockExportMockRecorder) Import(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockExport)(nil).Import), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Invitation)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockInvitation is a mock of Invitation interface.
+type MockInvitation struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationMockRecorder
+}
+
+// MockInvitationMockRecorder is the mock recorder for MockInvitation.
+type MockInvitationMockRecorder struct {
+	mock *MockInvitation
+}
+
+// NewMockInvitation creates a new mock instance.
+func NewMockInvitation(ctrl *gomock.Controller) *MockInvitation {
+	mock := &MockInvitation{ctrl: ctrl}
+	mock.recorder = &MockInvitationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitation) EXPECT() *MockInvitationMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockInvitation) Accept(arg0 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockInvitationMockRecorder) Accept(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockInvitation)(nil).Accept), arg0)
+}
+
+// Create mocks base method.
+func (m *MockInvitation) Create(arg0 *models.Invitation) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInvitationMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvitation)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockInvitation) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInvitationMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInvitation)(nil).Delete), arg0)
+}
+
+// GetAll mocks base method.
+func (m *MockInvitation) GetAll(arg0 int, arg1 int64) ([]*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockInvitationMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockInvitation)(nil).GetAll), arg0, arg1)
+}
+
+// GetAllForUser mocks base method.
+func (m *MockInvitation) GetAllForUser(arg0 int, arg1 int64) ([]*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllForUser", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllForUser indicates an expected call of GetAllForUser.
+func (mr *MockInvitationMockRecorder) GetAllForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllForUser", reflect.TypeOf((*MockInvitation)(nil).GetAllForUser), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockInvitation) GetById(arg0 int) (*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0)
+	ret0, _ := ret[0].(*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockInvitationMockRecorder) GetById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockInvitation)(nil).GetById), arg0)
+}
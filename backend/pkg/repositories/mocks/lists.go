@@ -93,6 +93,50 @@ func (mr *MockTaskListMockRecorder) GetById(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTaskList)(nil).GetById), arg0)
 }
 
+// GetUsers mocks base method.
+func (m *MockTaskList) GetUsers(arg0 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockTaskListMockRecorder) GetUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockTaskList)(nil).GetUsers), arg0)
+}
+
+// Move mocks base method.
+func (m *MockTaskList) Move(arg0, arg1 int, arg2 *int, arg3 []int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTaskListMockRecorder) Move(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTaskList)(nil).Move), arg0, arg1, arg2, arg3)
+}
+
+// SetArchived mocks base method.
+func (m *MockTaskList) SetArchived(arg0 int, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArchived", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArchived indicates an expected call of SetArchived.
+func (mr *MockTaskListMockRecorder) SetArchived(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArchived", reflect.TypeOf((*MockTaskList)(nil).SetArchived), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockTaskList) Update(arg0 int, arg1 *models.UpdateTaskList) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Mail)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMail is a mock of Mail interface.
+type MockMail struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailMockRecorder
+}
+
+// MockMailMockRecorder is the mock recorder for MockMail.
+type MockMailMockRecorder struct {
+	mock *MockMail
+}
+
+// NewMockMail creates a new mock instance.
+func NewMockMail(ctrl *gomock.Controller) *MockMail {
+	mock := &MockMail{ctrl: ctrl}
+	mock.recorder = &MockMailMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMail) EXPECT() *MockMailMockRecorder {
+	return m.recorder
+}
+
+// GetDigest mocks base method.
+func (m *MockMail) GetDigest(arg0 int, arg1 int64) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigest", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigest indicates an expected call of GetDigest.
+func (mr *MockMailMockRecorder) GetDigest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigest", reflect.TypeOf((*MockMail)(nil).GetDigest), arg0, arg1)
+}
+
+// GetDigestRecipients mocks base method.
+func (m *MockMail) GetDigestRecipients(arg0 int64) ([]*models.MailRecipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestRecipients", arg0)
+	ret0, _ := ret[0].([]*models.MailRecipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigestRecipients indicates an expected call of GetDigestRecipients.
+func (mr *MockMailMockRecorder) GetDigestRecipients(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestRecipients", reflect.TypeOf((*MockMail)(nil).GetDigestRecipients), arg0)
+}
+
+// GetEventTitle mocks base method.
+func (m *MockMail) GetEventTitle(arg0 *models.Event) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventTitle", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventTitle indicates an expected call of GetEventTitle.
+func (mr *MockMailMockRecorder) GetEventTitle(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventTitle", reflect.TypeOf((*MockMail)(nil).GetEventTitle), arg0)
+}
+
+// GetRecipient mocks base method.
+func (m *MockMail) GetRecipient(arg0 int) (*models.MailRecipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipient", arg0)
+	ret0, _ := ret[0].(*models.MailRecipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipient indicates an expected call of GetRecipient.
+func (mr *MockMailMockRecorder) GetRecipient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipient", reflect.TypeOf((*MockMail)(nil).GetRecipient), arg0)
+}
+
+// GetReminders mocks base method.
+func (m *MockMail) GetReminders(arg0 int64) ([]*models.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReminders", arg0)
+	ret0, _ := ret[0].([]*models.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReminders indicates an expected call of GetReminders.
+func (mr *MockMailMockRecorder) GetReminders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminders", reflect.TypeOf((*MockMail)(nil).GetReminders), arg0)
+}
+
+// SetDigestSent mocks base method.
+func (m *MockMail) SetDigestSent(arg0 int, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDigestSent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDigestSent indicates an expected call of SetDigestSent.
+func (mr *MockMailMockRecorder) SetDigestSent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDigestSent", reflect.TypeOf((*MockMail)(nil).SetDigestSent), arg0, arg1)
+}
+
+// SetReminded mocks base method.
+func (m *MockMail) SetReminded(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminded", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReminded indicates an expected call of SetReminded.
+func (mr *MockMailMockRecorder) SetReminded(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminded", reflect.TypeOf((*MockMail)(nil).SetReminded), arg0)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockMail) UpdateSettings(arg0 int, arg1 *models.UpdateMailSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockMailMockRecorder) UpdateSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockMail)(nil).UpdateSettings), arg0, arg1)
+}
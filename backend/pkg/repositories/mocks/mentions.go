@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Mention)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMention is a mock of Mention interface.
+type MockMention struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionMockRecorder
+}
+
+// MockMentionMockRecorder is the mock recorder for MockMention.
+type MockMentionMockRecorder struct {
+	mock *MockMention
+}
+
+// NewMockMention creates a new mock instance.
+func NewMockMention(ctrl *gomock.Controller) *MockMention {
+	mock := &MockMention{ctrl: ctrl}
+	mock.recorder = &MockMentionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMention) EXPECT() *MockMentionMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockMention) GetAll(arg0 int) ([]*models.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*models.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockMentionMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockMention)(nil).GetAll), arg0)
+}
+
+// Set mocks base method.
+func (m *MockMention) Set(arg0, arg1 int, arg2 []int, arg3 int64) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockMentionMockRecorder) Set(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockMention)(nil).Set), arg0, arg1, arg2, arg3)
+}
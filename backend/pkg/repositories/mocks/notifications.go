@@ -0,0 +1,224 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Notification)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotification) CountUnread(arg0 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationMockRecorder) CountUnread(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotification)(nil).CountUnread), arg0)
+}
+
+// Create mocks base method.
+func (m *MockNotification) Create(arg0 []int, arg1 *models.Event, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotification)(nil).Create), arg0, arg1, arg2)
+}
+
+// GetAll mocks base method.
+func (m *MockNotification) GetAll(arg0 int, arg1 bool, arg2, arg3 int) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockNotificationMockRecorder) GetAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockNotification)(nil).GetAll), arg0, arg1, arg2, arg3)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotification) GetPreferences(arg0 int) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", arg0)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationMockRecorder) GetPreferences(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotification)(nil).GetPreferences), arg0)
+}
+
+// GetRecipients mocks base method.
+func (m *MockNotification) GetRecipients(arg0 *models.Event) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipients", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipients indicates an expected call of GetRecipients.
+func (mr *MockNotificationMockRecorder) GetRecipients(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipients", reflect.TypeOf((*MockNotification)(nil).GetRecipients), arg0)
+}
+
+// GetWatchBoard mocks base method.
+func (m *MockNotification) GetWatchBoard(arg0 *models.Watch) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchBoard", arg0)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchBoard indicates an expected call of GetWatchBoard.
+func (mr *MockNotificationMockRecorder) GetWatchBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchBoard", reflect.TypeOf((*MockNotification)(nil).GetWatchBoard), arg0)
+}
+
+// GetWatches mocks base method.
+func (m *MockNotification) GetWatches(arg0 int) ([]*models.Watch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatches", arg0)
+	ret0, _ := ret[0].([]*models.Watch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatches indicates an expected call of GetWatches.
+func (mr *MockNotificationMockRecorder) GetWatches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatches", reflect.TypeOf((*MockNotification)(nil).GetWatches), arg0)
+}
+
+// IsEnabled mocks base method.
+func (m *MockNotification) IsEnabled(arg0 int, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockNotificationMockRecorder) IsEnabled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockNotification)(nil).IsEnabled), arg0, arg1)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), arg0)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(arg0, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), arg0, arg1)
+}
+
+// Unwatch mocks base method.
+func (m *MockNotification) Unwatch(arg0 int, arg1 *models.Watch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unwatch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unwatch indicates an expected call of Unwatch.
+func (mr *MockNotificationMockRecorder) Unwatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unwatch", reflect.TypeOf((*MockNotification)(nil).Unwatch), arg0, arg1)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockNotification) UpdatePreferences(arg0 int, arg1 map[string]bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationMockRecorder) UpdatePreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotification)(nil).UpdatePreferences), arg0, arg1)
+}
+
+// Watch mocks base method.
+func (m *MockNotification) Watch(arg0 int, arg1 *models.Watch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockNotificationMockRecorder) Watch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockNotification)(nil).Watch), arg0, arg1)
+}
@@ -49,6 +49,21 @@ func (mr *MockObjectPermsMockRecorder) Create(arg0, arg1, arg2, arg3 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockObjectPerms)(nil).Create), arg0, arg1, arg2, arg3)
 }
 
+// CreateForGroup mocks base method.
+func (m *MockObjectPerms) CreateForGroup(arg0, arg1, arg2 int, arg3 *models.Permission) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForGroup", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateForGroup indicates an expected call of CreateForGroup.
+func (mr *MockObjectPermsMockRecorder) CreateForGroup(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForGroup", reflect.TypeOf((*MockObjectPerms)(nil).CreateForGroup), arg0, arg1, arg2, arg3)
+}
+
 // Delete mocks base method.
 func (m *MockObjectPerms) Delete(arg0, arg1, arg2, arg3 int) error {
 	m.ctrl.T.Helper()
@@ -63,6 +78,49 @@ func (mr *MockObjectPermsMockRecorder) Delete(arg0, arg1, arg2, arg3 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockObjectPerms)(nil).Delete), arg0, arg1, arg2, arg3)
 }
 
+// DeleteBoardDeny mocks base method.
+func (m *MockObjectPerms) DeleteBoardDeny(arg0, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardDeny", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardDeny indicates an expected call of DeleteBoardDeny.
+func (mr *MockObjectPermsMockRecorder) DeleteBoardDeny(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardDeny", reflect.TypeOf((*MockObjectPerms)(nil).DeleteBoardDeny), arg0, arg1)
+}
+
+// DeleteForGroup mocks base method.
+func (m *MockObjectPerms) DeleteForGroup(arg0, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForGroup", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForGroup indicates an expected call of DeleteForGroup.
+func (mr *MockObjectPermsMockRecorder) DeleteForGroup(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForGroup", reflect.TypeOf((*MockObjectPerms)(nil).DeleteForGroup), arg0, arg1, arg2)
+}
+
+// GetByGroupId mocks base method.
+func (m *MockObjectPerms) GetByGroupId(arg0, arg1, arg2 int) (*models.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGroupId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGroupId indicates an expected call of GetByGroupId.
+func (mr *MockObjectPermsMockRecorder) GetByGroupId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGroupId", reflect.TypeOf((*MockObjectPerms)(nil).GetByGroupId), arg0, arg1, arg2)
+}
+
 // GetById mocks base method.
 func (m *MockObjectPerms) GetById(arg0, arg1, arg2 int) (*models.Permission, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +151,65 @@ func (mr *MockObjectPermsMockRecorder) GetByNickname(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNickname", reflect.TypeOf((*MockObjectPerms)(nil).GetByNickname), arg0, arg1, arg2)
 }
 
+// GetTransfers mocks base method.
+func (m *MockObjectPerms) GetTransfers(arg0 int) ([]*models.OwnershipTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfers", arg0)
+	ret0, _ := ret[0].([]*models.OwnershipTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfers indicates an expected call of GetTransfers.
+func (mr *MockObjectPermsMockRecorder) GetTransfers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfers", reflect.TypeOf((*MockObjectPerms)(nil).GetTransfers), arg0)
+}
+
+// GetUserIds mocks base method.
+func (m *MockObjectPerms) GetUserIds(arg0 []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIds", arg0)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIds indicates an expected call of GetUserIds.
+func (mr *MockObjectPermsMockRecorder) GetUserIds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIds", reflect.TypeOf((*MockObjectPerms)(nil).GetUserIds), arg0)
+}
+
+// SetBoardDeny mocks base method.
+func (m *MockObjectPerms) SetBoardDeny(arg0, arg1 int, arg2 *models.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBoardDeny", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBoardDeny indicates an expected call of SetBoardDeny.
+func (mr *MockObjectPermsMockRecorder) SetBoardDeny(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoardDeny", reflect.TypeOf((*MockObjectPerms)(nil).SetBoardDeny), arg0, arg1, arg2)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockObjectPerms) TransferOwnership(arg0, arg1 int, arg2 *models.OwnershipTransfer, arg3 *models.Permission) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockObjectPermsMockRecorder) TransferOwnership(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockObjectPerms)(nil).TransferOwnership), arg0, arg1, arg2, arg3)
+}
+
 // Update mocks base method.
 func (m *MockObjectPerms) Update(arg0, arg1, arg2, arg3 int, arg4 *models.UpdatePermission) error {
 	m.ctrl.T.Helper()
@@ -106,3 +223,17 @@ func (mr *MockObjectPermsMockRecorder) Update(arg0, arg1, arg2, arg3, arg4 inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockObjectPerms)(nil).Update), arg0, arg1, arg2, arg3, arg4)
 }
+
+// UpdateForGroup mocks base method.
+func (m *MockObjectPerms) UpdateForGroup(arg0, arg1, arg2 int, arg3 *models.UpdatePermission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateForGroup", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateForGroup indicates an expected call of UpdateForGroup.
+func (mr *MockObjectPermsMockRecorder) UpdateForGroup(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateForGroup", reflect.TypeOf((*MockObjectPerms)(nil).UpdateForGroup), arg0, arg1, arg2, arg3)
+}
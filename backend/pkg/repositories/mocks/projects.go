@@ -108,21 +108,6 @@ func (mr *MockProjectMockRecorder) GetMembers(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockProject)(nil).GetMembers), arg0)
 }
 
-// GetPermissions mocks base method.
-func (m *MockProject) GetPermissions(arg0, arg1 int) (*models.Permission, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPermissions", arg0, arg1)
-	ret0, _ := ret[0].(*models.Permission)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetPermissions indicates an expected call of GetPermissions.
-func (mr *MockProjectMockRecorder) GetPermissions(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissions", reflect.TypeOf((*MockProject)(nil).GetPermissions), arg0, arg1)
-}
-
 // Update mocks base method.
 func (m *MockProject) Update(arg0 int, arg1 *models.UpdateProject) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Relation)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRelation is a mock of Relation interface.
+type MockRelation struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelationMockRecorder
+}
+
+// MockRelationMockRecorder is the mock recorder for MockRelation.
+type MockRelationMockRecorder struct {
+	mock *MockRelation
+}
+
+// NewMockRelation creates a new mock instance.
+func NewMockRelation(ctrl *gomock.Controller) *MockRelation {
+	mock := &MockRelation{ctrl: ctrl}
+	mock.recorder = &MockRelationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelation) EXPECT() *MockRelationMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRelation) Create(arg0, arg1 int, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRelationMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRelation)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockRelation) Delete(arg0, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRelationMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRelation)(nil).Delete), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockRelation) GetAll(arg0 int) ([]*models.TaskRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*models.TaskRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRelationMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRelation)(nil).GetAll), arg0)
+}
+
+// GetTaskBoard mocks base method.
+func (m *MockRelation) GetTaskBoard(arg0 int) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskBoard", arg0)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskBoard indicates an expected call of GetTaskBoard.
+func (mr *MockRelationMockRecorder) GetTaskBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskBoard", reflect.TypeOf((*MockRelation)(nil).GetTaskBoard), arg0)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Search)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearch is a mock of Search interface.
+type MockSearch struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchMockRecorder
+}
+
+// MockSearchMockRecorder is the mock recorder for MockSearch.
+type MockSearchMockRecorder struct {
+	mock *MockSearch
+}
+
+// NewMockSearch creates a new mock instance.
+func NewMockSearch(ctrl *gomock.Controller) *MockSearch {
+	mock := &MockSearch{ctrl: ctrl}
+	mock.recorder = &MockSearchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearch) EXPECT() *MockSearchMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearch) Search(arg0 *models.SearchQuery, arg1, arg2 []int) ([]*models.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearch)(nil).Search), arg0, arg1, arg2)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: BoardShare)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBoardShare is a mock of BoardShare interface.
+type MockBoardShare struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardShareMockRecorder
+}
+
+// MockBoardShareMockRecorder is the mock recorder for MockBoardShare.
+type MockBoardShareMockRecorder struct {
+	mock *MockBoardShare
+}
+
+// NewMockBoardShare creates a new mock instance.
+func NewMockBoardShare(ctrl *gomock.Controller) *MockBoardShare {
+	mock := &MockBoardShare{ctrl: ctrl}
+	mock.recorder = &MockBoardShareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardShare) EXPECT() *MockBoardShareMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBoardShare) Create(arg0 *models.BoardShare) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBoardShareMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoardShare)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockBoardShare) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBoardShareMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBoardShare)(nil).Delete), arg0)
+}
+
+// GetAll mocks base method.
+func (m *MockBoardShare) GetAll(arg0 int) ([]*models.BoardShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*models.BoardShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockBoardShareMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBoardShare)(nil).GetAll), arg0)
+}
+
+// GetById mocks base method.
+func (m *MockBoardShare) GetById(arg0 int) (*models.BoardShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0)
+	ret0, _ := ret[0].(*models.BoardShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockBoardShareMockRecorder) GetById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockBoardShare)(nil).GetById), arg0)
+}
+
+// GetByToken mocks base method.
+func (m *MockBoardShare) GetByToken(arg0 string) (*models.BoardShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByToken", arg0)
+	ret0, _ := ret[0].(*models.BoardShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByToken indicates an expected call of GetByToken.
+func (mr *MockBoardShareMockRecorder) GetByToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockBoardShare)(nil).GetByToken), arg0)
+}
@@ -6,6 +6,7 @@ package mock_repositories
 
 import (
 	reflect "reflect"
+	filter "github.com/architectv/networking-course-project/backend/pkg/filter"
 	models "github.com/architectv/networking-course-project/backend/pkg/models"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +35,21 @@ func (m *MockTask) EXPECT() *MockTaskMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockTask) Bulk(arg0 int, arg1 []*models.BulkTaskOperation) ([]*models.BulkTaskResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", arg0, arg1)
+	ret0, _ := ret[0].([]*models.BulkTaskResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockTaskMockRecorder) Bulk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockTask)(nil).Bulk), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockTask) Create(arg0 *models.Task) (int, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +65,21 @@ func (mr *MockTaskMockRecorder) Create(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTask)(nil).Create), arg0)
 }
 
+// CreateWithLabels mocks base method.
+func (m *MockTask) CreateWithLabels(arg0 *models.Task, arg1 []int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithLabels", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithLabels indicates an expected call of CreateWithLabels.
+func (mr *MockTaskMockRecorder) CreateWithLabels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithLabels", reflect.TypeOf((*MockTask)(nil).CreateWithLabels), arg0, arg1)
+}
+
 // Delete mocks base method.
 func (m *MockTask) Delete(arg0 int) error {
 	m.ctrl.T.Helper()
@@ -78,6 +109,21 @@ func (mr *MockTaskMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTask)(nil).GetAll), arg0)
 }
 
+// GetAllInBoard mocks base method.
+func (m *MockTask) GetAllInBoard(arg0 int) ([]*models.TaskRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllInBoard", arg0)
+	ret0, _ := ret[0].([]*models.TaskRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllInBoard indicates an expected call of GetAllInBoard.
+func (mr *MockTaskMockRecorder) GetAllInBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllInBoard", reflect.TypeOf((*MockTask)(nil).GetAllInBoard), arg0)
+}
+
 // GetById mocks base method.
 func (m *MockTask) GetById(arg0 int) (*models.Task, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +139,51 @@ func (mr *MockTaskMockRecorder) GetById(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTask)(nil).GetById), arg0)
 }
 
+// GetFiltered mocks base method.
+func (m *MockTask) GetFiltered(arg0, arg1 int, arg2 *filter.Query) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiltered", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFiltered indicates an expected call of GetFiltered.
+func (mr *MockTaskMockRecorder) GetFiltered(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiltered", reflect.TypeOf((*MockTask)(nil).GetFiltered), arg0, arg1, arg2)
+}
+
+// GetUsers mocks base method.
+func (m *MockTask) GetUsers(arg0 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", arg0)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockTaskMockRecorder) GetUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockTask)(nil).GetUsers), arg0)
+}
+
+// Move mocks base method.
+func (m *MockTask) Move(arg0, arg1 int, arg2 *int, arg3 []int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTaskMockRecorder) Move(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTask)(nil).Move), arg0, arg1, arg2, arg3)
+}
+
 // Update mocks base method.
 func (m *MockTask) Update(arg0 int, arg1 *models.UpdateTask) error {
 	m.ctrl.T.Helper()
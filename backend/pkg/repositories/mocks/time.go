@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Time)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTime is a mock of Time interface.
+type MockTime struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeMockRecorder
+}
+
+// MockTimeMockRecorder is the mock recorder for MockTime.
+type MockTimeMockRecorder struct {
+	mock *MockTime
+}
+
+// NewMockTime creates a new mock instance.
+func NewMockTime(ctrl *gomock.Controller) *MockTime {
+	mock := &MockTime{ctrl: ctrl}
+	mock.recorder = &MockTimeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTime) EXPECT() *MockTimeMockRecorder {
+	return m.recorder
+}
+
+// CreateLog mocks base method.
+func (m *MockTime) CreateLog(arg0 *models.TimeLog) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLog", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLog indicates an expected call of CreateLog.
+func (mr *MockTimeMockRecorder) CreateLog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLog", reflect.TypeOf((*MockTime)(nil).CreateLog), arg0)
+}
+
+// DeleteLog mocks base method.
+func (m *MockTime) DeleteLog(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLog", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLog indicates an expected call of DeleteLog.
+func (mr *MockTimeMockRecorder) DeleteLog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLog", reflect.TypeOf((*MockTime)(nil).DeleteLog), arg0)
+}
+
+// GetEstimate mocks base method.
+func (m *MockTime) GetEstimate(arg0 int) (*models.TimeEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstimate", arg0)
+	ret0, _ := ret[0].(*models.TimeEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEstimate indicates an expected call of GetEstimate.
+func (mr *MockTimeMockRecorder) GetEstimate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstimate", reflect.TypeOf((*MockTime)(nil).GetEstimate), arg0)
+}
+
+// GetLog mocks base method.
+func (m *MockTime) GetLog(arg0 int) (*models.TimeLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLog", arg0)
+	ret0, _ := ret[0].(*models.TimeLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLog indicates an expected call of GetLog.
+func (mr *MockTimeMockRecorder) GetLog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLog", reflect.TypeOf((*MockTime)(nil).GetLog), arg0)
+}
+
+// GetLogs mocks base method.
+func (m *MockTime) GetLogs(arg0 int) ([]*models.TimeLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogs", arg0)
+	ret0, _ := ret[0].([]*models.TimeLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogs indicates an expected call of GetLogs.
+func (mr *MockTimeMockRecorder) GetLogs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockTime)(nil).GetLogs), arg0)
+}
+
+// GetReport mocks base method.
+func (m *MockTime) GetReport(arg0 *models.TimeReportQuery, arg1 []int) ([]*models.TimeReportEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", arg0, arg1)
+	ret0, _ := ret[0].([]*models.TimeReportEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockTimeMockRecorder) GetReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockTime)(nil).GetReport), arg0, arg1)
+}
+
+// GetTimer mocks base method.
+func (m *MockTime) GetTimer(arg0 int) (*models.Timer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimer", arg0)
+	ret0, _ := ret[0].(*models.Timer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimer indicates an expected call of GetTimer.
+func (mr *MockTimeMockRecorder) GetTimer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimer", reflect.TypeOf((*MockTime)(nil).GetTimer), arg0)
+}
+
+// StartTimer mocks base method.
+func (m *MockTime) StartTimer(arg0, arg1 int, arg2 int64) (*models.TimeLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.TimeLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockTimeMockRecorder) StartTimer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockTime)(nil).StartTimer), arg0, arg1, arg2)
+}
+
+// StopTimer mocks base method.
+func (m *MockTime) StopTimer(arg0 int, arg1 int64, arg2 string) (*models.TimeLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.TimeLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTimeMockRecorder) StopTimer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTime)(nil).StopTimer), arg0, arg1, arg2)
+}
+
+// UpdateEstimate mocks base method.
+func (m *MockTime) UpdateEstimate(arg0 int, arg1 *models.UpdateTimeEstimate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEstimate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEstimate indicates an expected call of UpdateEstimate.
+func (mr *MockTimeMockRecorder) UpdateEstimate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEstimate", reflect.TypeOf((*MockTime)(nil).UpdateEstimate), arg0, arg1)
+}
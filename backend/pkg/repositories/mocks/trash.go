@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: Trash)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTrash is a mock of Trash interface.
+type MockTrash struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashMockRecorder
+}
+
+// MockTrashMockRecorder is the mock recorder for MockTrash.
+type MockTrashMockRecorder struct {
+	mock *MockTrash
+}
+
+// NewMockTrash creates a new mock instance.
+func NewMockTrash(ctrl *gomock.Controller) *MockTrash {
+	mock := &MockTrash{ctrl: ctrl}
+	mock.recorder = &MockTrashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrash) EXPECT() *MockTrashMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockTrash) GetAll(arg0 int) ([]*models.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]*models.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTrashMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTrash)(nil).GetAll), arg0)
+}
+
+// GetById mocks base method.
+func (m *MockTrash) GetById(arg0 string, arg1 int) (*models.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(*models.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTrashMockRecorder) GetById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTrash)(nil).GetById), arg0, arg1)
+}
+
+// GetProjects mocks base method.
+func (m *MockTrash) GetProjects(arg0 int) ([]*models.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", arg0)
+	ret0, _ := ret[0].([]*models.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockTrashMockRecorder) GetProjects(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockTrash)(nil).GetProjects), arg0)
+}
+
+// Purge mocks base method.
+func (m *MockTrash) Purge(arg0 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrash)(nil).Purge), arg0)
+}
+
+// Restore mocks base method.
+func (m *MockTrash) Restore(arg0 string, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTrashMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTrash)(nil).Restore), arg0, arg1)
+}
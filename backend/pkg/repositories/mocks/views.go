@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/architectv/networking-course-project/backend/pkg/repositories (interfaces: View)
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	models "github.com/architectv/networking-course-project/backend/pkg/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockView is a mock of View interface.
+type MockView struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewMockRecorder
+}
+
+// MockViewMockRecorder is the mock recorder for MockView.
+type MockViewMockRecorder struct {
+	mock *MockView
+}
+
+// NewMockView creates a new mock instance.
+func NewMockView(ctrl *gomock.Controller) *MockView {
+	mock := &MockView{ctrl: ctrl}
+	mock.recorder = &MockViewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockView) EXPECT() *MockViewMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockView) Create(arg0 *models.BoardView) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockViewMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockView)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockView) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockViewMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockView)(nil).Delete), arg0)
+}
+
+// DeleteDefault mocks base method.
+func (m *MockView) DeleteDefault(arg0, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDefault", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDefault indicates an expected call of DeleteDefault.
+func (mr *MockViewMockRecorder) DeleteDefault(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDefault", reflect.TypeOf((*MockView)(nil).DeleteDefault), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockView) GetAll(arg0, arg1 int) ([]*models.BoardView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
+	ret0, _ := ret[0].([]*models.BoardView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockViewMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockView)(nil).GetAll), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockView) GetById(arg0, arg1 int) (*models.BoardView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(*models.BoardView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockViewMockRecorder) GetById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockView)(nil).GetById), arg0, arg1)
+}
+
+// SetDefault mocks base method.
+func (m *MockView) SetDefault(arg0, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefault", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefault indicates an expected call of SetDefault.
+func (mr *MockViewMockRecorder) SetDefault(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefault", reflect.TypeOf((*MockView)(nil).SetDefault), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockView) Update(arg0 int, arg1 *models.UpdateBoardView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockViewMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockView)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type AccessPg struct {
+	db *sqlx.DB
+}
+
+func NewAccessPg(db *sqlx.DB) *AccessPg {
+	return &AccessPg{db: db}
+}
+
+func (r *AccessPg) GetProjectSources(userId, projectId int) (*models.AccessSources, error) {
+	sources := &models.AccessSources{UserId: userId}
+	var project, groups [3]sql.NullBool
+
+	query := fmt.Sprintf(
+		`SELECT p.id, p.owner_id = $2, per.read, per.write, per.admin,
+		gper.read, gper.write, gper.admin
+		FROM %s AS p
+			LEFT JOIN %s AS pu ON pu.project_id = p.id AND pu.user_id = $2
+			LEFT JOIN %s AS per ON pu.permissions_id = per.id
+			LEFT JOIN LATERAL (%s) AS gper ON true
+		WHERE p.id = $1 AND p.deleted_at IS NULL`,
+		projectsTable, projectUsersTable, permissionsTable,
+		groupPermsQuery(projectGroupsTable, "project_id", "p.id", "$2"))
+
+	row := r.db.QueryRow(query, projectId, userId)
+	err := row.Scan(&sources.ProjectId, &sources.IsProjectOwner,
+		&project[0], &project[1], &project[2],
+		&groups[0], &groups[1], &groups[2])
+	if err != nil {
+		return nil, err
+	}
+
+	sources.Project = nullPermission(project[0], project[1], project[2])
+	sources.ProjectGroups = nullPermission(groups[0], groups[1], groups[2])
+	return sources, nil
+}
+
+func (r *AccessPg) GetBoardSources(userId, boardId int) (*models.AccessSources, error) {
+	query := fmt.Sprintf("%s WHERE b.id = $1 AND b.deleted_at IS NULL", r.boardSourcesQuery(singleUser))
+
+	rows, err := r.db.Query(query, boardId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sources, err := scanBoardSources(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return sources[0], nil
+}
+
+// A share guest is only known by the share link; the link counts while it
+// points at the board and has not expired.
+func (r *AccessPg) GetShareSources(shareId, boardId int, now int64) (*models.AccessSources, error) {
+	sources := &models.AccessSources{}
+
+	query := fmt.Sprintf(
+		`SELECT b.project_id, b.id, EXISTS (
+			SELECT 1 FROM %s AS s
+			WHERE s.id = $2 AND s.board_id = b.id AND (s.expires = 0 OR s.expires > $3))
+		FROM %s AS b WHERE b.id = $1 AND b.deleted_at IS NULL`,
+		boardSharesTable, boardsTable)
+
+	row := r.db.QueryRow(query, boardId, shareId, now)
+	err := row.Scan(&sources.ProjectId, &sources.BoardId, &sources.IsShareGuest)
+	if err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+func (r *AccessPg) GetBoardsSources(userId, projectId int) ([]*models.AccessSources, error) {
+	query := fmt.Sprintf("%s WHERE b.project_id = $1 AND b.deleted_at IS NULL ORDER BY b.id",
+		r.boardSourcesQuery(singleUser))
+
+	rows, err := r.db.Query(query, projectId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBoardSources(rows)
+}
+
+// GetUsersSources returns the sources of each of the users on the board, in
+// one query instead of one per user.
+func (r *AccessPg) GetUsersSources(boardId int, userIds []int) ([]*models.AccessSources, error) {
+	query := fmt.Sprintf("%s WHERE b.id = $1 AND b.deleted_at IS NULL ORDER BY u.id",
+		r.boardSourcesQuery(manyUsers))
+
+	rows, err := r.db.Query(query, boardId, pq.Array(userIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBoardSources(rows)
+}
+
+// GetReadableProjects returns the ids of the projects the user is a member
+// of with read access, like the project list does.
+func (r *AccessPg) GetReadableProjects(userId int) ([]int, error) {
+	projectIds := make([]int, 0)
+	query := fmt.Sprintf(
+		`SELECT p.id
+		FROM %s AS pu
+			INNER JOIN %s AS per ON pu.permissions_id = per.id
+			INNER JOIN %s AS p ON pu.project_id = p.id
+		WHERE pu.user_id = $1 AND per.read = true AND p.deleted_at IS NULL
+		ORDER BY p.id`,
+		projectUsersTable, permissionsTable, projectsTable)
+	if err := r.db.Select(&projectIds, query, userId); err != nil {
+		return nil, err
+	}
+	return projectIds, nil
+}
+
+func (r *AccessPg) IsListArchived(listId int) (bool, error) {
+	var archived bool
+	query := fmt.Sprintf(`SELECT archived FROM %s WHERE id = $1`, taskListsTable)
+	err := r.db.QueryRow(query, listId).Scan(&archived)
+	return archived, err
+}
+
+// The users of boardSourcesQuery: the user id or an array of them in $2.
+const (
+	singleUser = "(SELECT $2::int AS id) AS u"
+	manyUsers  = "unnest($2::int[]) AS u(id)"
+)
+
+func (r *AccessPg) boardSourcesQuery(users string) string {
+	return fmt.Sprintf(
+		`SELECT u.id, b.id, b.project_id, p.owner_id = u.id, b.owner_id = u.id, b.archived,
+		pper.read, pper.write, pper.admin,
+		pgper.read, pgper.write, pgper.admin,
+		dper.read, dper.write, dper.admin,
+		aper.read, aper.write, aper.admin,
+		bgper.read, bgper.write, bgper.admin,
+		nper.read, nper.write, nper.admin
+		FROM %s AS b
+			CROSS JOIN %s
+			INNER JOIN %s AS p ON b.project_id = p.id AND p.deleted_at IS NULL
+			INNER JOIN %s AS dper ON b.default_permissions_id = dper.id
+			LEFT JOIN %s AS pu ON pu.project_id = b.project_id AND pu.user_id = u.id
+			LEFT JOIN %s AS pper ON pu.permissions_id = pper.id
+			LEFT JOIN %s AS bu ON bu.board_id = b.id AND bu.user_id = u.id
+			LEFT JOIN %s AS aper ON bu.permissions_id = aper.id
+			LEFT JOIN %s AS bd ON bd.board_id = b.id AND bd.user_id = u.id
+			LEFT JOIN %s AS nper ON bd.permissions_id = nper.id
+			LEFT JOIN LATERAL (%s) AS pgper ON true
+			LEFT JOIN LATERAL (%s) AS bgper ON true`,
+		boardsTable, users, projectsTable, permissionsTable,
+		projectUsersTable, permissionsTable,
+		boardUsersTable, permissionsTable,
+		boardDenialsTable, permissionsTable,
+		groupPermsQuery(projectGroupsTable, "project_id", "b.project_id", "u.id"),
+		groupPermsQuery(boardGroupsTable, "board_id", "b.id", "u.id"))
+}
+
+// Union of the permissions granted to the user's groups on one object;
+// bool_or yields NULL when none of the groups has a grant.
+func groupPermsQuery(table, idTitle, objectColumn, userColumn string) string {
+	return fmt.Sprintf(
+		`SELECT bool_or(gp.read) AS read, bool_or(gp.write) AS write, bool_or(gp.admin) AS admin
+		FROM %s AS og
+			INNER JOIN %s AS gu ON gu.group_id = og.group_id
+			INNER JOIN %s AS gp ON og.permissions_id = gp.id
+		WHERE og.%s = %s AND gu.user_id = %s`,
+		table, groupUsersTable, permissionsTable, idTitle, objectColumn, userColumn)
+}
+
+func scanBoardSources(rows *sql.Rows) ([]*models.AccessSources, error) {
+	var sourcesList []*models.AccessSources
+
+	for rows.Next() {
+		sources := &models.AccessSources{}
+		var project, projectGroups, allow, boardGroups, deny [3]sql.NullBool
+		defaults := &models.Permission{}
+
+		err := rows.Scan(&sources.UserId, &sources.BoardId, &sources.ProjectId,
+			&sources.IsProjectOwner, &sources.IsBoardOwner, &sources.IsArchived,
+			&project[0], &project[1], &project[2],
+			&projectGroups[0], &projectGroups[1], &projectGroups[2],
+			&defaults.Read, &defaults.Write, &defaults.Admin,
+			&allow[0], &allow[1], &allow[2],
+			&boardGroups[0], &boardGroups[1], &boardGroups[2],
+			&deny[0], &deny[1], &deny[2])
+		if err != nil {
+			return nil, err
+		}
+
+		sources.Project = nullPermission(project[0], project[1], project[2])
+		sources.ProjectGroups = nullPermission(projectGroups[0], projectGroups[1], projectGroups[2])
+		sources.BoardDefault = defaults
+		sources.BoardAllow = nullPermission(allow[0], allow[1], allow[2])
+		sources.BoardGroups = nullPermission(boardGroups[0], boardGroups[1], boardGroups[2])
+		sources.BoardDeny = nullPermission(deny[0], deny[1], deny[2])
+		sourcesList = append(sourcesList, sources)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sourcesList, nil
+}
+
+func nullPermission(read, write, admin sql.NullBool) *models.Permission {
+	if !read.Valid {
+		return nil
+	}
+
+	return &models.Permission{
+		Read:  read.Bool,
+		Write: write.Bool,
+		Admin: admin.Bool,
+	}
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AttachmentPg struct {
+	db *sqlx.DB
+}
+
+func NewAttachmentPg(db *sqlx.DB) *AttachmentPg {
+	return &AttachmentPg{db: db}
+}
+
+const attachmentColumns = `id, task_id, COALESCE(uploader_id, 0) AS uploader_id, name,
+	content_type, size, storage_key, created`
+
+func (r *AttachmentPg) GetAll(taskId int) ([]*models.Attachment, error) {
+	attachments := make([]*models.Attachment, 0)
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE task_id = $1 ORDER BY id`,
+		attachmentColumns, attachmentsTable)
+	if err := r.db.Select(&attachments, query, taskId); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentPg) GetById(attachmentId int) (*models.Attachment, error) {
+	attachment := &models.Attachment{}
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, attachmentColumns, attachmentsTable)
+	if err := r.db.Get(attachment, query, attachmentId); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (r *AttachmentPg) Create(attachment *models.Attachment) (int, error) {
+	var id int
+	query := fmt.Sprintf(
+		`INSERT INTO %s (task_id, uploader_id, name, content_type, size, storage_key, created)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`, attachmentsTable)
+	row := r.db.QueryRow(query, attachment.TaskId, attachment.UploaderId, attachment.Name,
+		attachment.ContentType, attachment.Size, attachment.StorageKey, attachment.Created)
+	err := row.Scan(&id)
+	return id, err
+}
+
+func (r *AttachmentPg) Delete(attachmentId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, attachmentsTable)
+	_, err := r.db.Exec(query, attachmentId)
+	return err
+}
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/lib/pq"
+)
+
+// GetContent takes a snapshot of the live lists and labels of the board,
+// and of the tasks in those lists when withTasks is set.
+func (r *BoardPg) GetContent(boardId int, withTasks bool) (*models.BoardContent, error) {
+	content := &models.BoardContent{
+		Lists:  make([]*models.ListContent, 0),
+		Labels: make([]*models.LabelContent, 0),
+	}
+
+	query := fmt.Sprintf(
+		`SELECT id, name, color FROM %s WHERE board_id = $1 ORDER BY id`, labelsTable)
+	if err := r.db.Select(&content.Labels, query, boardId); err != nil {
+		return nil, err
+	}
+
+	fields, err := selectFields(r.db, "board_id = $1", boardId)
+	if err != nil {
+		return nil, err
+	}
+	content.Fields = fields
+
+	var lists []struct {
+		Id    int
+		Title string
+	}
+	query = fmt.Sprintf(
+		`SELECT id, title FROM %s
+		WHERE board_id = $1 AND deleted_at IS NULL
+		ORDER BY position`, taskListsTable)
+	if err := r.db.Select(&lists, query, boardId); err != nil {
+		return nil, err
+	}
+
+	listsById := make(map[int]*models.ListContent, len(lists))
+	for _, list := range lists {
+		listContent := &models.ListContent{Title: list.Title}
+		listsById[list.Id] = listContent
+		content.Lists = append(content.Lists, listContent)
+	}
+
+	if !withTasks {
+		return content, nil
+	}
+
+	query = fmt.Sprintf(
+		`SELECT t.id, t.list_id, t.title, COALESCE(t.description, ''),
+			COALESCE(array_agg(tlb.label_id) FILTER (WHERE tlb.label_id IS NOT NULL), '{}')
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			LEFT JOIN %s AS tlb ON tlb.task_id = t.id
+		WHERE tl.board_id = $1 AND tl.deleted_at IS NULL AND t.deleted_at IS NULL
+		GROUP BY t.id
+		ORDER BY t.position`,
+		tasksTable, taskListsTable, taskLabelsTable)
+
+	rows, err := r.db.Query(query, boardId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasksById := make(map[int]*models.TaskContent)
+	for rows.Next() {
+		var taskId, listId int
+		var labelIds pq.Int64Array
+		task := &models.TaskContent{}
+		err := rows.Scan(&taskId, &listId, &task.Title, &task.Description, &labelIds)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, labelId := range labelIds {
+			task.LabelIds = append(task.LabelIds, int(labelId))
+		}
+		list := listsById[listId]
+		list.Tasks = append(list.Tasks, task)
+		tasksById[taskId] = task
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	values, err := selectFieldValues(r.db, "f.board_id = $1", boardId)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		if task, ok := tasksById[value.TaskId]; ok {
+			task.Values = append(task.Values, value)
+		}
+	}
+	return content, nil
+}
+
+// CreateWithContent creates the board like Create and fills it with the
+// content; positions follow the order of the lists and tasks.
+func (r *BoardPg) CreateWithContent(userId int, board *models.Board, content *models.BoardContent) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	boardId, err := createBoard(tx, userId, board)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err = fillBoard(tx, boardId, board.Datetimes, content); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	tx.Commit()
+	return boardId, nil
+}
+
+func fillBoard(tx *sql.Tx, boardId int, datetimes *models.Datetimes, content *models.BoardContent) error {
+	labelIds, err := createLabels(tx, boardId, content.Labels)
+	if err != nil {
+		return err
+	}
+
+	fields, err := createFields(tx, boardId, content.Fields)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (board_id, title, position)
+		VALUES ($1, $2, $3) RETURNING id`, taskListsTable)
+	for listPos, list := range content.Lists {
+		var listId int
+		if err := tx.QueryRow(query, boardId, list.Title, listPos).Scan(&listId); err != nil {
+			return err
+		}
+
+		for taskPos, task := range list.Tasks {
+			if err := createContentTask(tx, listId, taskPos, task, datetimes, labelIds, fields); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// createLabels returns the ids of the created labels by the ids of the
+// labels they were copied from.
+func createLabels(tx *sql.Tx, boardId int, labels []*models.LabelContent) (map[int]int, error) {
+	labelIds := make(map[int]int, len(labels))
+	query := fmt.Sprintf(
+		`INSERT INTO %s (board_id, name, color)
+		VALUES ($1, $2, $3) RETURNING id`, labelsTable)
+	for _, label := range labels {
+		var id int
+		if err := tx.QueryRow(query, boardId, label.Name, label.Color).Scan(&id); err != nil {
+			return nil, err
+		}
+		labelIds[label.Id] = id
+	}
+	return labelIds, nil
+}
+
+func createContentTask(tx *sql.Tx, listId, position int, task *models.TaskContent,
+	datetimes *models.Datetimes, labelIds map[int]int, fields map[int]*copiedField) error {
+	datetimesId, err := createDatetimes(tx, datetimes)
+	if err != nil {
+		return err
+	}
+
+	var taskId int
+	query := fmt.Sprintf(
+		`INSERT INTO %s (list_id, title, description, datetimes_id, position)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`, tasksTable)
+	row := tx.QueryRow(query, listId, task.Title, task.Description, datetimesId, position)
+	if err := row.Scan(&taskId); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`INSERT INTO %s (task_id, label_id) VALUES ($1, $2)`, taskLabelsTable)
+	for _, labelId := range task.LabelIds {
+		newLabelId, ok := labelIds[labelId]
+		if !ok {
+			continue
+		}
+		if _, err := tx.Exec(query, taskId, newLabelId); err != nil {
+			return err
+		}
+	}
+
+	for _, value := range task.Values {
+		field, ok := fields[value.FieldId]
+		if !ok || field.fieldType == models.FieldUser {
+			// Like assignees, users are not copied.
+			continue
+		}
+		copied := *value
+		if value.OptionIds != nil {
+			copied.OptionIds = make([]int, 0, len(value.OptionIds))
+			for _, optionId := range value.OptionIds {
+				if id, ok := field.optionIds[optionId]; ok {
+					copied.OptionIds = append(copied.OptionIds, id)
+				}
+			}
+			if len(copied.OptionIds) == 0 {
+				continue
+			}
+		}
+		if err := insertFieldValue(tx, taskId, field.id, &copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copiedField is a field created from the content of another board, with
+// the ids of its options by the ids of those they were copied from.
+type copiedField struct {
+	id        int
+	fieldType string
+	optionIds map[int]int
+}
+
+// createFields returns the created fields by the ids of the fields they were
+// copied from.
+func createFields(tx *sql.Tx, boardId int, fields []*models.CustomField) (map[int]*copiedField, error) {
+	copied := make(map[int]*copiedField, len(fields))
+	for _, field := range fields {
+		id, optionIds, err := createField(tx, boardId, field)
+		if err != nil {
+			return nil, err
+		}
+		copied[field.Id] = &copiedField{id: id, fieldType: field.Type, optionIds: optionIds}
+	}
+	return copied, nil
+}
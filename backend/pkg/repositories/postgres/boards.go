@@ -4,10 +4,12 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type BoardPg struct {
@@ -19,22 +21,31 @@ func NewBoardPg(db *sqlx.DB) *BoardPg {
 }
 
 func (r *BoardPg) Create(userId int, board *models.Board) (int, error) {
-	var boardId int
-
 	tx, err := r.db.Begin()
 	if err != nil {
 		return 0, err
 	}
 
-	defPermissionId, err := createPermissions(tx, board.DefaultPermissions)
+	boardId, err := createBoard(tx, userId, board)
 	if err != nil {
 		tx.Rollback()
 		return 0, err
 	}
 
+	tx.Commit()
+	return boardId, nil
+}
+
+func createBoard(tx *sql.Tx, userId int, board *models.Board) (int, error) {
+	var boardId int
+
+	defPermissionId, err := createPermissions(tx, board.DefaultPermissions)
+	if err != nil {
+		return 0, err
+	}
+
 	datetimesId, err := createDatetimes(tx, board.Datetimes)
 	if err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
@@ -46,7 +57,6 @@ func (r *BoardPg) Create(userId int, board *models.Board) (int, error) {
 	row := tx.QueryRow(query, board.ProjectId, board.OwnerId, defPermissionId,
 		datetimesId, board.Title)
 	if err := row.Scan(&boardId); err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
@@ -57,7 +67,6 @@ func (r *BoardPg) Create(userId int, board *models.Board) (int, error) {
 	}
 	permissionId, err := createPermissions(tx, permission)
 	if err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
@@ -68,11 +77,9 @@ func (r *BoardPg) Create(userId int, board *models.Board) (int, error) {
 
 	_, err = tx.Exec(query, userId, boardId, permissionId)
 	if err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
-	tx.Commit()
 	return boardId, nil
 }
 
@@ -83,18 +90,19 @@ func (r *BoardPg) GetById(boardId int) (*models.Board, error) {
 
 	query := fmt.Sprintf(
 		`SELECT b.id, b.project_id, b.owner_id, bper.read, bper.write, bper.admin, 
-		d.created, d.updated, d.accessed, b.title
+		d.created, d.updated, d.accessed, b.title, b.archived
 		FROM %s AS b
 			INNER JOIN %s AS bper ON b.default_permissions_id = bper.id
 			INNER JOIN %s AS d ON b.datetimes_id = d.id
-		WHERE b.id = $1`,
-		boardsTable, permissionsTable, datetimesTable)
+			INNER JOIN %s AS p ON b.project_id = p.id
+		WHERE b.id = $1 AND b.deleted_at IS NULL AND p.deleted_at IS NULL`,
+		boardsTable, permissionsTable, datetimesTable, projectsTable)
 
 	row := r.db.QueryRow(query, boardId)
 	err := row.Scan(&board.Id, &board.ProjectId, &board.OwnerId,
 		&defaultPermissions.Read, &defaultPermissions.Write, &defaultPermissions.Admin,
 		&datetimes.Created, &datetimes.Updated, &datetimes.Accessed,
-		&board.Title)
+		&board.Title, &board.Archived)
 	if err != nil {
 		return nil, err
 	}
@@ -104,22 +112,20 @@ func (r *BoardPg) GetById(boardId int) (*models.Board, error) {
 	return board, nil
 }
 
-func (r *BoardPg) GetAll(userId, projectId int) ([]*models.Board, error) {
+func (r *BoardPg) GetAll(projectId int) ([]*models.Board, error) {
 	var boards []*models.Board
 
 	query := fmt.Sprintf(
 		`SELECT b.id, b.project_id, b.owner_id, bper.read, bper.write, bper.admin, 
-		d.created, d.updated, d.accessed, b.title
-		FROM %s AS bu
-			INNER JOIN %s AS per ON bu.permissions_id = per.id
-			INNER JOIN %s AS b ON bu.board_id = b.id
+		d.created, d.updated, d.accessed, b.title, b.archived
+		FROM %s AS b
 			INNER JOIN %s AS bper ON b.default_permissions_id = bper.id
 			INNER JOIN %s AS d ON b.datetimes_id = d.id
-		WHERE bu.user_id = $1 AND b.project_id = $2 AND per.read = true`,
-		boardUsersTable, permissionsTable, boardsTable, permissionsTable,
-		datetimesTable)
+		WHERE b.project_id = $1 AND b.deleted_at IS NULL
+		ORDER BY b.id`,
+		boardsTable, permissionsTable, datetimesTable)
 
-	rows, err := r.db.Query(query, userId, projectId)
+	rows, err := r.db.Query(query, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +139,7 @@ func (r *BoardPg) GetAll(userId, projectId int) ([]*models.Board, error) {
 		err := rows.Scan(&board.Id, &board.ProjectId, &board.OwnerId,
 			&defaultPermissions.Read, &defaultPermissions.Write, &defaultPermissions.Admin,
 			&datetimes.Created, &datetimes.Updated, &datetimes.Accessed,
-			&board.Title)
+			&board.Title, &board.Archived)
 
 		if err != nil {
 			return nil, err
@@ -199,36 +205,15 @@ func (r *BoardPg) Update(boardId int, input *models.UpdateBoard) error {
 }
 
 func (r *BoardPg) Delete(boardId int) error {
-	tx, err := r.db.Begin()
-	if err != nil {
-		return err
-	}
-
 	query := fmt.Sprintf(
-		`DELETE FROM %s AS per USING %s AS bu
-		WHERE per.id = bu.permissions_id AND bu.board_id=$1`,
-		permissionsTable, boardUsersTable)
-	_, err = tx.Exec(query, boardId)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	defPermissionsId, datetimesId, err := r.getBoardForeignKeys(boardId)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-	if err = deletePermissions(tx, defPermissionsId); err != nil {
-		tx.Rollback()
-		return err
-	}
-	if err = deleteDatetimes(tx, datetimesId); err != nil {
-		tx.Rollback()
-		return err
-	}
+		`UPDATE %s SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`, boardsTable)
+	_, err := r.db.Exec(query, boardId, time.Now().Unix())
+	return err
+}
 
-	tx.Commit()
+func (r *BoardPg) SetArchived(boardId int, archived bool) error {
+	query := fmt.Sprintf(`UPDATE %s SET archived = $2 WHERE id = $1`, boardsTable)
+	_, err := r.db.Exec(query, boardId, archived)
 	return err
 }
 
@@ -243,25 +228,6 @@ func (r *BoardPg) getBoardForeignKeys(boardId int) (int, int, error) {
 	return defPermissionsId, datetimesId, err
 }
 
-func (r *BoardPg) GetPermissions(userId, boardId int) (*models.Permission, error) {
-	permissions := &models.Permission{}
-
-	query := fmt.Sprintf(
-		`SELECT per.read, per.write, per.admin
-		FROM %s AS bu
-			INNER JOIN %s AS per ON bu.permissions_id = per.id
-		WHERE bu.board_id = $1 AND bu.user_id = $2`,
-		boardUsersTable, permissionsTable)
-
-	row := r.db.QueryRow(query, boardId, userId)
-	err := row.Scan(&permissions.Read, &permissions.Write, &permissions.Admin)
-	if err != nil {
-		return nil, err
-	}
-
-	return permissions, nil
-}
-
 func (r *BoardPg) GetBoardsCountByOwnerId(projectId, ownerId int) (int, error) {
 	var count int
 
@@ -292,21 +258,47 @@ func updateOwnerIdByBoardId(tx *sql.Tx, boardId, oldOwnerId, newOwnerId int) err
 	return err
 }
 
+// GetMembers lists the users with board grants and the project members who
+// may inherit access to the board; the latter come without permissions.
 func (r *BoardPg) GetMembers(boardId int) ([]*models.Member, error) {
 	var members []*models.Member
 
 	query := fmt.Sprintf(
-		`SELECT u.id, u.nickname, u.avatar, per.read, per.write, per.admin,
-		CASE b.owner_id
-		WHEN user_id THEN true
-		ELSE false
-		END AS isOwner
-		FROM %s AS pu
-			INNER JOIN %s AS per ON pu.permissions_id = per.id
-			INNER JOIN %s AS u ON pu.user_id = u.id
-			INNER JOIN %s AS b ON pu.board_id = b.id
-		WHERE pu.board_id = $1`,
-		boardUsersTable, permissionsTable, usersTable, boardsTable)
+		`SELECT u.id, u.nickname, u.avatar,
+		bool_or(per.read), bool_or(per.write), bool_or(per.admin),
+		u.id = b.owner_id AS isOwner,
+		bool_or(m.direct) AS isDirect,
+		array_remove(array_agg(m.group_id), NULL) AS groups
+		FROM (
+			SELECT bu.user_id, bu.permissions_id, true AS direct, NULL::int AS group_id
+			FROM %s AS bu
+			WHERE bu.board_id = $1
+			UNION ALL
+			SELECT gu.user_id, bg.permissions_id, false, bg.group_id
+			FROM %s AS bg
+				INNER JOIN %s AS gu ON gu.group_id = bg.group_id
+			WHERE bg.board_id = $1
+			UNION ALL
+			SELECT pu.user_id, NULL::int, false, NULL::int
+			FROM %s AS pu
+				INNER JOIN %s AS pb ON pb.project_id = pu.project_id
+			WHERE pb.id = $1
+			UNION ALL
+			SELECT gu.user_id, NULL::int, false, NULL::int
+			FROM %s AS pg
+				INNER JOIN %s AS gu ON gu.group_id = pg.group_id
+				INNER JOIN %s AS pb ON pb.project_id = pg.project_id
+			WHERE pb.id = $1
+		) AS m
+			LEFT JOIN %s AS per ON m.permissions_id = per.id
+			INNER JOIN %s AS u ON m.user_id = u.id
+			INNER JOIN %s AS b ON b.id = $1
+		GROUP BY u.id, b.owner_id
+		ORDER BY u.id`,
+		boardUsersTable, boardGroupsTable, groupUsersTable,
+		projectUsersTable, boardsTable,
+		projectGroupsTable, groupUsersTable, boardsTable,
+		permissionsTable, usersTable, boardsTable)
 
 	rows, err := r.db.Query(query, boardId)
 	if err != nil {
@@ -316,15 +308,19 @@ func (r *BoardPg) GetMembers(boardId int) ([]*models.Member, error) {
 
 	for rows.Next() {
 		member := &models.Member{}
-		permissions := &models.Permission{}
+		var permissions [3]sql.NullBool
+		var groups pq.Int64Array
 
-		err := rows.Scan(&member.Id, &member.Nickname, &member.Avatar, &permissions.Read,
-			&permissions.Write, &permissions.Admin, &member.IsOwner)
+		err := rows.Scan(&member.Id, &member.Nickname, &member.Avatar, &permissions[0],
+			&permissions[1], &permissions[2], &member.IsOwner, &member.IsDirect, &groups)
 		if err != nil {
 			return nil, err
 		}
 
-		member.Permissions = permissions
+		for _, groupId := range groups {
+			member.Groups = append(member.Groups, int(groupId))
+		}
+		member.Permissions = nullPermission(permissions[0], permissions[1], permissions[2])
 		members = append(members, member)
 	}
 
@@ -0,0 +1,344 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	fieldExists         = "Field already exists"
+	fieldOptionNotFound = "Option is not on the field"
+)
+
+type CustomFieldPg struct {
+	db *sqlx.DB
+}
+
+func NewCustomFieldPg(db *sqlx.DB) *CustomFieldPg {
+	return &CustomFieldPg{db: db}
+}
+
+func (r *CustomFieldPg) GetAll(boardId int) ([]*models.CustomField, error) {
+	return selectFields(r.db, "board_id = $1", boardId)
+}
+
+func (r *CustomFieldPg) GetById(fieldId int) (*models.CustomField, error) {
+	fields, err := selectFields(r.db, "id = $1", fieldId)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return fields[0], nil
+}
+
+// Create adds the field after the other fields of the board. Names are
+// unique on a board regardless of the case.
+func (r *CustomFieldPg) Create(field *models.CustomField) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkFieldName(tx, field.BoardId, 0, field.Name); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	fieldId, _, err := createField(tx, field.BoardId, field)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return fieldId, tx.Commit()
+}
+
+func (r *CustomFieldPg) Update(fieldId int, input *models.UpdateCustomField) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if input.Name != nil {
+		var boardId int
+		query := fmt.Sprintf(`SELECT board_id FROM %s WHERE id = $1 FOR UPDATE`, fieldsTable)
+		if err := tx.QueryRow(query, fieldId).Scan(&boardId); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := checkFieldName(tx, boardId, fieldId, *input.Name); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		query = fmt.Sprintf(`UPDATE %s SET name = $2 WHERE id = $1`, fieldsTable)
+		if _, err := tx.Exec(query, fieldId, *input.Name); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if input.Options != nil {
+		if err := updateFieldOptions(tx, fieldId, *input.Options); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes the field with its values.
+func (r *CustomFieldPg) Delete(fieldId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, fieldsTable)
+	_, err := r.db.Exec(query, fieldId)
+	return err
+}
+
+// GetValues returns the values of the tasks in the order of their fields.
+func (r *CustomFieldPg) GetValues(taskIds []int) ([]*models.CustomValue, error) {
+	ids := make(pq.Int64Array, len(taskIds))
+	for i, id := range taskIds {
+		ids[i] = int64(id)
+	}
+	return selectFieldValues(r.db, "v.task_id = ANY($1)", ids)
+}
+
+// SetValue replaces the value of the field of the task.
+func (r *CustomFieldPg) SetValue(value *models.CustomValue) error {
+	return insertFieldValue(r.db, value.TaskId, value.FieldId, value)
+}
+
+func (r *CustomFieldPg) DeleteValue(taskId, fieldId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE task_id = $1 AND field_id = $2`, fieldValuesTable)
+	_, err := r.db.Exec(query, taskId, fieldId)
+	return err
+}
+
+// selectFields returns the fields matching the condition with their
+// options.
+func selectFields(q sqlx.Queryer, condition string, args ...interface{}) ([]*models.CustomField, error) {
+	fields := make([]*models.CustomField, 0)
+	query := fmt.Sprintf(
+		`SELECT id, board_id, name, type, position FROM %s WHERE %s ORDER BY position, id`,
+		fieldsTable, condition)
+	if err := sqlx.Select(q, &fields, query, args...); err != nil {
+		return nil, err
+	}
+
+	fieldIds := make(pq.Int64Array, len(fields))
+	fieldsById := make(map[int]*models.CustomField, len(fields))
+	for i, field := range fields {
+		fieldIds[i] = int64(field.Id)
+		fieldsById[field.Id] = field
+	}
+
+	var options []struct {
+		FieldId int `db:"field_id"`
+		Id      int
+		Name    string
+	}
+	query = fmt.Sprintf(
+		`SELECT field_id, id, name FROM %s WHERE field_id = ANY($1) ORDER BY position, id`,
+		fieldOptionsTable)
+	if err := sqlx.Select(q, &options, query, fieldIds); err != nil {
+		return nil, err
+	}
+	for _, option := range options {
+		field := fieldsById[option.FieldId]
+		field.Options = append(field.Options, &models.FieldOption{Id: option.Id, Name: option.Name})
+	}
+	return fields, nil
+}
+
+func selectFieldValues(q sqlx.Queryer, condition string, args ...interface{}) ([]*models.CustomValue, error) {
+	query := fmt.Sprintf(
+		`SELECT v.task_id, v.field_id, v.text_value, v.number_value, v.date_value, v.checked,
+			v.user_id, v.option_ids
+		FROM %s AS v INNER JOIN %s AS f ON v.field_id = f.id
+		WHERE %s
+		ORDER BY v.task_id, f.position, f.id`,
+		fieldValuesTable, fieldsTable, condition)
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]*models.CustomValue, 0)
+	for rows.Next() {
+		var userId sql.NullInt64
+		var optionIds pq.Int64Array
+		value := &models.CustomValue{}
+		err := rows.Scan(&value.TaskId, &value.FieldId, &value.Text, &value.Number, &value.Date,
+			&value.Checked, &userId, &optionIds)
+		if err != nil {
+			return nil, err
+		}
+		if userId.Valid {
+			id := int(userId.Int64)
+			value.UserId = &id
+		}
+		for _, id := range optionIds {
+			value.OptionIds = append(value.OptionIds, int(id))
+		}
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func checkFieldName(tx *sql.Tx, boardId, fieldId int, name string) error {
+	var exists bool
+	query := fmt.Sprintf(
+		`SELECT EXISTS (SELECT 1 FROM %s WHERE board_id = $1 AND id <> $2 AND lower(name) = lower($3))`,
+		fieldsTable)
+	if err := tx.QueryRow(query, boardId, fieldId, name).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return errors.New(fieldExists)
+	}
+	return nil
+}
+
+// createField adds the field to the board and returns its id with the ids
+// of its options by the ids they were given, which clones use to copy the
+// values.
+func createField(tx *sql.Tx, boardId int, field *models.CustomField) (int, map[int]int, error) {
+	var fieldId int
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (board_id, name, type, position)
+		SELECT $1, $2, $3, COALESCE(MAX(position) + 1, 0) FROM %[1]s WHERE board_id = $1
+		RETURNING id`, fieldsTable)
+	if err := tx.QueryRow(query, boardId, field.Name, field.Type).Scan(&fieldId); err != nil {
+		return 0, nil, err
+	}
+
+	optionIds := make(map[int]int, len(field.Options))
+	query = fmt.Sprintf(
+		`INSERT INTO %s (field_id, name, position) VALUES ($1, $2, $3) RETURNING id`, fieldOptionsTable)
+	for position, option := range field.Options {
+		var id int
+		if err := tx.QueryRow(query, fieldId, option.Name, position).Scan(&id); err != nil {
+			return 0, nil, err
+		}
+		optionIds[option.Id] = id
+	}
+	return fieldId, optionIds, nil
+}
+
+// updateFieldOptions renames and reorders the options with an id, adds the
+// others and deletes the missing ones from the field and the values.
+func updateFieldOptions(tx *sql.Tx, fieldId int, options []*models.FieldOption) error {
+	kept := make(pq.Int64Array, 0, len(options))
+	for position, option := range options {
+		if option.Id == 0 {
+			query := fmt.Sprintf(
+				`INSERT INTO %s (field_id, name, position) VALUES ($1, $2, $3) RETURNING id`,
+				fieldOptionsTable)
+			if err := tx.QueryRow(query, fieldId, option.Name, position).Scan(&option.Id); err != nil {
+				return err
+			}
+		} else {
+			query := fmt.Sprintf(
+				`UPDATE %s SET name = $3, position = $4 WHERE id = $1 AND field_id = $2`,
+				fieldOptionsTable)
+			result, err := tx.Exec(query, option.Id, fieldId, option.Name, position)
+			if err != nil {
+				return err
+			}
+			if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+				return errors.New(fieldOptionNotFound)
+			}
+		}
+		kept = append(kept, int64(option.Id))
+	}
+
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE field_id = $1 AND NOT (id = ANY($2))`, fieldOptionsTable)
+	if _, err := tx.Exec(query, fieldId, kept); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`UPDATE %s SET option_ids = ARRAY(SELECT o FROM unnest(option_ids) AS o WHERE o = ANY($2))
+		WHERE field_id = $1`, fieldValuesTable)
+	if _, err := tx.Exec(query, fieldId, kept); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`DELETE FROM %s WHERE field_id = $1 AND cardinality(option_ids) = 0`, fieldValuesTable)
+	_, err := tx.Exec(query, fieldId)
+	return err
+}
+
+func insertFieldValue(e sqlx.Execer, taskId, fieldId int, value *models.CustomValue) error {
+	var optionIds interface{}
+	if value.OptionIds != nil {
+		ids := make(pq.Int64Array, len(value.OptionIds))
+		for i, id := range value.OptionIds {
+			ids[i] = int64(id)
+		}
+		optionIds = ids
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (task_id, field_id, text_value, number_value, date_value, checked,
+			user_id, option_ids)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (task_id, field_id) DO UPDATE SET
+			text_value = EXCLUDED.text_value, number_value = EXCLUDED.number_value,
+			date_value = EXCLUDED.date_value, checked = EXCLUDED.checked,
+			user_id = EXCLUDED.user_id, option_ids = EXCLUDED.option_ids`,
+		fieldValuesTable)
+	_, err := e.Exec(query, taskId, fieldId, value.Text, value.Number, value.Date, value.Checked,
+		value.UserId, optionIds)
+	return err
+}
+
+// remapTaskFields moves the values of the tasks that are not on the board to
+// the field of the same name and type on it, matching options by name, and
+// removes the rest along with the values of the users in dropUsers.
+func remapTaskFields(tx *sql.Tx, taskIds []int64, boardId int, dropUsers []int) error {
+	users := make(pq.Int64Array, len(dropUsers))
+	for i, id := range dropUsers {
+		users[i] = int64(id)
+	}
+
+	query := fmt.Sprintf(
+		`UPDATE %[1]s AS v SET field_id = target.id,
+			option_ids = CASE WHEN v.option_ids IS NULL THEN NULL ELSE array_remove(ARRAY(
+				SELECT (SELECT MIN(tgo.id)
+					FROM %[3]s AS tgo INNER JOIN %[3]s AS so ON lower(tgo.name) = lower(so.name)
+					WHERE so.id = o.id AND tgo.field_id = target.id)
+				FROM unnest(v.option_ids) WITH ORDINALITY AS o(id, n) ORDER BY o.n), NULL) END
+		FROM %[2]s AS src, %[2]s AS target
+		WHERE v.field_id = src.id AND src.board_id <> $2 AND v.task_id = ANY($1)
+			AND target.board_id = $2 AND lower(target.name) = lower(src.name)
+			AND target.type = src.type`,
+		fieldValuesTable, fieldsTable, fieldOptionsTable)
+	if _, err := tx.Exec(query, pq.Array(taskIds), boardId); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`DELETE FROM %s AS v USING %s AS f
+		WHERE v.field_id = f.id AND v.task_id = ANY($1)
+			AND (f.board_id <> $2 OR cardinality(v.option_ids) = 0 OR v.user_id = ANY($3))`,
+		fieldValuesTable, fieldsTable)
+	_, err := tx.Exec(query, pq.Array(taskIds), boardId, users)
+	return err
+}
@@ -0,0 +1,370 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ExportPg struct {
+	db *sqlx.DB
+}
+
+func NewExportPg(db *sqlx.DB) *ExportPg {
+	return &ExportPg{db: db}
+}
+
+// Export collects the live boards, lists and tasks of the project; archived
+// ones are kept with their flag.
+func (r *ExportPg) Export(projectId int) (*models.ProjectExport, error) {
+	archive := &models.ProjectExport{
+		DefaultPermissions: &models.Permission{},
+		Datetimes:          &models.Datetimes{},
+		Boards:             make([]*models.BoardExport, 0),
+	}
+
+	query := fmt.Sprintf(
+		`SELECT p.title, COALESCE(p.description, ''), dper.read, dper.write, dper.admin,
+		d.created, d.updated, d.accessed
+		FROM %s AS p
+			INNER JOIN %s AS dper ON p.default_permissions_id = dper.id
+			INNER JOIN %s AS d ON p.datetimes_id = d.id
+		WHERE p.id = $1 AND p.deleted_at IS NULL`,
+		projectsTable, permissionsTable, datetimesTable)
+
+	row := r.db.QueryRow(query, projectId)
+	err := row.Scan(&archive.Title, &archive.Description,
+		&archive.DefaultPermissions.Read, &archive.DefaultPermissions.Write,
+		&archive.DefaultPermissions.Admin, &archive.Datetimes.Created,
+		&archive.Datetimes.Updated, &archive.Datetimes.Accessed)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Members, err = r.getMembers(projectUsersTable, "project_id", projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	query = fmt.Sprintf(
+		`SELECT b.id, b.title, b.archived, dper.read, dper.write, dper.admin,
+		d.created, d.updated, d.accessed
+		FROM %s AS b
+			INNER JOIN %s AS dper ON b.default_permissions_id = dper.id
+			INNER JOIN %s AS d ON b.datetimes_id = d.id
+		WHERE b.project_id = $1 AND b.deleted_at IS NULL
+		ORDER BY b.id`,
+		boardsTable, permissionsTable, datetimesTable)
+
+	rows, err := r.db.Query(query, projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var boardIds []int
+	for rows.Next() {
+		var boardId int
+		board := &models.BoardExport{
+			DefaultPermissions: &models.Permission{},
+			Datetimes:          &models.Datetimes{},
+		}
+		err := rows.Scan(&boardId, &board.Title, &board.Archived,
+			&board.DefaultPermissions.Read, &board.DefaultPermissions.Write,
+			&board.DefaultPermissions.Admin, &board.Datetimes.Created,
+			&board.Datetimes.Updated, &board.Datetimes.Accessed)
+		if err != nil {
+			return nil, err
+		}
+		boardIds = append(boardIds, boardId)
+		archive.Boards = append(archive.Boards, board)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, board := range archive.Boards {
+		if err := r.fillBoardExport(boardIds[i], board); err != nil {
+			return nil, err
+		}
+	}
+	return archive, nil
+}
+
+func (r *ExportPg) getMembers(table, column string, objectId int) ([]*models.MemberExport, error) {
+	members := make([]*models.MemberExport, 0)
+	query := fmt.Sprintf(
+		`SELECT u.nickname, per.read, per.write, per.admin
+		FROM %s AS ou
+			INNER JOIN %s AS u ON ou.user_id = u.id
+			INNER JOIN %s AS per ON ou.permissions_id = per.id
+		WHERE ou.%s = $1
+		ORDER BY u.nickname`,
+		table, usersTable, permissionsTable, column)
+
+	rows, err := r.db.Query(query, objectId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		member := &models.MemberExport{Permissions: &models.Permission{}}
+		err := rows.Scan(&member.Nickname, &member.Permissions.Read,
+			&member.Permissions.Write, &member.Permissions.Admin)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *ExportPg) fillBoardExport(boardId int, board *models.BoardExport) error {
+	var err error
+	board.Members, err = r.getMembers(boardUsersTable, "board_id", boardId)
+	if err != nil {
+		return err
+	}
+
+	board.Labels = make([]*models.LabelContent, 0)
+	query := fmt.Sprintf(
+		`SELECT id, name, color FROM %s WHERE board_id = $1 ORDER BY id`, labelsTable)
+	if err := r.db.Select(&board.Labels, query, boardId); err != nil {
+		return err
+	}
+
+	var lists []struct {
+		Id       int
+		Title    string
+		Archived bool
+	}
+	query = fmt.Sprintf(
+		`SELECT id, title, archived FROM %s
+		WHERE board_id = $1 AND deleted_at IS NULL
+		ORDER BY position`, taskListsTable)
+	if err := r.db.Select(&lists, query, boardId); err != nil {
+		return err
+	}
+
+	board.Lists = make([]*models.ListExport, 0, len(lists))
+	listsById := make(map[int]*models.ListExport, len(lists))
+	for _, list := range lists {
+		listExport := &models.ListExport{
+			Title:    list.Title,
+			Archived: list.Archived,
+			Tasks:    make([]*models.TaskExport, 0),
+		}
+		listsById[list.Id] = listExport
+		board.Lists = append(board.Lists, listExport)
+	}
+
+	query = fmt.Sprintf(
+		`SELECT t.list_id, t.title, COALESCE(t.description, ''),
+			d.created, d.updated, d.accessed,
+			COALESCE(array_agg(tlb.label_id) FILTER (WHERE tlb.label_id IS NOT NULL), '{}')
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			INNER JOIN %s AS d ON t.datetimes_id = d.id
+			LEFT JOIN %s AS tlb ON tlb.task_id = t.id
+		WHERE tl.board_id = $1 AND tl.deleted_at IS NULL AND t.deleted_at IS NULL
+		GROUP BY t.id, d.id
+		ORDER BY t.position`,
+		tasksTable, taskListsTable, datetimesTable, taskLabelsTable)
+
+	rows, err := r.db.Query(query, boardId)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var listId int
+		var labelIds pq.Int64Array
+		task := &models.TaskExport{Datetimes: &models.Datetimes{}}
+		err := rows.Scan(&listId, &task.Title, &task.Description,
+			&task.Datetimes.Created, &task.Datetimes.Updated,
+			&task.Datetimes.Accessed, &labelIds)
+		if err != nil {
+			return err
+		}
+
+		for _, labelId := range labelIds {
+			task.LabelIds = append(task.LabelIds, int(labelId))
+		}
+		list := listsById[listId]
+		list.Tasks = append(list.Tasks, task)
+	}
+
+	return rows.Err()
+}
+
+// Import creates the project owned by the user in a single transaction and
+// returns its id along with the nicknames of the members that were skipped
+// because there are no such users. The other members are invited, with the
+// invitations created and expiring at the given times.
+func (r *ExportPg) Import(userId int, archive *models.ProjectExport, created, expires int64) (int, []string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	project := &models.Project{
+		OwnerId:            userId,
+		DefaultPermissions: archive.DefaultPermissions,
+		Datetimes:          archive.Datetimes,
+		Title:              archive.Title,
+		Description:        archive.Description,
+	}
+	projectId, err := createProject(tx, project)
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	invite := &memberInviter{
+		tx:        tx,
+		inviterId: userId,
+		projectId: projectId,
+		created:   created,
+		expires:   expires,
+		missing:   make(map[string]bool),
+	}
+	if err := invite.members(0, archive.Members); err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	for _, board := range archive.Boards {
+		if err := importBoard(tx, userId, projectId, board, invite); err != nil {
+			tx.Rollback()
+			return 0, nil, err
+		}
+	}
+
+	tx.Commit()
+
+	nicknames := make([]string, 0, len(invite.missing))
+	for nickname := range invite.missing {
+		nicknames = append(nicknames, nickname)
+	}
+	sort.Strings(nicknames)
+	return projectId, nicknames, nil
+}
+
+func importBoard(tx *sql.Tx, userId, projectId int, board *models.BoardExport,
+	invite *memberInviter) error {
+	boardId, err := createBoard(tx, userId, &models.Board{
+		ProjectId:          projectId,
+		OwnerId:            userId,
+		DefaultPermissions: board.DefaultPermissions,
+		Datetimes:          board.Datetimes,
+		Title:              board.Title,
+	})
+	if err != nil {
+		return err
+	}
+
+	if board.Archived {
+		query := fmt.Sprintf(`UPDATE %s SET archived = true WHERE id = $1`, boardsTable)
+		if _, err := tx.Exec(query, boardId); err != nil {
+			return err
+		}
+	}
+
+	if err := invite.members(boardId, board.Members); err != nil {
+		return err
+	}
+
+	labelIds, err := createLabels(tx, boardId, board.Labels)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (board_id, title, position, archived)
+		VALUES ($1, $2, $3, $4) RETURNING id`, taskListsTable)
+	for listPos, list := range board.Lists {
+		var listId int
+		row := tx.QueryRow(query, boardId, list.Title, listPos, list.Archived)
+		if err := row.Scan(&listId); err != nil {
+			return err
+		}
+
+		for taskPos, task := range list.Tasks {
+			content := &models.TaskContent{
+				Title:       task.Title,
+				Description: task.Description,
+				LabelIds:    task.LabelIds,
+			}
+			err := createContentTask(tx, listId, taskPos, content, task.Datetimes, labelIds, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// memberInviter invites the members of an imported document, like admins
+// would invite them, rather than adding them to the project right away.
+type memberInviter struct {
+	tx        *sql.Tx
+	inviterId int
+	projectId int
+	created   int64
+	expires   int64
+	missing   map[string]bool
+}
+
+// members invites the members to the project, or to the board when boardId
+// is set. The importing user owns both already and is skipped.
+func (i *memberInviter) members(boardId int, members []*models.MemberExport) error {
+	var board sql.NullInt64
+	if boardId != 0 {
+		board.Int64, board.Valid = int64(boardId), true
+	}
+
+	userQuery := fmt.Sprintf(`SELECT id FROM %s WHERE nickname = $1`, usersTable)
+	invitationQuery := fmt.Sprintf(
+		`INSERT INTO %s (project_id, board_id, inviter_id, invitee_id, permissions_id, created, expires)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`, invitationsTable)
+
+	invited := make(map[int]bool, len(members))
+	for _, member := range members {
+		var memberId int
+		err := i.tx.QueryRow(userQuery, member.Nickname).Scan(&memberId)
+		if err == sql.ErrNoRows {
+			i.missing[member.Nickname] = true
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if memberId == i.inviterId || invited[memberId] {
+			continue
+		}
+		invited[memberId] = true
+
+		permissionsId, err := createPermissions(i.tx, member.Permissions)
+		if err != nil {
+			return err
+		}
+		_, err = i.tx.Exec(invitationQuery, i.projectId, board, i.inviterId, memberId, permissionsId,
+			i.created, i.expires)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
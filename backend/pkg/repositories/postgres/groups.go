@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	memberNotInProject = "Member is not in the project"
+	memberInGroup      = "Member is already in the group"
+	userNotFound       = "User is not found"
+)
+
+type GroupPg struct {
+	db *sqlx.DB
+}
+
+func NewGroupPg(db *sqlx.DB) *GroupPg {
+	return &GroupPg{db: db}
+}
+
+func (r *GroupPg) GetAll(projectId int) ([]*models.Group, error) {
+	var groups []*models.Group
+	query := fmt.Sprintf(
+		`SELECT g.id, g.project_id, g.owner_id, g.title, g.description
+		FROM %s AS g WHERE g.project_id = $1 ORDER BY g.id`, groupsTable)
+
+	rows, err := r.db.Query(query, projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		group := &models.Group{}
+		err := rows.Scan(&group.Id, &group.ProjectId, &group.OwnerId, &group.Title, &group.Description)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *GroupPg) GetById(groupId int) (*models.Group, error) {
+	group := &models.Group{}
+	query := fmt.Sprintf(
+		`SELECT g.id, g.project_id, g.owner_id, g.title, g.description
+		FROM %s AS g WHERE g.id = $1`, groupsTable)
+
+	row := r.db.QueryRow(query, groupId)
+	err := row.Scan(&group.Id, &group.ProjectId, &group.OwnerId, &group.Title, &group.Description)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (r *GroupPg) Create(group *models.Group) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (project_id, owner_id, title, description)
+		VALUES ($1, $2, $3, $4) RETURNING id`, groupsTable)
+
+	var id int
+	row := tx.QueryRow(query, group.ProjectId, group.OwnerId, group.Title, group.Description)
+	if err := row.Scan(&id); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	tx.Commit()
+	return id, nil
+}
+
+func (r *GroupPg) Update(groupId int, input *models.UpdateGroup) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Title != nil {
+		setValues = append(setValues, fmt.Sprintf("title=$%d", argId))
+		args = append(args, *input.Title)
+		argId++
+	}
+
+	if input.Description != nil {
+		setValues = append(setValues, fmt.Sprintf("description=$%d", argId))
+		args = append(args, *input.Description)
+		argId++
+	}
+
+	if len(setValues) == 0 {
+		return nil
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+	query := fmt.Sprintf(`UPDATE %s SET %s WHERE id=$%d`,
+		groupsTable, setQuery, argId)
+	args = append(args, groupId)
+	_, err := r.db.Exec(query, args...)
+	return err
+}
+
+func (r *GroupPg) Delete(groupId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{projectGroupsTable, boardGroupsTable} {
+		query := fmt.Sprintf(
+			`DELETE FROM %s AS per USING %s AS og
+			WHERE per.id = og.permissions_id AND og.group_id = $1`,
+			permissionsTable, table)
+		_, err = tx.Exec(query, groupId)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, groupsTable)
+	_, err = tx.Exec(query, groupId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+func (r *GroupPg) GetMembers(groupId int) ([]*models.Member, error) {
+	var members []*models.Member
+	query := fmt.Sprintf(
+		`SELECT u.id, u.nickname, u.avatar, u.id = g.owner_id AS isOwner
+		FROM %s AS gu
+			INNER JOIN %s AS u ON gu.user_id = u.id
+			INNER JOIN %s AS g ON gu.group_id = g.id
+		WHERE gu.group_id = $1
+		ORDER BY u.id`,
+		groupUsersTable, usersTable, groupsTable)
+
+	rows, err := r.db.Query(query, groupId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		member := &models.Member{IsDirect: true}
+		err := rows.Scan(&member.Id, &member.Nickname, &member.Avatar, &member.IsOwner)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *GroupPg) AddMember(groupId int, memberNickname string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	memberId, err := getUserIdByNickname(tx, memberNickname)
+	if err != nil {
+		tx.Rollback()
+		return 0, errors.New(userNotFound)
+	}
+
+	// Only those who accepted their invitation to the project may join its
+	// groups, so that the grants of a group never bring in anyone else.
+	var isMember bool
+	query := fmt.Sprintf(
+		`SELECT EXISTS (SELECT 1 FROM %s AS pu
+			INNER JOIN %s AS g ON g.project_id = pu.project_id
+		WHERE g.id = $1 AND pu.user_id = $2)`,
+		projectUsersTable, groupsTable)
+	if err := tx.QueryRow(query, groupId, memberId).Scan(&isMember); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if !isMember {
+		tx.Rollback()
+		return 0, errors.New(memberNotInProject)
+	}
+
+	var exists bool
+	query = fmt.Sprintf(
+		`SELECT EXISTS (SELECT 1 FROM %s WHERE group_id = $1 AND user_id = $2)`,
+		groupUsersTable)
+	if err := tx.QueryRow(query, groupId, memberId).Scan(&exists); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if exists {
+		tx.Rollback()
+		return 0, errors.New(memberInGroup)
+	}
+
+	var id int
+	query = fmt.Sprintf(
+		`INSERT INTO %s (group_id, user_id)
+		VALUES ($1, $2) RETURNING id`, groupUsersTable)
+	row := tx.QueryRow(query, groupId, memberId)
+	if err := row.Scan(&id); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	tx.Commit()
+	return id, nil
+}
+
+func (r *GroupPg) DeleteMember(groupId, memberId int) error {
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE group_id = $1 AND user_id = $2`, groupUsersTable)
+	_, err := r.db.Exec(query, groupId, memberId)
+	return err
+}
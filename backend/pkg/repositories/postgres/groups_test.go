@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestGroupPg_Create(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewGroupPg(db)
+
+	type args struct {
+		group *models.Group
+	}
+	type mockBehavior func(args args, id int)
+
+	tests := []struct {
+		name    string
+		mock    mockBehavior
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			input: args{
+				group: &models.Group{
+					ProjectId:   1,
+					OwnerId:     1,
+					Title:       "Developers",
+					Description: "Backend team",
+				},
+			},
+			want: 1,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				group := args.group
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectQuery("INSERT INTO groups").
+					WithArgs(group.ProjectId, group.OwnerId, group.Title, group.Description).
+					WillReturnRows(rows)
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Insert Error",
+			input: args{
+				group: &models.Group{
+					ProjectId: 1,
+					OwnerId:   1,
+					Title:     "Developers",
+				},
+			},
+			wantErr: true,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				group := args.group
+				mock.ExpectQuery("INSERT INTO groups").
+					WithArgs(group.ProjectId, group.OwnerId, group.Title, group.Description).
+					WillReturnError(errors.New("insert error"))
+
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(tt.input, tt.want)
+
+			got, err := r.Create(tt.input.group)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGroupPg_AddMember(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewGroupPg(db)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    int
+		wantErr string
+	}{
+		{
+			name: "Ok",
+			want: 3,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("member").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM project_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM group_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery("INSERT INTO group_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "Not Project Member",
+			wantErr: memberNotInProject,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("member").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM project_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name:    "Unknown User",
+			wantErr: userNotFound,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("member").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name:    "Already In Group",
+			wantErr: memberInGroup,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("member").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM project_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM group_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.AddMember(1, "member")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
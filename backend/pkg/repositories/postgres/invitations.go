@@ -0,0 +1,290 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type InvitationPg struct {
+	db *sqlx.DB
+}
+
+func NewInvitationPg(db *sqlx.DB) *InvitationPg {
+	return &InvitationPg{db: db}
+}
+
+const invitationSelect = `SELECT inv.id, inv.project_id, p.title, COALESCE(inv.board_id, 0),
+		COALESCE(b.title, ''), COALESCE(inv.group_id, 0), COALESCE(g.title, ''), inv.inviter_id,
+		COALESCE(inv.invitee_id, 0), COALESCE(u.nickname, ''), COALESCE(inv.email, ''),
+		per.read, per.write, per.admin, inv.created, inv.expires
+	FROM %s AS inv
+		INNER JOIN %s AS p ON inv.project_id = p.id
+		INNER JOIN %s AS per ON inv.permissions_id = per.id
+		LEFT JOIN %s AS u ON inv.invitee_id = u.id
+		LEFT JOIN %s AS b ON inv.board_id = b.id
+		LEFT JOIN %s AS g ON inv.group_id = g.id`
+
+func (r *InvitationPg) GetAll(projectId int, now int64) ([]*models.Invitation, error) {
+	query := fmt.Sprintf(invitationSelect+`
+		WHERE inv.project_id = $1 AND inv.expires > $2 ORDER BY inv.id`,
+		invitationsTable, projectsTable, permissionsTable, usersTable, boardsTable, groupsTable)
+	return r.selectInvitations(query, projectId, now)
+}
+
+func (r *InvitationPg) GetAllForUser(userId int, now int64) ([]*models.Invitation, error) {
+	query := fmt.Sprintf(invitationSelect+`
+		WHERE inv.invitee_id = $1 AND inv.expires > $2 ORDER BY inv.id`,
+		invitationsTable, projectsTable, permissionsTable, usersTable, boardsTable, groupsTable)
+	return r.selectInvitations(query, userId, now)
+}
+
+func (r *InvitationPg) GetById(invitationId int) (*models.Invitation, error) {
+	query := fmt.Sprintf(invitationSelect+` WHERE inv.id = $1`,
+		invitationsTable, projectsTable, permissionsTable, usersTable, boardsTable, groupsTable)
+
+	invitation, err := scanInvitation(r.db.QueryRow(query, invitationId))
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// Create invites a user found by nickname or email to the project, or to
+// a board of it when invitation.BoardId is set. Emails without an account
+// wait for the sign up with that email. Invitations with invitation.GroupId
+// also put the member into the group of the project.
+func (r *InvitationPg) Create(invitation *models.Invitation) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	objParams, objectId := invitationObject(invitation.ProjectId, invitation.BoardId)
+
+	var inviteeId, boardId, groupId sql.NullInt64
+	var email, token sql.NullString
+	if invitation.BoardId != 0 {
+		boardId.Int64, boardId.Valid = int64(invitation.BoardId), true
+	}
+	if invitation.GroupId != 0 {
+		groupId.Int64, groupId.Valid = int64(invitation.GroupId), true
+	}
+
+	memberId := 0
+	if invitation.Nickname != "" {
+		memberId, err = getUserIdByNickname(tx, invitation.Nickname)
+		if err != nil {
+			tx.Rollback()
+			str := fmt.Sprintf("User with nickname '%s' is not exists", invitation.Nickname)
+			return 0, errors.New(str)
+		}
+	} else {
+		memberId, err = getUserIdByEmail(tx, invitation.Email)
+		if err != nil && err != sql.ErrNoRows {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if memberId != 0 {
+		var exists bool
+		query := fmt.Sprintf(
+			`SELECT EXISTS (SELECT 1 FROM %s WHERE %s = $1 AND user_id = $2)`,
+			objParams.Table, objParams.IdTitle)
+		if err := tx.QueryRow(query, objectId, memberId).Scan(&exists); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		if exists {
+			tx.Rollback()
+			errText := fmt.Sprintf("Member already has permissions in the %s", objParams.Title)
+			return 0, errors.New(errText)
+		}
+		inviteeId.Int64, inviteeId.Valid = int64(memberId), true
+	} else {
+		email.String, email.Valid = invitation.Email, true
+		token.String, token.Valid = invitation.Token, invitation.Token != ""
+	}
+	invitation.InviteeId = memberId
+
+	var exists bool
+	query := fmt.Sprintf(
+		`SELECT EXISTS (SELECT 1 FROM %s
+		WHERE project_id = $1 AND board_id IS NOT DISTINCT FROM $2
+			AND (invitee_id = $3 OR lower(email) = lower($4)) AND expires > $5)`,
+		invitationsTable)
+	err = tx.QueryRow(query, invitation.ProjectId, boardId, inviteeId, email, invitation.Created).Scan(&exists)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if exists {
+		tx.Rollback()
+		return 0, errors.New("Invitation is already pending")
+	}
+
+	permissionsId, err := createPermissions(tx, invitation.Permissions)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var id int
+	query = fmt.Sprintf(
+		`INSERT INTO %s (project_id, board_id, group_id, inviter_id, invitee_id, email, token,
+			permissions_id, created, expires)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`, invitationsTable)
+	row := tx.QueryRow(query, invitation.ProjectId, boardId, groupId, invitation.InviterId, inviteeId,
+		email, token, permissionsId, invitation.Created, invitation.Expires)
+	if err := row.Scan(&id); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	tx.Commit()
+	return id, nil
+}
+
+// Accept turns the invitation into a project or board membership which
+// keeps the permissions row the invitation was created with, and adds the
+// member to the group the invitation was sent through.
+func (r *InvitationPg) Accept(invitationId int) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var projectId, inviteeId, permissionsId int
+	var boardId, groupId sql.NullInt64
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE id = $1 AND invitee_id IS NOT NULL
+		RETURNING project_id, board_id, group_id, invitee_id, permissions_id`, invitationsTable)
+	row := tx.QueryRow(query, invitationId)
+	if err := row.Scan(&projectId, &boardId, &groupId, &inviteeId, &permissionsId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	objParams, objectId := invitationObject(projectId, int(boardId.Int64))
+
+	var exists bool
+	query = fmt.Sprintf(
+		`SELECT EXISTS (SELECT 1 FROM %s WHERE %s = $1 AND user_id = $2)`,
+		objParams.Table, objParams.IdTitle)
+	if err := tx.QueryRow(query, objectId, inviteeId).Scan(&exists); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if exists {
+		tx.Rollback()
+		errText := fmt.Sprintf("Member already has permissions in the %s", objParams.Title)
+		return 0, errors.New(errText)
+	}
+
+	var id int
+	query = fmt.Sprintf(
+		`INSERT INTO %s (user_id, %s, permissions_id)
+		VALUES ($1, $2, $3) RETURNING id`, objParams.Table, objParams.IdTitle)
+	row = tx.QueryRow(query, inviteeId, objectId, permissionsId)
+	if err := row.Scan(&id); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if groupId.Valid {
+		query = fmt.Sprintf(
+			`INSERT INTO %s (group_id, user_id) VALUES ($1, $2)
+			ON CONFLICT (group_id, user_id) DO NOTHING`, groupUsersTable)
+		if _, err := tx.Exec(query, groupId.Int64, inviteeId); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	tx.Commit()
+	return id, nil
+}
+
+func (r *InvitationPg) Delete(invitationId int) error {
+	query := fmt.Sprintf(
+		`DELETE FROM %s AS per USING %s AS inv
+		WHERE per.id = inv.permissions_id AND inv.id = $1`,
+		permissionsTable, invitationsTable)
+	_, err := r.db.Exec(query, invitationId)
+	return err
+}
+
+func (r *InvitationPg) selectInvitations(query string, args ...interface{}) ([]*models.Invitation, error) {
+	invitations := make([]*models.Invitation, 0)
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanInvitation(row rowScanner) (*models.Invitation, error) {
+	invitation := &models.Invitation{Permissions: &models.Permission{}}
+	err := row.Scan(&invitation.Id, &invitation.ProjectId, &invitation.ProjectTitle,
+		&invitation.BoardId, &invitation.BoardTitle, &invitation.GroupId, &invitation.GroupTitle, &invitation.InviterId, &invitation.InviteeId, &invitation.Nickname, &invitation.Email,
+		&invitation.Permissions.Read, &invitation.Permissions.Write, &invitation.Permissions.Admin,
+		&invitation.Created, &invitation.Expires)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// Email invitations of not yet registered people are bound to the account
+// signing up with the token of the invitation, so they show up among the
+// user's invitations. Emails are not verified, so the email alone is not
+// enough: anyone could sign up with the address of somebody else.
+func redeemInvitations(tx *sql.Tx, userId int, email, token string) error {
+	if token == "" {
+		return nil
+	}
+	query := fmt.Sprintf(
+		`UPDATE %s SET invitee_id = $1, email = NULL, token = NULL
+		WHERE token = $3 AND lower(email) = lower($2) AND invitee_id IS NULL`, invitationsTable)
+	_, err := tx.Exec(query, userId, email, token)
+	return err
+}
+
+func invitationObject(projectId, boardId int) (*ObjectParams, int) {
+	if boardId != 0 {
+		objParams, _ := getObjectParams(IsBoard)
+		return objParams, boardId
+	}
+	objParams, _ := getObjectParams(IsProject)
+	return objParams, projectId
+}
+
+func getUserIdByEmail(tx *sql.Tx, email string) (int, error) {
+	var userId int
+	query := fmt.Sprintf(
+		`SELECT id FROM %s WHERE lower(email) = lower($1) ORDER BY id LIMIT 1`, usersTable)
+	err := tx.QueryRow(query, email).Scan(&userId)
+	return userId, err
+}
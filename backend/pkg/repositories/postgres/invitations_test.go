@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestInvitationPg_Create(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewInvitationPg(db)
+
+	type args struct {
+		invitation *models.Invitation
+	}
+	type mockBehavior func(args args, id int)
+
+	tests := []struct {
+		name    string
+		mock    mockBehavior
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok by email",
+			input: args{
+				invitation: &models.Invitation{
+					ProjectId:   1,
+					InviterId:   1,
+					Email:       "new@test.com",
+					Token:       "token",
+					Permissions: &models.Permission{Read: true},
+					Created:     100,
+					Expires:     200,
+				},
+			},
+			want: 1,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("new@test.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT EXISTS").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery("INSERT INTO permissions").
+					WithArgs(true, false, false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("INSERT INTO invitations").
+					WithArgs(1, nil, nil, 1, nil, "new@test.com", "token", 2, 100, 200).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Ok by email of a user",
+			input: args{
+				invitation: &models.Invitation{
+					ProjectId:   1,
+					InviterId:   1,
+					Email:       "member@test.com",
+					Permissions: &models.Permission{Read: true},
+					Created:     100,
+					Expires:     200,
+				},
+			},
+			want: 3,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("member@test.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("SELECT EXISTS").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery("SELECT EXISTS").
+					WithArgs(1, nil, 2, nil, 100).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery("INSERT INTO permissions").
+					WithArgs(true, false, false).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("INSERT INTO invitations").
+					WithArgs(1, nil, nil, 1, 2, nil, nil, 2, 100, 200).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Already on the board",
+			input: args{
+				invitation: &models.Invitation{
+					ProjectId:   1,
+					BoardId:     4,
+					InviterId:   1,
+					Nickname:    "member",
+					Permissions: &models.Permission{Read: true},
+					Created:     100,
+					Expires:     200,
+				},
+			},
+			wantErr: true,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("member").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM board_users").WithArgs(4, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "Already pending",
+			input: args{
+				invitation: &models.Invitation{
+					ProjectId:   1,
+					InviterId:   1,
+					Email:       "new@test.com",
+					Permissions: &models.Permission{Read: true},
+					Created:     100,
+					Expires:     200,
+				},
+			},
+			wantErr: true,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("new@test.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT EXISTS").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "Already member",
+			input: args{
+				invitation: &models.Invitation{
+					ProjectId:   1,
+					InviterId:   1,
+					Nickname:    "member",
+					Permissions: &models.Permission{Read: true},
+					Created:     100,
+					Expires:     200,
+				},
+			},
+			wantErr: true,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT id FROM users").WithArgs("member").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("SELECT EXISTS").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(tt.input, tt.want)
+
+			got, err := r.Create(tt.input.invitation)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInvitationPg_Accept(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewInvitationPg(db)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			want: 5,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("DELETE FROM invitations").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "board_id", "group_id", "invitee_id", "permissions_id"}).
+						AddRow(1, nil, nil, 2, 3))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM project_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery("INSERT INTO project_users").WithArgs(2, 1, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Ok through group",
+			want: 5,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("DELETE FROM invitations").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "board_id", "group_id", "invitee_id", "permissions_id"}).
+						AddRow(1, nil, 4, 2, 3))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM project_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery("INSERT INTO project_users").WithArgs(2, 1, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec("INSERT INTO group_users").WithArgs(4, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Already a member",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("DELETE FROM invitations").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "board_id", "group_id", "invitee_id", "permissions_id"}).
+						AddRow(1, nil, 4, 2, 3))
+				mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM project_users").WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.Accept(1)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 
@@ -22,10 +23,10 @@ func NewTaskListPg(db *sqlx.DB) *TaskListPg {
 func (r *TaskListPg) GetAll(boardId int) ([]*models.TaskList, error) {
 	var lists []*models.TaskList
 	query := fmt.Sprintf(
-		`SELECT tl.id, tl.board_id, tl.title, tl.position
+		`SELECT tl.id, tl.board_id, tl.title, tl.position, tl.archived, tl.done
 		FROM %s AS tl
 			INNER JOIN %s AS b ON tl.board_id = b.id
-		WHERE b.id = $1
+		WHERE b.id = $1 AND tl.deleted_at IS NULL
 		ORDER BY tl.position`,
 		taskListsTable, boardsTable)
 	if err := r.db.Select(&lists, query, boardId); err != nil {
@@ -38,7 +39,8 @@ func (r *TaskListPg) GetAll(boardId int) ([]*models.TaskList, error) {
 func (r *TaskListPg) GetById(listId int) (*models.TaskList, error) {
 	list := &models.TaskList{}
 	query := fmt.Sprintf(
-		`SELECT * FROM %s WHERE id = $1`, taskListsTable)
+		`SELECT id, board_id, title, position, archived, done FROM %s
+		WHERE id = $1 AND deleted_at IS NULL`, taskListsTable)
 	err := r.db.Get(list, query, listId)
 
 	return list, err
@@ -57,7 +59,7 @@ func (r *TaskListPg) Create(list *models.TaskList) (int, error) {
 		`SELECT MAX(tl.position)
 		FROM %s AS tl
 		INNER JOIN %s AS b ON b.id = tl.board_id
-		WHERE b.id = $1;`, taskListsTable, boardsTable)
+		WHERE b.id = $1 AND tl.deleted_at IS NULL;`, taskListsTable, boardsTable)
 
 	row := tx.QueryRow(query, list.BoardId)
 	if err := row.Scan(&position); err != nil {
@@ -87,9 +89,12 @@ func (r *TaskListPg) Delete(listId int) error {
 		return err
 	}
 
+	// The list keeps its position so that a restore can put it back.
 	var boardId, position int
-	query := fmt.Sprintf(`SELECT board_id, position FROM %s WHERE id = $1`, taskListsTable)
-	row := tx.QueryRow(query, listId)
+	query := fmt.Sprintf(
+		`UPDATE %s SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL
+		RETURNING board_id, position`, taskListsTable)
+	row := tx.QueryRow(query, listId, time.Now().Unix())
 	err = row.Scan(&boardId, &position)
 	if err != nil {
 		tx.Rollback()
@@ -98,7 +103,7 @@ func (r *TaskListPg) Delete(listId int) error {
 
 	query = fmt.Sprintf(
 		`UPDATE %s SET position = position - 1
-	WHERE board_id = $1 AND position > $2`,
+	WHERE board_id = $1 AND position > $2 AND deleted_at IS NULL`,
 		taskListsTable)
 	_, err = tx.Exec(query, boardId, position)
 	if err != nil {
@@ -106,8 +111,7 @@ func (r *TaskListPg) Delete(listId int) error {
 		return err
 	}
 
-	query = fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, taskListsTable)
-	_, err = tx.Exec(query, listId)
+	err = recordTaskHistory(tx, time.Now().Unix(), "t.list_id = $2", listId)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -117,6 +121,12 @@ func (r *TaskListPg) Delete(listId int) error {
 	return err
 }
 
+func (r *TaskListPg) SetArchived(listId int, archived bool) error {
+	query := fmt.Sprintf(`UPDATE %s SET archived = $2 WHERE id = $1`, taskListsTable)
+	_, err := r.db.Exec(query, listId, archived)
+	return err
+}
+
 func (r *TaskListPg) Update(listId int, input *models.UpdateTaskList) error {
 	setValues := make([]string, 0)
 	args := make([]interface{}, 0)
@@ -133,6 +143,18 @@ func (r *TaskListPg) Update(listId int, input *models.UpdateTaskList) error {
 		argId++
 	}
 
+	if input.Done != nil {
+		if *input.Done {
+			if err := checkBlockedInList(tx, listId); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		setValues = append(setValues, fmt.Sprintf("done=$%d", argId))
+		args = append(args, *input.Done)
+		argId++
+	}
+
 	if input.Position != nil {
 		newPos := *input.Position
 
@@ -168,7 +190,7 @@ func (r *TaskListPg) Update(listId int, input *models.UpdateTaskList) error {
 
 			query = fmt.Sprintf(
 				`UPDATE %s SET position = position %s 1
-			WHERE board_id = $1 AND position >= $2 AND position <= $3`,
+			WHERE board_id = $1 AND position >= $2 AND position <= $3 AND deleted_at IS NULL`,
 				taskListsTable, operation)
 			_, err = tx.Exec(query, boardId, start, end)
 			if err != nil {
@@ -188,6 +210,15 @@ func (r *TaskListPg) Update(listId int, input *models.UpdateTaskList) error {
 		return err
 	}
 
+	if input.Done != nil {
+		// The tasks of the list are done or undone at once.
+		err = recordTaskHistory(tx, time.Now().Unix(), "t.list_id = $2", listId)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	tx.Commit()
 	return err
 }
@@ -198,7 +229,7 @@ func getListMaxPosition(tx *sql.Tx, boardId int) (int, error) {
 		`SELECT MAX(tl.position)
 		FROM %s AS tl
 			INNER JOIN %s AS b ON b.id = tl.board_id
-		WHERE b.id = $1;`, taskListsTable, boardsTable)
+		WHERE b.id = $1 AND tl.deleted_at IS NULL;`, taskListsTable, boardsTable)
 
 	row := tx.QueryRow(query, boardId)
 	err := row.Scan(&position)
@@ -121,3 +121,48 @@ func TestTaskListPg_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskListPg_Update_Done(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTaskListPg(db)
+	done := true
+
+	tests := []struct {
+		name    string
+		blocked bool
+		wantErr bool
+	}{
+		{name: "Ok"},
+		{name: "Blocked task", blocked: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM tasks AS t").WithArgs(3).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.blocked))
+			if tt.blocked {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec("UPDATE task_lists SET done=\\$1").WithArgs(true, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO task_history").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			}
+
+			err := r.Update(3, &models.UpdateTaskList{Done: &done})
+			if tt.wantErr {
+				assert.EqualError(t, err, taskBlocked)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
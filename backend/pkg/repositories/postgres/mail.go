@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type MailPg struct {
+	db *sqlx.DB
+}
+
+func NewMailPg(db *sqlx.DB) *MailPg {
+	return &MailPg{db: db}
+}
+
+// recipientsQuery selects the users with their mail settings, the defaults
+// when they have none.
+var recipientsQuery = fmt.Sprintf(
+	`SELECT u.id, u.nickname, u.email, COALESCE(ms.notifications, true),
+		COALESCE(ms.digest, false), COALESCE(ms.digest_sent, 0)
+	FROM %s AS u LEFT JOIN %s AS ms ON ms.user_id = u.id`,
+	usersTable, mailSettingsTable)
+
+func (r *MailPg) GetRecipient(userId int) (*models.MailRecipient, error) {
+	recipient := &models.MailRecipient{}
+	row := r.db.QueryRow(recipientsQuery+" WHERE u.id = $1", userId)
+	err := row.Scan(&recipient.Id, &recipient.Nickname, &recipient.Email,
+		&recipient.Settings.Notifications, &recipient.Settings.Digest, &recipient.DigestSent)
+	if err != nil {
+		return nil, err
+	}
+	return recipient, nil
+}
+
+// GetDigestRecipients returns the users who get the digest and were last
+// sent one at or before the time.
+func (r *MailPg) GetDigestRecipients(before int64) ([]*models.MailRecipient, error) {
+	rows, err := r.db.Query(recipientsQuery+
+		" WHERE ms.digest AND ms.digest_sent <= $1 ORDER BY u.id", before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipients := make([]*models.MailRecipient, 0)
+	for rows.Next() {
+		recipient := &models.MailRecipient{}
+		err := rows.Scan(&recipient.Id, &recipient.Nickname, &recipient.Email,
+			&recipient.Settings.Notifications, &recipient.Settings.Digest, &recipient.DigestSent)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, rows.Err()
+}
+
+func (r *MailPg) UpdateSettings(userId int, input *models.UpdateMailSettings) error {
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (user_id, notifications, digest)
+		VALUES ($1, COALESCE($2, true), COALESCE($3, false))
+		ON CONFLICT (user_id) DO UPDATE SET
+			notifications = COALESCE($2, %[1]s.notifications),
+			digest = COALESCE($3, %[1]s.digest)`,
+		mailSettingsTable)
+	_, err := r.db.Exec(query, userId, input.Notifications, input.Digest)
+	return err
+}
+
+func (r *MailPg) SetDigestSent(userId int, sent int64) error {
+	query := fmt.Sprintf(`UPDATE %s SET digest_sent = $1 WHERE user_id = $2`, mailSettingsTable)
+	_, err := r.db.Exec(query, sent, userId)
+	return err
+}
+
+// GetDigest returns the notifications of the user created since the time,
+// oldest first.
+func (r *MailPg) GetDigest(userId int, since int64) ([]*models.Notification, error) {
+	notifications := make([]*models.Notification, 0)
+	query := notificationsQuery("n.user_id = $1 AND n.created >= $2") + " ORDER BY n.id"
+	if err := r.db.Select(&notifications, query, userId, since); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// GetEventTitle returns the title of the most specific object of the event.
+func (r *MailPg) GetEventTitle(event *models.Event) (string, error) {
+	var title string
+	query := fmt.Sprintf(
+		`SELECT COALESCE(
+			(SELECT title FROM %s WHERE id = $1),
+			(SELECT title FROM %s WHERE id = $2),
+			(SELECT title FROM %s WHERE id = $3),
+			(SELECT title FROM %s WHERE id = $4))`,
+		tasksTable, taskListsTable, boardsTable, projectsTable)
+	err := r.db.Get(&title, query, event.TaskId, event.ListId, event.BoardId, event.ProjectId)
+	return title, err
+}
+
+// GetReminders returns the live tasks with an assignee due at or before the
+// time that were not reminded of yet, leaving out the tasks in done lists and
+// the archived ones.
+func (r *MailPg) GetReminders(before int64) ([]*models.Reminder, error) {
+	query := fmt.Sprintf(
+		`SELECT t.id, b.project_id, b.id, t.assignee_id, t.title, t.due
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			INNER JOIN %s AS b ON tl.board_id = b.id
+		WHERE t.due <= $1 AND NOT t.due_reminded AND t.assignee_id IS NOT NULL
+			AND t.deleted_at IS NULL AND tl.deleted_at IS NULL AND b.deleted_at IS NULL
+			AND NOT t.archived AND NOT tl.archived AND NOT b.archived AND NOT tl.done
+		ORDER BY t.due, t.id`,
+		tasksTable, taskListsTable, boardsTable)
+	rows, err := r.db.Query(query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := make([]*models.Reminder, 0)
+	for rows.Next() {
+		reminder := &models.Reminder{}
+		err := rows.Scan(&reminder.TaskId, &reminder.ProjectId, &reminder.BoardId,
+			&reminder.UserId, &reminder.Title, &reminder.Due)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, rows.Err()
+}
+
+func (r *MailPg) SetReminded(taskId int) error {
+	query := fmt.Sprintf(`UPDATE %s SET due_reminded = true WHERE id = $1`, tasksTable)
+	_, err := r.db.Exec(query, taskId)
+	return err
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type MentionPg struct {
+	db *sqlx.DB
+}
+
+func NewMentionPg(db *sqlx.DB) *MentionPg {
+	return &MentionPg{db: db}
+}
+
+func (r *MentionPg) GetAll(taskId int) ([]*models.Mention, error) {
+	mentions := make([]*models.Mention, 0)
+	query := fmt.Sprintf(
+		`SELECT m.user_id, u.nickname
+		FROM %s AS m INNER JOIN %s AS u ON m.user_id = u.id
+		WHERE m.task_id = $1 ORDER BY m.id`,
+		mentionsTable, usersTable)
+	if err := r.db.Select(&mentions, query, taskId); err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
+// Set replaces the mentions of the task and returns the users who were not
+// mentioned before.
+func (r *MentionPg) Set(taskId, actorId int, userIds []int, created int64) ([]int, error) {
+	ids := make(pq.Int64Array, len(userIds))
+	for i, id := range userIds {
+		ids[i] = int64(id)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE task_id = $1 AND user_id <> ALL($2::int[])`, mentionsTable)
+	if _, err := tx.Exec(query, taskId, ids); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	query = fmt.Sprintf(
+		`INSERT INTO %s (task_id, user_id, actor_id, created)
+		SELECT $1, unnest($2::int[]), NULLIF($3, 0), $4
+		ON CONFLICT (task_id, user_id) DO NOTHING
+		RETURNING user_id`, mentionsTable)
+	rows, err := tx.Query(query, taskId, ids, actorId, created)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	added := make([]int, 0)
+	for rows.Next() {
+		var userId int
+		if err := rows.Scan(&userId); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return nil, err
+		}
+		added = append(added, userId)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return added, tx.Commit()
+}
@@ -0,0 +1,350 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	taskOutOfBounds = "Task position out of bounds"
+	listOutOfBounds = "List position out of bounds"
+)
+
+// Move puts the task to the position in the list, which may be on another
+// board, and to the end of the list without a position. Labels that are not
+// on the board of the list are replaced with the labels of the same name, the
+// names of the ones without a match are returned. The users in dropUsers are
+// cleared from the assignee and the custom fields, and relations with tasks of
+// other projects are removed.
+func (r *TaskPg) Move(taskId, listId int, position *int, dropUsers []int) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var fromListId, fromPos int
+	query := fmt.Sprintf(
+		`SELECT list_id, position FROM %s WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`, tasksTable)
+	if err := tx.QueryRow(query, taskId).Scan(&fromListId, &fromPos); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var boardId int
+	query = fmt.Sprintf(
+		`SELECT board_id FROM %s WHERE id = $1 AND deleted_at IS NULL`, taskListsTable)
+	if err := tx.QueryRow(query, listId).Scan(&boardId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := r.moveTask(tx, taskId, fromListId, fromPos, listId, position); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	dropped, err := remapTaskLabels(tx, []int64{int64(taskId)}, boardId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := leaveOtherBoardSprints(tx, []int64{int64(taskId)}, boardId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := remapTaskFields(tx, []int64{int64(taskId)}, boardId, dropUsers); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := detachMovedTasks(tx, []int64{int64(taskId)}, dropUsers); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	tx.Commit()
+	return dropped, nil
+}
+
+// moveTask takes the task out of its list before making room for it, so the
+// positions of the target list are counted without the task either way.
+func (r *TaskPg) moveTask(tx *sql.Tx, taskId, fromListId, fromPos, listId int, position *int) error {
+	if err := checkDoneList(tx, taskId, listId); err != nil {
+		return err
+	}
+
+	var count int
+	query := fmt.Sprintf(
+		`SELECT COUNT(*) FROM %s WHERE list_id = $1 AND id <> $2 AND deleted_at IS NULL`,
+		tasksTable)
+	if err := tx.QueryRow(query, listId, taskId).Scan(&count); err != nil {
+		return err
+	}
+	newPos := count
+	if position != nil {
+		if *position > count {
+			return bulkError(taskOutOfBounds)
+		}
+		newPos = *position
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET position = -1 WHERE id = $1`, tasksTable)
+	if _, err := tx.Exec(query, taskId); err != nil {
+		return err
+	}
+	if err := r.updateTaskPosition(tx, fromListId, fromPos+1, "-"); err != nil {
+		return err
+	}
+	if err := r.updateTaskPosition(tx, listId, newPos, "+"); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET list_id = $2, position = $3 WHERE id = $1`, tasksTable)
+	_, err := tx.Exec(query, taskId, listId, newPos)
+	return err
+}
+
+// Move puts the list with its tasks to the position on the board, which may
+// be in another project, and to the end of the board without a position.
+// Labels and custom fields are handled like for a moved task. The saved views
+// of the old board forget the list.
+func (r *TaskListPg) Move(listId, boardId int, position *int, dropUsers []int) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var fromBoardId, fromPos int
+	query := fmt.Sprintf(
+		`SELECT board_id, position FROM %s WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`, taskListsTable)
+	if err := tx.QueryRow(query, listId).Scan(&fromBoardId, &fromPos); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var count int
+	query = fmt.Sprintf(
+		`SELECT COUNT(*) FROM %s WHERE board_id = $1 AND id <> $2 AND deleted_at IS NULL`,
+		taskListsTable)
+	if err := tx.QueryRow(query, boardId, listId).Scan(&count); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	newPos := count
+	if position != nil {
+		if *position > count {
+			tx.Rollback()
+			return nil, errors.New(listOutOfBounds)
+		}
+		newPos = *position
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET position = -1 WHERE id = $1`, taskListsTable)
+	if _, err := tx.Exec(query, listId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := updateListPosition(tx, fromBoardId, fromPos+1, "-"); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := updateListPosition(tx, boardId, newPos, "+"); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET board_id = $2, position = $3 WHERE id = $1`, taskListsTable)
+	if _, err := tx.Exec(query, listId, boardId, newPos); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if fromBoardId == boardId {
+		tx.Commit()
+		return []string{}, nil
+	}
+
+	query = fmt.Sprintf(
+		`UPDATE %s SET list_ids = array_remove(list_ids, $1) WHERE board_id = $2`, viewsTable)
+	if _, err := tx.Exec(query, listId, fromBoardId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var taskIds pq.Int64Array
+	query = fmt.Sprintf(`SELECT COALESCE(array_agg(id), '{}') FROM %s WHERE list_id = $1`, tasksTable)
+	if err := tx.QueryRow(query, listId).Scan(&taskIds); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	dropped, err := remapTaskLabels(tx, taskIds, boardId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := leaveOtherBoardSprints(tx, taskIds, boardId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := remapTaskFields(tx, taskIds, boardId, dropUsers); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := detachMovedTasks(tx, taskIds, dropUsers); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	tx.Commit()
+	return dropped, nil
+}
+
+// GetUsers returns the users the task refers to: its assignee and the users
+// of its custom fields.
+func (r *TaskPg) GetUsers(taskId int) ([]int, error) {
+	return selectTaskUsers(r.db, "t.id = $1", taskId)
+}
+
+// GetUsers returns the users the live tasks of the list refer to.
+func (r *TaskListPg) GetUsers(listId int) ([]int, error) {
+	return selectTaskUsers(r.db, "t.list_id = $1 AND t.deleted_at IS NULL", listId)
+}
+
+func selectTaskUsers(q sqlx.Queryer, condition string, args ...interface{}) ([]int, error) {
+	users := make([]int, 0)
+	query := fmt.Sprintf(
+		`SELECT t.assignee_id FROM %[1]s AS t WHERE %[3]s AND t.assignee_id IS NOT NULL
+		UNION
+		SELECT v.user_id FROM %[2]s AS v INNER JOIN %[1]s AS t ON v.task_id = t.id
+		WHERE %[3]s AND v.user_id IS NOT NULL
+		ORDER BY 1`,
+		tasksTable, fieldValuesTable, condition)
+	if err := sqlx.Select(q, &users, query, args...); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// detachMovedTasks clears the assignee of the moved tasks if it is in dropUsers
+// and removes the relations of the tasks with tasks of other projects, which
+// can't be related.
+func detachMovedTasks(tx *sql.Tx, taskIds []int64, dropUsers []int) error {
+	users := make(pq.Int64Array, len(dropUsers))
+	for i, id := range dropUsers {
+		users[i] = int64(id)
+	}
+
+	query := fmt.Sprintf(
+		`UPDATE %s SET assignee_id = NULL WHERE id = ANY($1) AND assignee_id = ANY($2)`, tasksTable)
+	if _, err := tx.Exec(query, pq.Int64Array(taskIds), users); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`DELETE FROM %[1]s AS rel
+		USING %[2]s AS t, %[3]s AS tl, %[4]s AS b, %[2]s AS ot, %[3]s AS otl, %[4]s AS ob
+		WHERE rel.task_id = t.id AND rel.related_id = ot.id
+			AND (t.id = ANY($1) OR ot.id = ANY($1))
+			AND t.list_id = tl.id AND tl.board_id = b.id
+			AND ot.list_id = otl.id AND otl.board_id = ob.id
+			AND b.project_id <> ob.project_id`,
+		relationsTable, tasksTable, taskListsTable, boardsTable)
+	_, err := tx.Exec(query, pq.Int64Array(taskIds))
+	return err
+}
+
+func updateListPosition(tx *sql.Tx, boardId, start int, operation string) error {
+	query := fmt.Sprintf(
+		`UPDATE %s SET position = position %s 1
+		WHERE board_id = $1 AND position >= $2 AND deleted_at IS NULL`,
+		taskListsTable, operation)
+	_, err := tx.Exec(query, boardId, start)
+	return err
+}
+
+// remapTaskLabels replaces the labels of the tasks that are not on the board
+// with the first label of the same name on it and removes the rest,
+// returning their names.
+func remapTaskLabels(tx *sql.Tx, taskIds []int64, boardId int) ([]string, error) {
+	query := fmt.Sprintf(
+		`UPDATE %s AS tlb SET label_id = (
+			SELECT MIN(target.id) FROM %s AS target
+			WHERE target.board_id = $2 AND target.name = src.name)
+		FROM %s AS src
+		WHERE tlb.label_id = src.id AND src.board_id <> $2 AND tlb.task_id = ANY($1)
+			AND EXISTS (SELECT 1 FROM %s AS target
+				WHERE target.board_id = $2 AND target.name = src.name)`,
+		taskLabelsTable, labelsTable, labelsTable, labelsTable)
+	if _, err := tx.Exec(query, pq.Int64Array(taskIds), boardId); err != nil {
+		return nil, err
+	}
+
+	// Two labels of the old board with the same name end up as one.
+	query = fmt.Sprintf(
+		`DELETE FROM %s AS a USING %s AS b
+		WHERE a.task_id = b.task_id AND a.label_id = b.label_id AND a.id > b.id
+			AND a.task_id = ANY($1)`,
+		taskLabelsTable, taskLabelsTable)
+	if _, err := tx.Exec(query, pq.Int64Array(taskIds)); err != nil {
+		return nil, err
+	}
+
+	query = fmt.Sprintf(
+		`DELETE FROM %s AS tlb USING %s AS src
+		WHERE tlb.label_id = src.id AND src.board_id <> $2 AND tlb.task_id = ANY($1)
+		RETURNING src.name`,
+		taskLabelsTable, labelsTable)
+	rows, err := tx.Query(query, pq.Array(taskIds), boardId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	dropped := make([]string, 0, len(names))
+	for name := range names {
+		dropped = append(dropped, name)
+	}
+	sort.Strings(dropped)
+	return dropped, nil
+}
+
+// leaveOtherBoardSprints puts the moved tasks that are in a sprint of another
+// board to the backlog, and records the moves for the burndown of both.
+func leaveOtherBoardSprints(tx *sql.Tx, taskIds []int64, boardId int) error {
+	query := fmt.Sprintf(
+		`UPDATE %s AS t SET sprint_id = NULL
+		FROM %s AS s
+		WHERE t.sprint_id = s.id AND t.id = ANY($1) AND s.board_id <> $2`,
+		tasksTable, sprintsTable)
+	if _, err := tx.Exec(query, pq.Int64Array(taskIds), boardId); err != nil {
+		return err
+	}
+	return recordTaskHistory(tx, time.Now().Unix(), "t.id = ANY($2)", pq.Int64Array(taskIds))
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type NotificationPg struct {
+	db *sqlx.DB
+}
+
+func NewNotificationPg(db *sqlx.DB) *NotificationPg {
+	return &NotificationPg{db: db}
+}
+
+func (r *NotificationPg) Watch(userId int, watch *models.Watch) error {
+	query := fmt.Sprintf(
+		`INSERT INTO %s (user_id, object_type, object_id) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, object_type, object_id) DO NOTHING`, watchersTable)
+	_, err := r.db.Exec(query, userId, watch.ObjectType, watch.ObjectId)
+	return err
+}
+
+func (r *NotificationPg) Unwatch(userId int, watch *models.Watch) error {
+	var id int
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE user_id = $1 AND object_type = $2 AND object_id = $3 RETURNING id`,
+		watchersTable)
+	return r.db.QueryRow(query, userId, watch.ObjectType, watch.ObjectId).Scan(&id)
+}
+
+func (r *NotificationPg) GetWatches(userId int) ([]*models.Watch, error) {
+	watches := make([]*models.Watch, 0)
+	query := fmt.Sprintf(
+		`SELECT object_type, object_id FROM %s WHERE user_id = $1 ORDER BY id`, watchersTable)
+	if err := r.db.Select(&watches, query, userId); err != nil {
+		return nil, err
+	}
+	return watches, nil
+}
+
+// GetWatchBoard returns the board and the project of a watchable object,
+// the board id is 0 for a project.
+func (r *NotificationPg) GetWatchBoard(watch *models.Watch) (*models.Board, error) {
+	var query string
+	switch watch.ObjectType {
+	case models.WatchProject:
+		query = fmt.Sprintf(
+			`SELECT 0, id FROM %s WHERE id = $1 AND deleted_at IS NULL`, projectsTable)
+	case models.WatchBoard:
+		query = fmt.Sprintf(
+			`SELECT id, project_id FROM %s WHERE id = $1 AND deleted_at IS NULL`, boardsTable)
+	case models.WatchList:
+		query = fmt.Sprintf(
+			`SELECT b.id, b.project_id
+			FROM %s AS tl INNER JOIN %s AS b ON tl.board_id = b.id
+			WHERE tl.id = $1 AND tl.deleted_at IS NULL`, taskListsTable, boardsTable)
+	case models.WatchTask:
+		query = fmt.Sprintf(
+			`SELECT b.id, b.project_id
+			FROM %s AS t
+				INNER JOIN %s AS tl ON t.list_id = tl.id
+				INNER JOIN %s AS b ON tl.board_id = b.id
+			WHERE t.id = $1 AND t.deleted_at IS NULL`, tasksTable, taskListsTable, boardsTable)
+	default:
+		return nil, fmt.Errorf("unknown object type %q", watch.ObjectType)
+	}
+
+	board := &models.Board{}
+	if err := r.db.QueryRow(query, watch.ObjectId).Scan(&board.Id, &board.ProjectId); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// GetRecipients returns the watchers of the objects of the event, except
+// the actor and those who turned the event off.
+func (r *NotificationPg) GetRecipients(event *models.Event) ([]int, error) {
+	recipients := make([]int, 0)
+	query := fmt.Sprintf(
+		`SELECT DISTINCT w.user_id
+		FROM %s AS w
+		WHERE ((w.object_type = $1 AND w.object_id = $2)
+				OR (w.object_type = $3 AND w.object_id = $4)
+				OR (w.object_type = $5 AND w.object_id = $6)
+				OR (w.object_type = $7 AND w.object_id = $8))
+			AND w.user_id <> $9
+			AND NOT EXISTS (
+				SELECT 1 FROM %s AS np
+				WHERE np.user_id = w.user_id AND np.event = $10 AND NOT np.enabled)
+		ORDER BY w.user_id`,
+		watchersTable, preferencesTable)
+	err := r.db.Select(&recipients, query,
+		models.WatchProject, event.ProjectId, models.WatchBoard, event.BoardId,
+		models.WatchList, event.ListId, models.WatchTask, event.TaskId,
+		event.ActorId, event.Type)
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// IsEnabled tells whether the user wants to be notified about the event.
+func (r *NotificationPg) IsEnabled(userId int, event string) (bool, error) {
+	var enabled bool
+	query := fmt.Sprintf(
+		`SELECT NOT EXISTS (
+			SELECT 1 FROM %s WHERE user_id = $1 AND event = $2 AND NOT enabled)`, preferencesTable)
+	err := r.db.Get(&enabled, query, userId, event)
+	return enabled, err
+}
+
+func (r *NotificationPg) Create(userIds []int, event *models.Event, created int64) error {
+	ids := make(pq.Int64Array, len(userIds))
+	for i, id := range userIds {
+		ids[i] = int64(id)
+	}
+	query := fmt.Sprintf(
+		`INSERT INTO %s (user_id, actor_id, event, project_id, board_id, list_id, task_id, created)
+		SELECT unnest($1::int[]), NULLIF($2, 0), $3, $4, NULLIF($5, 0), NULLIF($6, 0), NULLIF($7, 0), $8`,
+		notificationsTable)
+	_, err := r.db.Exec(query, ids, event.ActorId, event.Type, event.ProjectId,
+		event.BoardId, event.ListId, event.TaskId, created)
+	return err
+}
+
+// notificationsQuery selects the notifications matching the conditions,
+// titled after the most specific object they are about.
+func notificationsQuery(conditions string) string {
+	return fmt.Sprintf(
+		`SELECT n.id, n.event, COALESCE(n.actor_id, 0) AS actor_id, n.project_id,
+			COALESCE(n.board_id, 0) AS board_id, COALESCE(n.list_id, 0) AS list_id,
+			COALESCE(n.task_id, 0) AS task_id,
+			COALESCE(t.title, tl.title, b.title, p.title) AS title, n.created, n.read
+		FROM %s AS n
+			INNER JOIN %s AS p ON n.project_id = p.id
+			LEFT JOIN %s AS b ON n.board_id = b.id
+			LEFT JOIN %s AS tl ON n.list_id = tl.id
+			LEFT JOIN %s AS t ON n.task_id = t.id
+		WHERE %s`,
+		notificationsTable, projectsTable, boardsTable, taskListsTable, tasksTable, conditions)
+}
+
+// GetAll returns the newest notifications first.
+func (r *NotificationPg) GetAll(userId int, unread bool, limit, offset int) ([]*models.Notification, error) {
+	notifications := make([]*models.Notification, 0)
+	conditions := "n.user_id = $1"
+	if unread {
+		conditions += " AND NOT n.read"
+	}
+	query := notificationsQuery(conditions) + " ORDER BY n.id DESC LIMIT $2 OFFSET $3"
+	if err := r.db.Select(&notifications, query, userId, limit, offset); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *NotificationPg) CountUnread(userId int) (int, error) {
+	var count int
+	query := fmt.Sprintf(`SELECT count(*) FROM %s WHERE user_id = $1 AND NOT read`,
+		notificationsTable)
+	err := r.db.Get(&count, query, userId)
+	return count, err
+}
+
+func (r *NotificationPg) MarkRead(userId, notificationId int) error {
+	var id int
+	query := fmt.Sprintf(
+		`UPDATE %s SET read = true WHERE id = $1 AND user_id = $2 RETURNING id`, notificationsTable)
+	return r.db.QueryRow(query, notificationId, userId).Scan(&id)
+}
+
+func (r *NotificationPg) MarkAllRead(userId int) error {
+	query := fmt.Sprintf(`UPDATE %s SET read = true WHERE user_id = $1 AND NOT read`,
+		notificationsTable)
+	_, err := r.db.Exec(query, userId)
+	return err
+}
+
+// GetPreferences returns only the events the user has set.
+func (r *NotificationPg) GetPreferences(userId int) (map[string]bool, error) {
+	query := fmt.Sprintf(`SELECT event, enabled FROM %s WHERE user_id = $1`, preferencesTable)
+	rows, err := r.db.Query(query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	preferences := make(map[string]bool)
+	for rows.Next() {
+		var event string
+		var enabled bool
+		if err := rows.Scan(&event, &enabled); err != nil {
+			return nil, err
+		}
+		preferences[event] = enabled
+	}
+	return preferences, rows.Err()
+}
+
+func (r *NotificationPg) UpdatePreferences(userId int, preferences map[string]bool) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %s (user_id, event, enabled) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, event) DO UPDATE SET enabled = EXCLUDED.enabled`, preferencesTable)
+	for event, enabled := range preferences {
+		if _, err := tx.Exec(query, userId, event, enabled); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 const (
@@ -69,6 +70,27 @@ func (r *ObjectPermsPg) GetByNickname(objectId, objectType int, memberNickname s
 	return permissions, err
 }
 
+// GetUserIds looks the nicknames up, the unknown ones are left out.
+func (r *ObjectPermsPg) GetUserIds(nicknames []string) (map[string]int, error) {
+	query := fmt.Sprintf(`SELECT id, nickname FROM %s WHERE nickname = ANY($1)`, usersTable)
+	rows, err := r.db.Query(query, pq.StringArray(nicknames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIds := make(map[string]int, len(nicknames))
+	for rows.Next() {
+		var userId int
+		var nickname string
+		if err := rows.Scan(&userId, &nickname); err != nil {
+			return nil, err
+		}
+		userIds[nickname] = userId
+	}
+	return userIds, rows.Err()
+}
+
 func (r *ObjectPermsPg) Create(objectId, objectType int, memberNickname string, permissions *models.Permission) (int, error) {
 
 	objParams, err := getObjectParams(objectType)
@@ -162,6 +184,18 @@ func (r *ObjectPermsPg) Delete(objectId, memberId, ownerProjectId, objectType in
 		return err
 	}
 
+	if err = deleteMemberDenials(tx, objectType, objectId, memberId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if objectType == IsProject {
+		if err = deleteMemberFromProjectGroups(tx, objectId, memberId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	tx.Commit()
 	return err
 }
@@ -203,7 +237,146 @@ func (r *ObjectPermsPg) Update(objectId, memberId, ownerProjectId, objectType in
 		objParams.Table, objParams.IdTitle)
 
 	row := tx.QueryRow(query, objectId, memberId)
-	err = row.Scan(&objectPermsId)
+	if err = row.Scan(&objectPermsId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = updatePermissions(tx, objectPermsId, permissions); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+func (r *ObjectPermsPg) SetBoardDeny(boardId, memberId int, permissions *models.Permission) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`DELETE FROM %s AS per USING %s AS bd
+		WHERE per.id = bd.permissions_id AND bd.board_id = $1 AND bd.user_id = $2`,
+		permissionsTable, boardDenialsTable)
+	_, err = tx.Exec(query, boardId, memberId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	permissionsId, err := createPermissions(tx, permissions)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`INSERT INTO %s (user_id, board_id, permissions_id)
+		VALUES ($1, $2, $3)`, boardDenialsTable)
+	_, err = tx.Exec(query, memberId, boardId, permissionsId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
+}
+
+func (r *ObjectPermsPg) DeleteBoardDeny(boardId, memberId int) error {
+	query := fmt.Sprintf(
+		`DELETE FROM %s AS per USING %s AS bd
+		WHERE per.id = bd.permissions_id AND bd.board_id = $1 AND bd.user_id = $2`,
+		permissionsTable, boardDenialsTable)
+	_, err := r.db.Exec(query, boardId, memberId)
+	return err
+}
+
+func (r *ObjectPermsPg) GetByGroupId(objectId, groupId, objectType int) (*models.Permission, error) {
+	permissions := &models.Permission{}
+	objParams, err := getGroupObjectParams(objectType)
+	if err != nil {
+		return permissions, err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT per.read, per.write, per.admin
+		FROM %s AS obj
+			INNER JOIN %s AS per ON obj.permissions_id = per.id
+		WHERE obj.%s = $1 AND obj.group_id = $2`,
+		objParams.Table, permissionsTable, objParams.IdTitle)
+
+	row := r.db.QueryRow(query, objectId, groupId)
+	err = row.Scan(&permissions.Read, &permissions.Write, &permissions.Admin)
+	return permissions, err
+}
+
+func (r *ObjectPermsPg) CreateForGroup(objectId, objectType, groupId int, permissions *models.Permission) (int, error) {
+	objParams, err := getGroupObjectParams(objectType)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = r.GetByGroupId(objectId, groupId, objectType)
+	if err != nil && err.Error() != DbResultNotFound {
+		return 0, err
+	} else if err == nil {
+		errText := fmt.Sprintf("Group already has permissions in the %s", objParams.Title)
+		return 0, errors.New(errText)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	permissionsId, err := createPermissions(tx, permissions)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var objectPermsId int
+	query := fmt.Sprintf(
+		`INSERT INTO %s (group_id, %s, permissions_id)
+		VALUES ($1, $2, $3) RETURNING id`, objParams.Table, objParams.IdTitle)
+
+	row := tx.QueryRow(query, groupId, objectId, permissionsId)
+	if err := row.Scan(&objectPermsId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	tx.Commit()
+	return objectPermsId, nil
+}
+
+func (r *ObjectPermsPg) UpdateForGroup(objectId, groupId, objectType int, permissions *models.UpdatePermission) error {
+	objParams, err := getGroupObjectParams(objectType)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var objectPermsId int
+	query := fmt.Sprintf(
+		`SELECT obj.permissions_id
+		FROM %s AS obj
+		WHERE obj.%s = $1 AND obj.group_id = $2`,
+		objParams.Table, objParams.IdTitle)
+
+	row := tx.QueryRow(query, objectId, groupId)
+	if err = row.Scan(&objectPermsId); err != nil {
+		tx.Rollback()
+		return err
+	}
 
 	if err = updatePermissions(tx, objectPermsId, permissions); err != nil {
 		tx.Rollback()
@@ -214,6 +387,143 @@ func (r *ObjectPermsPg) Update(objectId, memberId, ownerProjectId, objectType in
 	return nil
 }
 
+func (r *ObjectPermsPg) DeleteForGroup(objectId, groupId, objectType int) error {
+	objParams, err := getGroupObjectParams(objectType)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`DELETE FROM %s AS per USING %s AS obj
+		WHERE per.id = obj.permissions_id AND obj.%s = $1 AND obj.group_id = $2`,
+		permissionsTable, objParams.Table, objParams.IdTitle)
+	_, err = r.db.Exec(query, objectId, groupId)
+	return err
+}
+
+// TransferOwnership hands the object to transfer.NewOwnerId, who becomes
+// an admin of it, and keeps a record of the change.
+func (r *ObjectPermsPg) TransferOwnership(objectId, objectType int, transfer *models.OwnershipTransfer,
+	oldOwnerPerms *models.Permission) (int, error) {
+	objParams, err := getObjectParams(objectType)
+	if err != nil {
+		return 0, err
+	}
+
+	ownersTable := projectsTable
+	if objectType == IsBoard {
+		ownersTable = boardsTable
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET owner_id = $1 WHERE id = $2 AND owner_id = $3`, ownersTable)
+	result, err := tx.Exec(query, transfer.NewOwnerId, objectId, transfer.OldOwnerId)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if count, err := result.RowsAffected(); err != nil || count == 0 {
+		tx.Rollback()
+		errText := fmt.Sprintf("Owner of the %s has changed", objParams.Title)
+		return 0, errors.New(errText)
+	}
+
+	admin := &models.Permission{Read: true, Write: true, Admin: true}
+	if err = setMemberPermissions(tx, objParams, objectId, transfer.NewOwnerId, admin); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if oldOwnerPerms != nil {
+		err = setMemberPermissions(tx, objParams, objectId, transfer.OldOwnerId, oldOwnerPerms)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	var boardId sql.NullInt64
+	if objectType == IsBoard {
+		boardId.Int64, boardId.Valid = int64(transfer.BoardId), true
+	}
+
+	var transferId int
+	query = fmt.Sprintf(
+		`INSERT INTO %s (project_id, board_id, old_owner_id, new_owner_id, author_id, created)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, transfersTable)
+	row := tx.QueryRow(query, transfer.ProjectId, boardId, transfer.OldOwnerId, transfer.NewOwnerId,
+		transfer.AuthorId, transfer.Created)
+	if err := row.Scan(&transferId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	tx.Commit()
+	return transferId, nil
+}
+
+func (r *ObjectPermsPg) GetTransfers(projectId int) ([]*models.OwnershipTransfer, error) {
+	transfers := make([]*models.OwnershipTransfer, 0)
+	query := fmt.Sprintf(
+		`SELECT t.id, t.project_id, COALESCE(t.board_id, 0), COALESCE(t.old_owner_id, 0),
+		COALESCE(t.new_owner_id, 0), COALESCE(t.author_id, 0), t.created
+		FROM %s AS t WHERE t.project_id = $1 ORDER BY t.id`, transfersTable)
+
+	rows, err := r.db.Query(query, projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		transfer := &models.OwnershipTransfer{}
+		err := rows.Scan(&transfer.Id, &transfer.ProjectId, &transfer.BoardId, &transfer.OldOwnerId,
+			&transfer.NewOwnerId, &transfer.AuthorId, &transfer.Created)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// setMemberPermissions overwrites the member's permissions on the object and
+// adds the member row when there is none yet.
+func setMemberPermissions(tx *sql.Tx, objParams *ObjectParams, objectId, memberId int,
+	permissions *models.Permission) error {
+	query := fmt.Sprintf(
+		`UPDATE %s AS per SET read = $1, write = $2, admin = $3
+		FROM %s AS obj
+		WHERE per.id = obj.permissions_id AND obj.%s = $4 AND obj.user_id = $5`,
+		permissionsTable, objParams.Table, objParams.IdTitle)
+	result, err := tx.Exec(query, permissions.Read, permissions.Write, permissions.Admin, objectId, memberId)
+	if err != nil {
+		return err
+	}
+	if count, err := result.RowsAffected(); err != nil || count != 0 {
+		return err
+	}
+
+	permissionsId, err := createPermissions(tx, permissions)
+	if err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`INSERT INTO %s (user_id, %s, permissions_id)
+		VALUES ($1, $2, $3)`, objParams.Table, objParams.IdTitle)
+	_, err = tx.Exec(query, memberId, objectId, permissionsId)
+	return err
+}
+
 func getObjectParams(objectType int) (*ObjectParams, error) {
 	var objParams ObjectParams
 	switch objectType {
@@ -235,6 +545,21 @@ func getObjectParams(objectType int) (*ObjectParams, error) {
 	return &objParams, nil
 }
 
+func getGroupObjectParams(objectType int) (*ObjectParams, error) {
+	objParams, err := getObjectParams(objectType)
+	if err != nil {
+		return objParams, err
+	}
+
+	switch objectType {
+	case IsProject:
+		objParams.Table = projectGroupsTable
+	case IsBoard:
+		objParams.Table = boardGroupsTable
+	}
+	return objParams, nil
+}
+
 func deleteMemberFromAllBoardsInProject(tx *sql.Tx, projectId, memberId int) error {
 	query := fmt.Sprintf(`DELETE FROM %s WHERE id IN (
 			SELECT bu.permissions_id
@@ -246,6 +571,34 @@ func deleteMemberFromAllBoardsInProject(tx *sql.Tx, projectId, memberId int) err
 	return err
 }
 
+// deleteMemberDenials drops the board denials of a removed member, so that
+// adding the member again starts afresh. Leaving a project drops the denials
+// on all of its boards.
+func deleteMemberDenials(tx *sql.Tx, objectType, objectId, memberId int) error {
+	condition := "bd.board_id = $1"
+	if objectType == IsProject {
+		condition = fmt.Sprintf("bd.board_id IN (SELECT id FROM %s WHERE project_id = $1)", boardsTable)
+	}
+
+	query := fmt.Sprintf(
+		`DELETE FROM %s AS per USING %s AS bd
+		WHERE per.id = bd.permissions_id AND %s AND bd.user_id = $2`,
+		permissionsTable, boardDenialsTable, condition)
+	_, err := tx.Exec(query, objectId, memberId)
+	return err
+}
+
+// A member who leaves the project leaves its groups as well, as these may
+// only hold project members.
+func deleteMemberFromProjectGroups(tx *sql.Tx, projectId, memberId int) error {
+	query := fmt.Sprintf(
+		`DELETE FROM %s AS gu USING %s AS g
+		WHERE gu.group_id = g.id AND g.project_id = $1 AND gu.user_id = $2`,
+		groupUsersTable, groupsTable)
+	_, err := tx.Exec(query, projectId, memberId)
+	return err
+}
+
 func getUserIdByNickname(tx *sql.Tx, nickname string) (int, error) {
 	var userId int
 	query := fmt.Sprintf(
@@ -8,18 +8,44 @@ import (
 )
 
 const (
-	usersTable        = "users"
-	projectsTable     = "projects"
-	projectUsersTable = "project_users"
-	permissionsTable  = "permissions"
-	datetimesTable    = "datetimes"
-	boardsTable       = "boards"
-	boardUsersTable   = "board_users"
-	taskListsTable    = "task_lists"
-	tasksTable        = "tasks"
-	labelsTable       = "labels"
-	taskLabelsTable   = "task_labels"
-	tokensTable       = "tokens"
+	usersTable         = "users"
+	projectsTable      = "projects"
+	projectUsersTable  = "project_users"
+	permissionsTable   = "permissions"
+	datetimesTable     = "datetimes"
+	boardsTable        = "boards"
+	boardUsersTable    = "board_users"
+	boardDenialsTable  = "board_denials"
+	groupsTable        = "groups"
+	groupUsersTable    = "group_users"
+	projectGroupsTable = "project_groups"
+	boardGroupsTable   = "board_groups"
+	invitationsTable   = "invitations"
+	boardSharesTable   = "board_shares"
+	transfersTable     = "ownership_transfers"
+	taskListsTable     = "task_lists"
+	tasksTable         = "tasks"
+	sprintsTable       = "sprints"
+	taskHistoryTable   = "task_history"
+	labelsTable        = "labels"
+	taskLabelsTable    = "task_labels"
+	relationsTable     = "task_relations"
+	fieldsTable        = "custom_fields"
+	fieldOptionsTable  = "field_options"
+	fieldValuesTable   = "task_field_values"
+	attachmentsTable   = "attachments"
+	watchersTable      = "watchers"
+	notificationsTable = "notifications"
+	preferencesTable   = "notification_preferences"
+	mailSettingsTable  = "mail_settings"
+	mentionsTable      = "mentions"
+	estimatesTable     = "time_estimates"
+	timeLogsTable      = "time_logs"
+	timersTable        = "timers"
+	templatesTable     = "board_templates"
+	viewsTable         = "board_views"
+	defaultViewsTable  = "board_default_views"
+	tokensTable        = "tokens"
 )
 
 type Config struct {
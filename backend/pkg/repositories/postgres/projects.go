@@ -1,12 +1,14 @@
 package postgres
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type ProjectPg struct {
@@ -18,22 +20,31 @@ func NewProjectPg(db *sqlx.DB) *ProjectPg {
 }
 
 func (r *ProjectPg) Create(project *models.Project) (int, error) {
-	var projectId int
-
 	tx, err := r.db.Begin()
 	if err != nil {
 		return 0, err
 	}
 
-	defPermissionId, err := createPermissions(tx, project.DefaultPermissions)
+	projectId, err := createProject(tx, project)
 	if err != nil {
 		tx.Rollback()
 		return 0, err
 	}
 
+	tx.Commit()
+	return projectId, nil
+}
+
+func createProject(tx *sql.Tx, project *models.Project) (int, error) {
+	var projectId int
+
+	defPermissionId, err := createPermissions(tx, project.DefaultPermissions)
+	if err != nil {
+		return 0, err
+	}
+
 	datetimesId, err := createDatetimes(tx, project.Datetimes)
 	if err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
@@ -45,7 +56,6 @@ func (r *ProjectPg) Create(project *models.Project) (int, error) {
 	row := tx.QueryRow(query, project.OwnerId, defPermissionId,
 		datetimesId, project.Title, project.Description)
 	if err := row.Scan(&projectId); err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
@@ -56,7 +66,6 @@ func (r *ProjectPg) Create(project *models.Project) (int, error) {
 	}
 	permissionId, err := createPermissions(tx, permission)
 	if err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
@@ -66,11 +75,9 @@ func (r *ProjectPg) Create(project *models.Project) (int, error) {
 
 	_, err = tx.Exec(query, project.OwnerId, projectId, permissionId)
 	if err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
-	tx.Commit()
 	return projectId, nil
 }
 
@@ -104,7 +111,7 @@ func (r *ProjectPg) GetById(projectId int) (*models.Project, error) {
 		FROM %s AS p
 			INNER JOIN %s AS dper ON p.default_permissions_id = dper.id
 			INNER JOIN %s AS d ON p.datetimes_id = d.id
-		WHERE p.id = $1`,
+		WHERE p.id = $1 AND p.deleted_at IS NULL`,
 		projectsTable, permissionsTable, datetimesTable)
 
 	row := tx.QueryRow(query, projectId)
@@ -134,7 +141,7 @@ func (r *ProjectPg) GetAll(userId int) ([]*models.Project, error) {
 			INNER JOIN %s AS p ON pu.project_id = p.id
 			INNER JOIN %s AS dper ON p.default_permissions_id = dper.id
 			INNER JOIN %s AS d ON p.datetimes_id = d.id
-		WHERE pu.user_id = $1 AND per.read = true`,
+		WHERE pu.user_id = $1 AND per.read = true AND p.deleted_at IS NULL`,
 		projectUsersTable, permissionsTable, projectsTable, permissionsTable,
 		datetimesTable)
 
@@ -224,55 +231,12 @@ func (r *ProjectPg) Update(projectId int, input *models.UpdateProject) error {
 }
 
 func (r *ProjectPg) Delete(projectId int) error {
-	tx, err := r.db.Begin()
-	if err != nil {
-		return err
-	}
-
 	query := fmt.Sprintf(
-		`DELETE FROM %s AS per USING %s AS pu
-		WHERE per.id = pu.permissions_id AND pu.project_id=$1`,
-		permissionsTable, projectUsersTable)
-	_, err = tx.Exec(query, projectId)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	defPermissionsId, datetimesId, err := r.getProjectForeignKeys(projectId)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	if err = deletePermissions(tx, defPermissionsId); err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	if err = deleteDatetimes(tx, datetimesId); err != nil {
-		tx.Rollback()
-		return err
-	}
-	tx.Commit()
+		`UPDATE %s SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`, projectsTable)
+	_, err := r.db.Exec(query, projectId, time.Now().Unix())
 	return err
 }
 
-func (r *ProjectPg) GetPermissions(userId, projectId int) (*models.Permission, error) {
-	permissions := &models.Permission{}
-
-	query := fmt.Sprintf(
-		`SELECT per.read, per.write, per.admin
-		FROM %s AS pu
-			INNER JOIN %s AS per ON pu.permissions_id = per.id
-		WHERE pu.project_id = $1 AND pu.user_id = $2`,
-		projectUsersTable, permissionsTable)
-
-	row := r.db.QueryRow(query, projectId, userId)
-	err := row.Scan(&permissions.Read, &permissions.Write, &permissions.Admin)
-	return permissions, err
-}
-
 func (r *ProjectPg) getProjectForeignKeys(projectId int) (int, int, error) {
 	var defPermissionsId, datetimesId int
 	query := fmt.Sprintf(
@@ -288,17 +252,28 @@ func (r *ProjectPg) GetMembers(projectId int) ([]*models.Member, error) {
 	var members []*models.Member
 
 	query := fmt.Sprintf(
-		`SELECT u.id, u.nickname, u.avatar, per.read, per.write, per.admin,
-		CASE p.owner_id
-		WHEN user_id THEN true
-		ELSE false
-		END AS isOwner
-		FROM %s AS pu
-			INNER JOIN %s AS per ON pu.permissions_id = per.id
-			INNER JOIN %s AS u ON pu.user_id = u.id
-			INNER JOIN %s AS p ON pu.project_id = p.id
-		WHERE pu.project_id = $1`,
-		projectUsersTable, permissionsTable, usersTable, projectsTable)
+		`SELECT u.id, u.nickname, u.avatar,
+		bool_or(per.read), bool_or(per.write), bool_or(per.admin),
+		u.id = p.owner_id AS isOwner,
+		bool_or(m.group_id IS NULL) AS isDirect,
+		array_remove(array_agg(m.group_id), NULL) AS groups
+		FROM (
+			SELECT pu.user_id, pu.permissions_id, NULL::int AS group_id
+			FROM %s AS pu
+			WHERE pu.project_id = $1
+			UNION ALL
+			SELECT gu.user_id, pg.permissions_id, pg.group_id
+			FROM %s AS pg
+				INNER JOIN %s AS gu ON gu.group_id = pg.group_id
+			WHERE pg.project_id = $1
+		) AS m
+			INNER JOIN %s AS per ON m.permissions_id = per.id
+			INNER JOIN %s AS u ON m.user_id = u.id
+			INNER JOIN %s AS p ON p.id = $1
+		GROUP BY u.id, p.owner_id
+		ORDER BY u.id`,
+		projectUsersTable, projectGroupsTable, groupUsersTable,
+		permissionsTable, usersTable, projectsTable)
 
 	rows, err := r.db.Query(query, projectId)
 	if err != nil {
@@ -309,13 +284,17 @@ func (r *ProjectPg) GetMembers(projectId int) ([]*models.Member, error) {
 	for rows.Next() {
 		member := &models.Member{}
 		permissions := &models.Permission{}
+		var groups pq.Int64Array
 
 		err := rows.Scan(&member.Id, &member.Nickname, &member.Avatar, &permissions.Read,
-			&permissions.Write, &permissions.Admin, &member.IsOwner)
+			&permissions.Write, &permissions.Admin, &member.IsOwner, &member.IsDirect, &groups)
 		if err != nil {
 			return nil, err
 		}
 
+		for _, groupId := range groups {
+			member.Groups = append(member.Groups, int(groupId))
+		}
 		member.Permissions = permissions
 		members = append(members, member)
 	}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	relationCycle  = "Relation would create a cycle"
+	relationExists = "Relation already exists"
+	taskBlocked    = "Task is blocked"
+)
+
+type RelationPg struct {
+	db *sqlx.DB
+}
+
+func NewRelationPg(db *sqlx.DB) *RelationPg {
+	return &RelationPg{db: db}
+}
+
+// blockedCondition tells whether the task is blocked by a live task that is
+// not in a done list yet.
+func blockedCondition(taskColumn string) string {
+	return fmt.Sprintf(
+		`EXISTS (SELECT 1 FROM %s AS rel
+			INNER JOIN %s AS bt ON rel.task_id = bt.id
+			INNER JOIN %s AS btl ON bt.list_id = btl.id
+		WHERE rel.related_id = %s AND rel.kind = '%s' AND bt.deleted_at IS NULL
+			AND btl.deleted_at IS NULL AND NOT btl.done)`,
+		relationsTable, tasksTable, taskListsTable, taskColumn, models.RelationBlocks)
+}
+
+// checkDoneList keeps blocked tasks out of done lists.
+func checkDoneList(tx *sql.Tx, taskId, listId int) error {
+	var blocked bool
+	query := fmt.Sprintf(
+		`SELECT tl.done AND %s FROM %s AS tl WHERE tl.id = $2`,
+		blockedCondition("$1::int"), taskListsTable)
+	if err := tx.QueryRow(query, taskId, listId).Scan(&blocked); err != nil {
+		return err
+	}
+	if blocked {
+		return bulkError(taskBlocked)
+	}
+	return nil
+}
+
+// checkBlockedInList keeps a list with blocked tasks from becoming done, which
+// would put them in a done list just the same.
+func checkBlockedInList(tx *sql.Tx, listId int) error {
+	var blocked bool
+	query := fmt.Sprintf(
+		`SELECT EXISTS (SELECT 1 FROM %s AS t
+		WHERE t.list_id = $1 AND t.deleted_at IS NULL AND %s)`,
+		tasksTable, blockedCondition("t.id"))
+	if err := tx.QueryRow(query, listId).Scan(&blocked); err != nil {
+		return err
+	}
+	if blocked {
+		return bulkError(taskBlocked)
+	}
+	return nil
+}
+
+// GetAll returns the relations of the task with its live tasks from the point
+// of view of the task, so a blocking link to it comes as blockedBy.
+func (r *RelationPg) GetAll(taskId int) ([]*models.TaskRelation, error) {
+	relations := make([]*models.TaskRelation, 0)
+	query := fmt.Sprintf(
+		`SELECT rel.id,
+			CASE WHEN rel.kind = '%[4]s' AND rel.related_id = $1 THEN '%[5]s' ELSE rel.kind END,
+			t.id, t.title, t.list_id, tl.board_id, tl.done
+		FROM %[1]s AS rel
+			INNER JOIN %[2]s AS t
+				ON t.id = CASE WHEN rel.task_id = $1 THEN rel.related_id ELSE rel.task_id END
+			INNER JOIN %[3]s AS tl ON t.list_id = tl.id
+		WHERE (rel.task_id = $1 OR rel.related_id = $1)
+			AND t.deleted_at IS NULL AND tl.deleted_at IS NULL
+		ORDER BY rel.id`,
+		relationsTable, tasksTable, taskListsTable, models.RelationBlocks, models.RelationBlockedBy)
+
+	rows, err := r.db.Query(query, taskId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		relation := &models.TaskRelation{Task: &models.RelatedTask{}}
+		err := rows.Scan(&relation.Id, &relation.Kind, &relation.Task.Id, &relation.Task.Title,
+			&relation.Task.ListId, &relation.Task.BoardId, &relation.Task.Done)
+		if err != nil {
+			return nil, err
+		}
+		relations = append(relations, relation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+func (r *RelationPg) GetTaskBoard(taskId int) (*models.Board, error) {
+	board := &models.Board{}
+	query := fmt.Sprintf(
+		`SELECT b.id, b.project_id
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			INNER JOIN %s AS b ON tl.board_id = b.id
+		WHERE t.id = $1 AND t.deleted_at IS NULL AND tl.deleted_at IS NULL
+			AND b.deleted_at IS NULL`,
+		tasksTable, taskListsTable, boardsTable)
+	err := r.db.QueryRow(query, taskId).Scan(&board.Id, &board.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// Create links the tasks with a blocks or relates link. Creating links is
+// serialized so that two concurrent blocking links can't close a cycle.
+func (r *RelationPg) Create(taskId, relatedId int, kind string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE`, relationsTable)
+	if _, err := tx.Exec(query); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	// Relates links go both ways, so the reversed one is the same link.
+	var exists bool
+	query = fmt.Sprintf(
+		`SELECT EXISTS (SELECT 1 FROM %s
+		WHERE kind = $3 AND ((task_id = $1 AND related_id = $2)
+			OR (kind = '%s' AND task_id = $2 AND related_id = $1)))`,
+		relationsTable, models.RelationRelates)
+	if err := tx.QueryRow(query, taskId, relatedId, kind).Scan(&exists); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if exists {
+		tx.Rollback()
+		return 0, errors.New(relationExists)
+	}
+
+	if kind == models.RelationBlocks {
+		var cycle bool
+		query = fmt.Sprintf(
+			`WITH RECURSIVE blocked (id) AS (
+				SELECT related_id FROM %[1]s WHERE task_id = $2 AND kind = '%[2]s'
+				UNION
+				SELECT rel.related_id FROM %[1]s AS rel
+					INNER JOIN blocked ON rel.task_id = blocked.id
+				WHERE rel.kind = '%[2]s'
+			)
+			SELECT EXISTS (SELECT 1 FROM blocked WHERE id = $1)`,
+			relationsTable, models.RelationBlocks)
+		if err := tx.QueryRow(query, taskId, relatedId).Scan(&cycle); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		if cycle {
+			tx.Rollback()
+			return 0, errors.New(relationCycle)
+		}
+	}
+
+	var id int
+	query = fmt.Sprintf(
+		`INSERT INTO %s (task_id, related_id, kind) VALUES ($1, $2, $3) RETURNING id`,
+		relationsTable)
+	if err := tx.QueryRow(query, taskId, relatedId, kind).Scan(&id); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	tx.Commit()
+	return id, nil
+}
+
+func (r *RelationPg) Delete(relationId, taskId int) error {
+	var id int
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE id = $1 AND (task_id = $2 OR related_id = $2) RETURNING id`,
+		relationsTable)
+	return r.db.QueryRow(query, relationId, taskId).Scan(&id)
+}
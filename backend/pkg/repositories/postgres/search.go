@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// The documents must stay the same as in the search indexes of init.sql,
+// otherwise the indexes are not used.
+const (
+	projectDocument = `p.title || ' ' || COALESCE(p.description, '')`
+	boardDocument   = `b.title`
+	taskDocument    = `t.title || ' ' || COALESCE(t.description, '')`
+	labelDocument   = `lb.name`
+
+	headlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5`
+)
+
+type SearchPg struct {
+	db *sqlx.DB
+}
+
+func NewSearchPg(db *sqlx.DB) *SearchPg {
+	return &SearchPg{db: db}
+}
+
+// Search looks for the text in the given projects and boards. Projects are
+// matched by their id and everything else by the id of its board.
+func (r *SearchPg) Search(query *models.SearchQuery, projectIds, boardIds []int) ([]*models.SearchHit, error) {
+	hits := make([]*models.SearchHit, 0)
+	hitColumns := func(document string) string {
+		return fmt.Sprintf(
+			`ts_headline('simple', %s, q.query, '%s') AS snippet,
+			ts_rank(to_tsvector('simple', %s), q.query) AS rank`,
+			document, headlineOptions, document)
+	}
+	match := func(document string) string {
+		return fmt.Sprintf(`to_tsvector('simple', %s) @@ q.query`, document)
+	}
+
+	projects := fmt.Sprintf(
+		`SELECT '%s' AS type, p.id, p.id AS project_id, 0 AS board_id, 0 AS list_id,
+			p.title, %s
+		FROM %s AS p, q
+		WHERE p.id = ANY($2) AND p.deleted_at IS NULL AND %s`,
+		models.SearchProject, hitColumns(projectDocument), projectsTable,
+		match(projectDocument))
+	boards := fmt.Sprintf(
+		`SELECT '%s', b.id, b.project_id, b.id, 0, b.title, %s
+		FROM %s AS b, q
+		WHERE b.id = ANY($3) AND b.deleted_at IS NULL AND %s`,
+		models.SearchBoard, hitColumns(boardDocument), boardsTable,
+		match(boardDocument))
+	tasks := fmt.Sprintf(
+		`SELECT '%s', t.id, b.project_id, b.id, tl.id, t.title, %s
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			INNER JOIN %s AS b ON tl.board_id = b.id, q
+		WHERE b.id = ANY($3) AND b.deleted_at IS NULL AND tl.deleted_at IS NULL
+			AND t.deleted_at IS NULL AND %s`,
+		models.SearchTask, hitColumns(taskDocument), tasksTable, taskListsTable,
+		boardsTable, match(taskDocument))
+	labels := fmt.Sprintf(
+		`SELECT '%s', lb.id, b.project_id, b.id, 0, lb.name, %s
+		FROM %s AS lb
+			INNER JOIN %s AS b ON lb.board_id = b.id, q
+		WHERE b.id = ANY($3) AND b.deleted_at IS NULL AND %s`,
+		models.SearchLabel, hitColumns(labelDocument), labelsTable, boardsTable,
+		match(labelDocument))
+
+	sqlQuery := fmt.Sprintf(
+		`WITH q AS (SELECT websearch_to_tsquery('simple', $1) AS query)
+		SELECT * FROM (%s UNION ALL %s UNION ALL %s UNION ALL %s) AS hits
+		ORDER BY rank DESC, type, id
+		LIMIT $4 OFFSET $5`,
+		projects, boards, tasks, labels)
+
+	rows, err := r.db.Query(sqlQuery, query.Text, pq.Array(projectIds),
+		pq.Array(boardIds), query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		hit := &models.SearchHit{}
+		err := rows.Scan(&hit.Type, &hit.Id, &hit.ProjectId, &hit.BoardId,
+			&hit.ListId, &hit.Title, &hit.Snippet, &hit.Rank)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
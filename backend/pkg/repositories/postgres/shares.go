@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type BoardSharePg struct {
+	db *sqlx.DB
+}
+
+func NewBoardSharePg(db *sqlx.DB) *BoardSharePg {
+	return &BoardSharePg{db: db}
+}
+
+const boardShareSelect = `SELECT s.id, b.project_id, s.board_id, s.owner_id, s.token, s.password,
+		s.created, s.expires
+	FROM %s AS s
+		INNER JOIN %s AS b ON s.board_id = b.id`
+
+func (r *BoardSharePg) Create(share *models.BoardShare) (int, error) {
+	var id int
+	query := fmt.Sprintf(
+		`INSERT INTO %s (board_id, owner_id, token, password, created, expires)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, boardSharesTable)
+
+	row := r.db.QueryRow(query, share.BoardId, share.OwnerId, share.Token, share.Password,
+		share.Created, share.Expires)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *BoardSharePg) GetAll(boardId int) ([]*models.BoardShare, error) {
+	shares := make([]*models.BoardShare, 0)
+	query := fmt.Sprintf(boardShareSelect+` WHERE s.board_id = $1 ORDER BY s.id`,
+		boardSharesTable, boardsTable)
+
+	rows, err := r.db.Query(query, boardId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		share, err := scanBoardShare(rows)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (r *BoardSharePg) GetById(shareId int) (*models.BoardShare, error) {
+	query := fmt.Sprintf(boardShareSelect+` WHERE s.id = $1`, boardSharesTable, boardsTable)
+	return scanBoardShare(r.db.QueryRow(query, shareId))
+}
+
+func (r *BoardSharePg) GetByToken(token string) (*models.BoardShare, error) {
+	query := fmt.Sprintf(boardShareSelect+` WHERE s.token = $1`, boardSharesTable, boardsTable)
+	return scanBoardShare(r.db.QueryRow(query, token))
+}
+
+func (r *BoardSharePg) Delete(shareId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, boardSharesTable)
+	_, err := r.db.Exec(query, shareId)
+	return err
+}
+
+func scanBoardShare(row rowScanner) (*models.BoardShare, error) {
+	share := &models.BoardShare{}
+	err := row.Scan(&share.Id, &share.ProjectId, &share.BoardId, &share.OwnerId, &share.Token,
+		&share.Password, &share.Created, &share.Expires)
+	if err != nil {
+		return nil, err
+	}
+	share.HasPassword = share.Password != ""
+	return share, nil
+}
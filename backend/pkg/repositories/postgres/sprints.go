@@ -0,0 +1,313 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	sprintNotOnBoard = "Sprint is not on the board"
+	sprintCompleted  = "Sprint is completed"
+	sprintNotPlanned = "Sprint is not planned"
+	sprintNotActive  = "Sprint is not active"
+	sprintActive     = "Another sprint is active"
+)
+
+type SprintPg struct {
+	db *sqlx.DB
+}
+
+func NewSprintPg(db *sqlx.DB) *SprintPg {
+	return &SprintPg{db: db}
+}
+
+const sprintColumns = `id, board_id, name, goal, start_date, end_date, state, started, completed`
+
+func (r *SprintPg) GetAll(boardId int) ([]*models.Sprint, error) {
+	sprints := make([]*models.Sprint, 0)
+	query := fmt.Sprintf(
+		`SELECT %s FROM %s WHERE board_id = $1 ORDER BY start_date, id`, sprintColumns, sprintsTable)
+	if err := r.db.Select(&sprints, query, boardId); err != nil {
+		return nil, err
+	}
+	return sprints, nil
+}
+
+func (r *SprintPg) GetById(sprintId int) (*models.Sprint, error) {
+	sprint := &models.Sprint{}
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, sprintColumns, sprintsTable)
+	if err := r.db.Get(sprint, query, sprintId); err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+func (r *SprintPg) Create(sprint *models.Sprint) (int, error) {
+	var id int
+	query := fmt.Sprintf(
+		`INSERT INTO %s (board_id, name, goal, start_date, end_date, state)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, sprintsTable)
+	row := r.db.QueryRow(query, sprint.BoardId, sprint.Name, sprint.Goal, sprint.Start, sprint.End,
+		models.SprintPlanned)
+	err := row.Scan(&id)
+	return id, err
+}
+
+func (r *SprintPg) Update(sprintId int, input *models.UpdateSprint) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if input.Goal != nil {
+		setValues = append(setValues, fmt.Sprintf("goal=$%d", argId))
+		args = append(args, *input.Goal)
+		argId++
+	}
+
+	if input.Start != nil {
+		setValues = append(setValues, fmt.Sprintf("start_date=$%d", argId))
+		args = append(args, *input.Start)
+		argId++
+	}
+
+	if input.End != nil {
+		setValues = append(setValues, fmt.Sprintf("end_date=$%d", argId))
+		args = append(args, *input.End)
+		argId++
+	}
+
+	if len(setValues) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET %s WHERE id = $%d`,
+		sprintsTable, strings.Join(setValues, ", "), argId)
+	args = append(args, sprintId)
+	_, err := r.db.Exec(query, args...)
+	return err
+}
+
+// Delete leaves the tasks of the sprint in the backlog.
+func (r *SprintPg) Delete(sprintId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, sprintsTable)
+	_, err := r.db.Exec(query, sprintId)
+	return err
+}
+
+// GetTasks returns the live tasks of the sprint.
+func (r *SprintPg) GetTasks(sprintId int) ([]*models.SprintTask, error) {
+	tasks := make([]*models.SprintTask, 0)
+	query := fmt.Sprintf(
+		`SELECT t.id, t.list_id, t.title, t.points, tl.done
+		FROM %s AS t INNER JOIN %s AS tl ON t.list_id = tl.id
+		WHERE t.sprint_id = $1 AND t.deleted_at IS NULL AND tl.deleted_at IS NULL
+		ORDER BY tl.position, t.position`,
+		tasksTable, taskListsTable)
+	if err := r.db.Select(&tasks, query, sprintId); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Start makes the planned sprint the active one of its board.
+func (r *SprintPg) Start(sprintId int, now int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	sprint, err := lockSprint(tx, sprintId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if sprint.State != models.SprintPlanned {
+		tx.Rollback()
+		return errors.New(sprintNotPlanned)
+	}
+
+	var active bool
+	query := fmt.Sprintf(
+		`SELECT EXISTS (SELECT 1 FROM %s WHERE board_id = $1 AND state = $2)`, sprintsTable)
+	if err := tx.QueryRow(query, sprint.BoardId, models.SprintActive).Scan(&active); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if active {
+		tx.Rollback()
+		return errors.New(sprintActive)
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET state = $2, started = $3 WHERE id = $1`, sprintsTable)
+	if _, err := tx.Exec(query, sprintId, models.SprintActive, now); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := recordTaskHistory(tx, now, "t.sprint_id = $2", sprintId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Complete closes the active sprint and carries its unfinished tasks over
+// to the planned sprint of the same board, or to the backlog for 0. It
+// returns the ids of the carried over tasks.
+func (r *SprintPg) Complete(sprintId, carryOverTo int, now int64) ([]int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	sprint, err := lockSprint(tx, sprintId)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if sprint.State != models.SprintActive {
+		tx.Rollback()
+		return nil, errors.New(sprintNotActive)
+	}
+
+	if carryOverTo != 0 {
+		target, err := lockSprint(tx, carryOverTo)
+		if err == sql.ErrNoRows || (err == nil && target.BoardId != sprint.BoardId) {
+			tx.Rollback()
+			return nil, errors.New(sprintNotOnBoard)
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if target.State != models.SprintPlanned {
+			tx.Rollback()
+			return nil, errors.New(sprintNotPlanned)
+		}
+	}
+
+	var carried pq.Int64Array
+	query := fmt.Sprintf(
+		`WITH carried AS (
+			UPDATE %s AS t SET sprint_id = NULLIF($2, 0)
+			FROM %s AS tl
+			WHERE t.list_id = tl.id AND t.sprint_id = $1 AND t.deleted_at IS NULL
+				AND tl.deleted_at IS NULL AND NOT tl.done
+			RETURNING t.id)
+		SELECT COALESCE(array_agg(id ORDER BY id), '{}') FROM carried`,
+		tasksTable, taskListsTable)
+	if err := tx.QueryRow(query, sprintId, carryOverTo).Scan(&carried); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET state = $2, completed = $3 WHERE id = $1`, sprintsTable)
+	if _, err := tx.Exec(query, sprintId, models.SprintCompleted, now); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := recordTaskHistory(tx, now, "t.id = ANY($2)", carried); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	taskIds := make([]int, len(carried))
+	for i, id := range carried {
+		taskIds[i] = int(id)
+	}
+	return taskIds, nil
+}
+
+// GetHistory returns the snapshots of every task that was ever in the
+// sprint, oldest first.
+func (r *SprintPg) GetHistory(sprintId int) ([]*models.TaskSnapshot, error) {
+	snapshots := make([]*models.TaskSnapshot, 0)
+	query := fmt.Sprintf(
+		`SELECT h.task_id, COALESCE(h.sprint_id, 0) AS sprint_id, h.points, h.done, h.created
+		FROM %[1]s AS h
+		WHERE h.task_id IN (SELECT task_id FROM %[1]s WHERE sprint_id = $1)
+		ORDER BY h.created, h.id`,
+		taskHistoryTable)
+	if err := r.db.Select(&snapshots, query, sprintId); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func lockSprint(tx *sql.Tx, sprintId int) (*models.Sprint, error) {
+	sprint := &models.Sprint{Id: sprintId}
+	query := fmt.Sprintf(`SELECT board_id, state FROM %s WHERE id = $1 FOR UPDATE`, sprintsTable)
+	if err := tx.QueryRow(query, sprintId).Scan(&sprint.BoardId, &sprint.State); err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+// checkTaskSprint lets tasks join only the sprints of their board that are
+// not completed yet.
+func checkTaskSprint(tx *sql.Tx, taskId, sprintId int) error {
+	var state string
+	query := fmt.Sprintf(
+		`SELECT s.state
+		FROM %s AS s
+			INNER JOIN %s AS tl ON tl.board_id = s.board_id
+			INNER JOIN %s AS t ON t.list_id = tl.id
+		WHERE s.id = $1 AND t.id = $2`,
+		sprintsTable, taskListsTable, tasksTable)
+	err := tx.QueryRow(query, sprintId, taskId).Scan(&state)
+	if err == sql.ErrNoRows {
+		return errors.New(sprintNotOnBoard)
+	}
+	if err != nil {
+		return err
+	}
+	if state == models.SprintCompleted {
+		return errors.New(sprintCompleted)
+	}
+	return nil
+}
+
+// recordTaskHistory snapshots the sprint, the points and the done state of
+// the tasks matching the condition, numbered from $2, that changed since
+// their last snapshot. Deleted tasks count as out of the sprint, and tasks
+// never in a sprint are left out. Burndown charts are drawn from these.
+func recordTaskHistory(tx *sql.Tx, now int64, condition string, args ...interface{}) error {
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (task_id, sprint_id, points, done, created)
+		SELECT cur.task_id, cur.sprint_id, cur.points, cur.done, $1
+		FROM (
+			SELECT t.id AS task_id,
+				CASE WHEN t.deleted_at IS NULL AND tl.deleted_at IS NULL THEN t.sprint_id END AS sprint_id,
+				t.points, tl.done
+			FROM %[2]s AS t INNER JOIN %[3]s AS tl ON t.list_id = tl.id
+			WHERE %[4]s
+		) AS cur
+		LEFT JOIN LATERAL (
+			SELECT h.sprint_id, h.points, h.done FROM %[1]s AS h
+			WHERE h.task_id = cur.task_id ORDER BY h.id DESC LIMIT 1
+		) AS last ON true
+		WHERE CASE WHEN last.points IS NULL THEN cur.sprint_id IS NOT NULL
+			ELSE last.sprint_id IS DISTINCT FROM cur.sprint_id
+				OR last.points <> cur.points OR last.done <> cur.done END`,
+		taskHistoryTable, tasksTable, taskListsTable, condition)
+	_, err := tx.Exec(query, append([]interface{}{now}, args...)...)
+	return err
+}
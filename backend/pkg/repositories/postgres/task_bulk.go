@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+)
+
+// bulkError fails a single operation of a bulk request, as opposed to the
+// database errors that fail the whole request.
+type bulkError string
+
+func (e bulkError) Error() string {
+	return string(e)
+}
+
+// Bulk applies the operations to the tasks of the board in order and in a
+// single transaction. When one of them fails nothing is applied: the failed
+// operation gets the reason and the ones after it are skipped.
+func (r *TaskPg) Bulk(boardId int, operations []*models.BulkTaskOperation) ([]*models.BulkTaskResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	failed := false
+	results := make([]*models.BulkTaskResult, 0, len(operations))
+	for _, operation := range operations {
+		result := &models.BulkTaskResult{TaskId: operation.TaskId, Status: models.BulkStatusOk}
+		results = append(results, result)
+		if failed {
+			result.Status = models.BulkStatusSkipped
+			continue
+		}
+
+		err := r.applyBulkOperation(tx, boardId, operation, now)
+		if opErr, ok := err.(bulkError); ok {
+			result.Status, result.Error = models.BulkStatusFailed, opErr.Error()
+			failed = true
+			continue
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if failed {
+		tx.Rollback()
+		return results, nil
+	}
+
+	tx.Commit()
+	return results, nil
+}
+
+func (r *TaskPg) applyBulkOperation(tx *sql.Tx, boardId int, operation *models.BulkTaskOperation, now int64) error {
+	var listId, position int
+	var listArchived bool
+	query := fmt.Sprintf(
+		`SELECT t.list_id, t.position, tl.archived
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+		WHERE t.id = $1 AND tl.board_id = $2 AND t.deleted_at IS NULL AND tl.deleted_at IS NULL
+		FOR UPDATE OF t`,
+		tasksTable, taskListsTable)
+	err := tx.QueryRow(query, operation.TaskId, boardId).Scan(&listId, &position, &listArchived)
+	if err == sql.ErrNoRows {
+		return bulkError("Task not found")
+	}
+	if err != nil {
+		return err
+	}
+	if listArchived {
+		return bulkError("List is archived")
+	}
+
+	switch operation.Op {
+	case models.BulkMove:
+		err = r.moveBulkTask(tx, boardId, operation, listId, position)
+	case models.BulkAddLabel, models.BulkRemoveLabel:
+		err = setBulkTaskLabel(tx, boardId, operation)
+	case models.BulkArchive, models.BulkUnarchive:
+		query = fmt.Sprintf(`UPDATE %s SET archived = $2 WHERE id = $1`, tasksTable)
+		_, err = tx.Exec(query, operation.TaskId, operation.Op == models.BulkArchive)
+	case models.BulkDelete:
+		query = fmt.Sprintf(`UPDATE %s SET deleted_at = $2 WHERE id = $1`, tasksTable)
+		if _, err := tx.Exec(query, operation.TaskId, now); err != nil {
+			return err
+		}
+		if err := r.updateTaskPosition(tx, listId, position+1, "-"); err != nil {
+			return err
+		}
+		return recordTaskHistory(tx, now, "t.id = $2", operation.TaskId)
+	default:
+		return bulkError(fmt.Sprintf("Unknown operation %q", operation.Op))
+	}
+	if err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`UPDATE %s SET updated = $2, accessed = $2
+		WHERE id = (SELECT datetimes_id FROM %s WHERE id = $1)`,
+		datetimesTable, tasksTable)
+	if _, err := tx.Exec(query, operation.TaskId, now); err != nil {
+		return err
+	}
+	return recordTaskHistory(tx, now, "t.id = $2", operation.TaskId)
+}
+
+func (r *TaskPg) moveBulkTask(tx *sql.Tx, boardId int, operation *models.BulkTaskOperation, listId, position int) error {
+	var archived bool
+	query := fmt.Sprintf(
+		`SELECT archived FROM %s WHERE id = $1 AND board_id = $2 AND deleted_at IS NULL`,
+		taskListsTable)
+	err := tx.QueryRow(query, operation.ListId, boardId).Scan(&archived)
+	if err == sql.ErrNoRows {
+		return bulkError("List not found")
+	}
+	if err != nil {
+		return err
+	}
+	if archived {
+		return bulkError("List is archived")
+	}
+
+	return r.moveTask(tx, operation.TaskId, listId, position, operation.ListId, operation.Position)
+}
+
+func setBulkTaskLabel(tx *sql.Tx, boardId int, operation *models.BulkTaskOperation) error {
+	var id int
+	query := fmt.Sprintf(`SELECT id FROM %s WHERE id = $1 AND board_id = $2`, labelsTable)
+	err := tx.QueryRow(query, operation.LabelId, boardId).Scan(&id)
+	if err == sql.ErrNoRows {
+		return bulkError("Label not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if operation.Op == models.BulkRemoveLabel {
+		query = fmt.Sprintf(`DELETE FROM %s WHERE task_id = $1 AND label_id = $2`, taskLabelsTable)
+	} else {
+		query = fmt.Sprintf(
+			`INSERT INTO %s (task_id, label_id)
+			SELECT $1, $2 WHERE NOT EXISTS
+				(SELECT 1 FROM %s WHERE task_id = $1 AND label_id = $2)`,
+			taskLabelsTable, taskLabelsTable)
+	}
+	_, err = tx.Exec(query, operation.TaskId, operation.LabelId)
+	return err
+}
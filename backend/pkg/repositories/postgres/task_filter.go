@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/filter"
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+)
+
+// taskFilterColumns are the columns of the tasks query in GetFiltered that
+// the fields of the filter are compared with.
+var taskFilterColumns = map[string]string{
+	filter.FieldList:        "tl.title",
+	filter.FieldTitle:       "t.title",
+	filter.FieldDescription: "COALESCE(t.description, '')",
+	filter.FieldCreated:     "d.created",
+	filter.FieldUpdated:     "d.updated",
+	filter.FieldAccessed:    "d.accessed",
+}
+
+// taskSortColumns are the columns of the tasks query in GetFiltered that
+// the tasks are sorted by.
+var taskSortColumns = map[string]string{
+	filter.FieldTitle:    "lower(t.title)",
+	filter.FieldCreated:  "d.created",
+	filter.FieldUpdated:  "d.updated",
+	filter.FieldAccessed: "d.accessed",
+}
+
+var numberOperators = map[string]string{
+	filter.OpEqual:          "=",
+	filter.OpLess:           "<",
+	filter.OpLessOrEqual:    "<=",
+	filter.OpGreater:        ">",
+	filter.OpGreaterOrEqual: ">=",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// compileTaskFilter turns the query into SQL conditions joined with AND.
+// Placeholders are numbered from argId on.
+func compileTaskFilter(query *filter.Query, now time.Time, argId int) (string, []interface{}) {
+	conditions := make([]string, 0, len(query.Terms))
+	args := make([]interface{}, 0)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		argId++
+		return fmt.Sprintf("$%d", argId-1)
+	}
+
+	for _, term := range query.Terms {
+		var condition string
+		switch term.Field {
+		case filter.FieldText:
+			pattern := arg(likePattern(term.Value))
+			condition = fmt.Sprintf("(t.title ILIKE %s OR COALESCE(t.description, '') ILIKE %s)",
+				pattern, pattern)
+		case filter.FieldLabel:
+			condition = fmt.Sprintf(
+				`EXISTS (SELECT 1 FROM %s AS ftl
+					INNER JOIN %s AS flb ON ftl.label_id = flb.id
+				WHERE ftl.task_id = t.id AND %s)`,
+				taskLabelsTable, labelsTable, textCondition("flb.name", term, arg))
+		case filter.FieldList, filter.FieldTitle, filter.FieldDescription:
+			condition = textCondition(taskFilterColumns[term.Field], term, arg)
+		case filter.FieldCustom:
+			condition = customCondition(term, now, arg)
+		default:
+			condition = timeCondition(taskFilterColumns[term.Field], term, now, arg)
+		}
+
+		if term.Negated {
+			condition = "NOT " + condition
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// compileTaskSort returns the ORDER BY expression of the tasks within their
+// lists, numbering placeholders from argId on.
+func compileTaskSort(sort *filter.Sort, argId int) (string, []interface{}) {
+	if sort == nil || sort.Field == filter.FieldPosition {
+		return "t.position", nil
+	}
+
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+	column := taskSortColumns[sort.Field]
+	var args []interface{}
+	if sort.Custom != nil {
+		args = append(args, sort.Custom.Id)
+		column = fmt.Sprintf("(SELECT %s FROM %s AS fv WHERE fv.task_id = t.id AND fv.field_id = $%d)",
+			customSortColumn(sort.Custom.Type), fieldValuesTable, argId)
+	}
+	return fmt.Sprintf("%s %s NULLS LAST, t.position", column, direction), args
+}
+
+// customSortColumn orders select fields by their first option and users by
+// nickname.
+func customSortColumn(fieldType string) string {
+	switch fieldType {
+	case models.FieldNumber:
+		return "fv.number_value"
+	case models.FieldDate:
+		return "fv.date_value"
+	case models.FieldCheckbox:
+		return "fv.checked"
+	case models.FieldUser:
+		return fmt.Sprintf("(SELECT lower(nickname) FROM %s WHERE id = fv.user_id)", usersTable)
+	case models.FieldSelect, models.FieldMultiSelect:
+		return fmt.Sprintf("(SELECT MIN(position) FROM %s WHERE id = ANY(fv.option_ids))",
+			fieldOptionsTable)
+	}
+	return "lower(fv.text_value)"
+}
+
+// customCondition matches the value of the custom field of the term, which
+// the caller resolved. Select fields match option names and user fields
+// nicknames; an unset checkbox counts as unchecked.
+func customCondition(term *filter.Term, now time.Time, arg func(interface{}) string) string {
+	field := arg(term.Custom.Id)
+	exists := func(join, condition string) string {
+		return fmt.Sprintf(
+			`EXISTS (SELECT 1 FROM %s AS fv %s
+			WHERE fv.task_id = t.id AND fv.field_id = %s AND %s)`,
+			fieldValuesTable, join, field, condition)
+	}
+
+	switch term.Custom.Type {
+	case models.FieldNumber:
+		return exists("", fmt.Sprintf("(fv.number_value %s %s)", numberOperators[term.Op], arg(term.Number)))
+	case models.FieldDate:
+		return exists("", timeCondition("fv.date_value", term, now, arg))
+	case models.FieldCheckbox:
+		return fmt.Sprintf(
+			`(COALESCE((SELECT fv.checked FROM %s AS fv
+				WHERE fv.task_id = t.id AND fv.field_id = %s), false) = %s)`,
+			fieldValuesTable, field, arg(term.Value == "true"))
+	case models.FieldUser:
+		join := fmt.Sprintf("INNER JOIN %s AS fu ON fu.id = fv.user_id", usersTable)
+		return exists(join, textCondition("fu.nickname", term, arg))
+	case models.FieldSelect, models.FieldMultiSelect:
+		join := fmt.Sprintf("INNER JOIN %s AS fo ON fo.id = ANY(fv.option_ids)", fieldOptionsTable)
+		return exists(join, textCondition("fo.name", term, arg))
+	}
+	return exists("", textCondition("fv.text_value", term, arg))
+}
+
+func textCondition(column string, term *filter.Term, arg func(interface{}) string) string {
+	if term.Op == filter.OpContains {
+		return fmt.Sprintf("(%s ILIKE %s)", column, arg(likePattern(term.Value)))
+	}
+	return fmt.Sprintf("(lower(%s) = lower(%s))", column, arg(term.Value))
+}
+
+// timeCondition compares the unix time in the column with the term. A
+// duration is an age, so updated:<7d means updated less than 7 days ago.
+func timeCondition(column string, term *filter.Term, now time.Time, arg func(interface{}) string) string {
+	if term.IsRelative() {
+		since := now.Add(-term.Duration).Unix()
+		ops := map[string]string{
+			filter.OpEqual:          ">=",
+			filter.OpLess:           ">",
+			filter.OpLessOrEqual:    ">=",
+			filter.OpGreater:        "<",
+			filter.OpGreaterOrEqual: "<=",
+		}
+		return fmt.Sprintf("(%s %s %s)", column, ops[term.Op], arg(since))
+	}
+
+	dayStart := term.Date.Unix()
+	dayEnd := term.Date.Add(24 * time.Hour).Unix()
+	switch term.Op {
+	case filter.OpLess:
+		return fmt.Sprintf("(%s < %s)", column, arg(dayStart))
+	case filter.OpLessOrEqual:
+		return fmt.Sprintf("(%s < %s)", column, arg(dayEnd))
+	case filter.OpGreater:
+		return fmt.Sprintf("(%s >= %s)", column, arg(dayEnd))
+	case filter.OpGreaterOrEqual:
+		return fmt.Sprintf("(%s >= %s)", column, arg(dayStart))
+	}
+	return fmt.Sprintf("(%s >= %s AND %s < %s)", column, arg(dayStart), column, arg(dayEnd))
+}
+
+func likePattern(value string) string {
+	return "%" + likeEscaper.Replace(value) + "%"
+}
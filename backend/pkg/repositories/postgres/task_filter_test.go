@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/filter"
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileTaskFilter(t *testing.T) {
+	now := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	query, err := filter.Parse(`-label:wontfix list:~prog updated:<7d created:2021-03-04 50%`)
+	assert.NoError(t, err)
+
+	conditions, args := compileTaskFilter(query, now, 2)
+
+	assert.Equal(t, "NOT EXISTS (SELECT 1 FROM task_labels AS ftl\n"+
+		"\t\t\t\t\tINNER JOIN labels AS flb ON ftl.label_id = flb.id\n"+
+		"\t\t\t\tWHERE ftl.task_id = t.id AND (lower(flb.name) = lower($2)))"+
+		" AND (tl.title ILIKE $3)"+
+		" AND (d.updated > $4)"+
+		" AND (d.created >= $5 AND d.created < $6)"+
+		" AND (t.title ILIKE $7 OR COALESCE(t.description, '') ILIKE $7)", conditions)
+	assert.Equal(t, []interface{}{
+		"wontfix",
+		"%prog%",
+		now.Add(-7 * 24 * time.Hour).Unix(),
+		time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC).Unix(),
+		time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC).Unix(),
+		`%50\%%`,
+	}, args)
+}
+
+func TestCompileTaskFilter_Custom(t *testing.T) {
+	query, err := filter.Parse(`cf.severity:high cf.estimate:>=2.5 -cf.approved:true`)
+	assert.NoError(t, err)
+	query.Terms[0].Custom.Id, query.Terms[0].Custom.Type = 4, models.FieldSelect
+	query.Terms[1].Custom.Id, query.Terms[1].Custom.Type = 5, models.FieldNumber
+	query.Terms[1].Number = 2.5
+	query.Terms[2].Custom.Id, query.Terms[2].Custom.Type = 6, models.FieldCheckbox
+
+	conditions, args := compileTaskFilter(query, time.Now(), 2)
+
+	assert.Equal(t, "EXISTS (SELECT 1 FROM task_field_values AS fv "+
+		"INNER JOIN field_options AS fo ON fo.id = ANY(fv.option_ids)\n"+
+		"\t\t\tWHERE fv.task_id = t.id AND fv.field_id = $2 AND (lower(fo.name) = lower($3)))"+
+		" AND EXISTS (SELECT 1 FROM task_field_values AS fv \n"+
+		"\t\t\tWHERE fv.task_id = t.id AND fv.field_id = $4 AND (fv.number_value >= $5))"+
+		" AND NOT (COALESCE((SELECT fv.checked FROM task_field_values AS fv\n"+
+		"\t\t\t\tWHERE fv.task_id = t.id AND fv.field_id = $6), false) = $7)", conditions)
+	assert.Equal(t, []interface{}{4, "high", 5, 2.5, 6, true}, args)
+}
+
+func TestCompileTaskSort(t *testing.T) {
+	order, args := compileTaskSort(nil, 3)
+	assert.Equal(t, "t.position", order)
+	assert.Empty(t, args)
+
+	order, args = compileTaskSort(&filter.Sort{Field: filter.FieldUpdated, Desc: true}, 3)
+	assert.Equal(t, "d.updated DESC NULLS LAST, t.position", order)
+	assert.Empty(t, args)
+
+	order, args = compileTaskSort(&filter.Sort{Field: filter.FieldCustom,
+		Custom: &filter.Custom{Name: "due", Id: 7, Type: models.FieldDate}}, 3)
+	assert.Equal(t, "(SELECT fv.date_value FROM task_field_values AS fv "+
+		"WHERE fv.task_id = t.id AND fv.field_id = $3) ASC NULLS LAST, t.position", order)
+	assert.Equal(t, []interface{}{7}, args)
+}
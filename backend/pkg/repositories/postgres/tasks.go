@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/architectv/networking-course-project/backend/pkg/filter"
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type TaskPg struct {
@@ -22,14 +25,16 @@ func NewTaskPg(db *sqlx.DB) *TaskPg {
 func (r *TaskPg) GetAll(listId int) ([]*models.Task, error) {
 	var tasks []*models.Task
 	query := fmt.Sprintf(
-		`SELECT t.id, t.list_id, t.title, t.description, d.created, d.updated, d.accessed, t.position
+		`SELECT t.id, t.list_id, t.title, t.description, d.created, d.updated, d.accessed,
+			t.position, t.archived, %s,
+			COALESCE(t.assignee_id, 0), COALESCE(t.sprint_id, 0), t.points, COALESCE(t.due, 0)
 		FROM %s AS t
 			INNER JOIN %s AS tl ON t.list_id = tl.id
 			INNER JOIN %s AS d ON t.datetimes_id = d.id
-		WHERE tl.id = $1
+		WHERE tl.id = $1 AND t.deleted_at IS NULL
 		ORDER BY t.position`,
 
-		tasksTable, taskListsTable, datetimesTable)
+		blockedCondition("t.id"), tasksTable, taskListsTable, datetimesTable)
 
 	rows, err := r.db.Query(query, listId)
 	if err != nil {
@@ -42,7 +47,8 @@ func (r *TaskPg) GetAll(listId int) ([]*models.Task, error) {
 		datetimes := &models.Datetimes{}
 
 		err := rows.Scan(&task.Id, &task.ListId, &task.Title, &task.Description, &datetimes.Created,
-			&datetimes.Updated, &datetimes.Accessed, &task.Position)
+			&datetimes.Updated, &datetimes.Accessed, &task.Position, &task.Archived, &task.IsBlocked, &task.AssigneeId,
+			&task.SprintId, &task.Points, &task.Due)
 
 		if err != nil {
 			return nil, err
@@ -64,15 +70,18 @@ func (r *TaskPg) GetById(taskId int) (*models.Task, error) {
 	datetimes := &models.Datetimes{}
 
 	query := fmt.Sprintf(
-		`SELECT t.id, t.list_id, t.title, t.description, d.created, d.updated, d.accessed, t.position
+		`SELECT t.id, t.list_id, t.title, t.description, d.created, d.updated, d.accessed,
+			t.position, t.archived, %s,
+			COALESCE(t.assignee_id, 0), COALESCE(t.sprint_id, 0), t.points, COALESCE(t.due, 0)
 		FROM %s AS t
 		INNER JOIN %s AS d ON t.datetimes_id = d.id
-		WHERE t.id = $1`,
-		tasksTable, datetimesTable)
+		WHERE t.id = $1 AND t.deleted_at IS NULL`,
+		blockedCondition("t.id"), tasksTable, datetimesTable)
 
 	row := r.db.QueryRow(query, taskId)
 	err := row.Scan(&task.Id, &task.ListId, &task.Title, &task.Description, &datetimes.Created,
-		&datetimes.Updated, &datetimes.Accessed, &task.Position)
+		&datetimes.Updated, &datetimes.Accessed, &task.Position, &task.Archived, &task.IsBlocked, &task.AssigneeId,
+		&task.SprintId, &task.Points, &task.Due)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +91,12 @@ func (r *TaskPg) GetById(taskId int) (*models.Task, error) {
 }
 
 func (r *TaskPg) Create(task *models.Task) (int, error) {
+	return r.CreateWithLabels(task, nil)
+}
+
+// CreateWithLabels creates the task with the labels in one transaction, so
+// that a failed label leaves no task behind.
+func (r *TaskPg) CreateWithLabels(task *models.Task, labelIds []int) (int, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return 0, err
@@ -103,14 +118,23 @@ func (r *TaskPg) Create(task *models.Task) (int, error) {
 	position++
 
 	query := fmt.Sprintf(
-		`INSERT INTO %s (list_id, title, description, datetimes_id, position)
-		VALUES ($1, $2, $3, $4, $5) RETURNING id`, tasksTable)
+		`INSERT INTO %s (list_id, title, description, datetimes_id, position, due)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0)) RETURNING id`, tasksTable)
 
-	row := tx.QueryRow(query, task.ListId, task.Title, task.Description, datetimesId, position)
+	row := tx.QueryRow(query, task.ListId, task.Title, task.Description, datetimesId, position, task.Due)
 	if err := row.Scan(&id); err != nil {
 		tx.Rollback()
 		return 0, err
 	}
+
+	query = fmt.Sprintf(
+		`INSERT INTO %s (task_id, label_id) VALUES ($1, $2)`, taskLabelsTable)
+	for _, labelId := range labelIds {
+		if _, err := tx.Exec(query, id, labelId); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
 	tx.Commit()
 
 	return id, nil
@@ -138,6 +162,37 @@ func (r *TaskPg) Update(taskId int, input *models.UpdateTask) error {
 		argId++
 	}
 
+	if input.AssigneeId != nil {
+		setValues = append(setValues, fmt.Sprintf("assignee_id=NULLIF($%d, 0)", argId))
+		args = append(args, *input.AssigneeId)
+		argId++
+	}
+
+	if input.SprintId != nil {
+		if *input.SprintId != 0 {
+			if err := checkTaskSprint(tx, taskId, *input.SprintId); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		setValues = append(setValues, fmt.Sprintf("sprint_id=NULLIF($%d, 0)", argId))
+		args = append(args, *input.SprintId)
+		argId++
+	}
+
+	if input.Points != nil {
+		setValues = append(setValues, fmt.Sprintf("points=$%d", argId))
+		args = append(args, *input.Points)
+		argId++
+	}
+
+	// A new due date is reminded of again.
+	if input.Due != nil {
+		setValues = append(setValues, fmt.Sprintf("due=NULLIF($%d, 0), due_reminded=false", argId))
+		args = append(args, *input.Due)
+		argId++
+	}
+
 	if input.Position != nil {
 		newPos := *input.Position
 
@@ -160,6 +215,12 @@ func (r *TaskPg) Update(taskId int, input *models.UpdateTask) error {
 				return err
 			}
 
+			err = checkDoneList(tx, taskId, newListId)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+
 			err = checkTaskOutOfBounds(tx, newPos, newListId, true)
 			if err != nil {
 				tx.Rollback()
@@ -209,7 +270,7 @@ func (r *TaskPg) Update(taskId int, input *models.UpdateTask) error {
 
 				query := fmt.Sprintf(
 					`UPDATE %s SET position = position %s 1
-					WHERE list_id = $1 AND position >= $2 AND position <= $3`,
+					WHERE list_id = $1 AND position >= $2 AND position <= $3 AND deleted_at IS NULL`,
 					tasksTable, operation)
 				_, err := tx.Exec(query, listId, start, end)
 				if err != nil {
@@ -233,6 +294,12 @@ func (r *TaskPg) Update(taskId int, input *models.UpdateTask) error {
 		return err
 	}
 
+	err = recordTaskHistory(tx, time.Now().Unix(), "t.id = $2", taskId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	tx.Commit()
 	return err
 }
@@ -243,29 +310,25 @@ func (r *TaskPg) Delete(taskId int) error {
 		return err
 	}
 
+	// The task keeps its position so that a restore can put it back.
 	var listId, position int
-	query := fmt.Sprintf(`SELECT list_id, position FROM %s WHERE id = $1`, tasksTable)
-	row := tx.QueryRow(query, taskId)
+	query := fmt.Sprintf(
+		`UPDATE %s SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL
+		RETURNING list_id, position`, tasksTable)
+	row := tx.QueryRow(query, taskId, time.Now().Unix())
 	err = row.Scan(&listId, &position)
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	err = r.updateTaskPosition(tx, listId, position, "-")
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	datetimesId, err := r.getTaskForeignKey(taskId)
+	err = r.updateTaskPosition(tx, listId, position+1, "-")
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	query = fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, datetimesTable)
-	_, err = tx.Exec(query, datetimesId)
+	err = recordTaskHistory(tx, time.Now().Unix(), "t.id = $2", taskId)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -275,21 +338,10 @@ func (r *TaskPg) Delete(taskId int) error {
 	return err
 }
 
-func (r *TaskPg) getTaskForeignKey(boardId int) (int, error) {
-	var datetimesId int
-	query := fmt.Sprintf(
-		`SELECT p.datetimes_id
-		FROM %s AS p WHERE p.id = $1`, tasksTable)
-
-	row := r.db.QueryRow(query, boardId)
-	err := row.Scan(&datetimesId)
-	return datetimesId, err
-}
-
 func (r *TaskPg) updateTaskPosition(tx *sql.Tx, listId, start int, operation string) error {
 	query := fmt.Sprintf(
 		`UPDATE %s SET position = position %s 1
-		WHERE list_id = $1 AND position >= $2`,
+		WHERE list_id = $1 AND position >= $2 AND deleted_at IS NULL`,
 		tasksTable, operation)
 	_, err := tx.Exec(query, listId, start)
 	return err
@@ -301,7 +353,7 @@ func getTaskMaxPosition(tx *sql.Tx, listId int) (int, error) {
 		`SELECT MAX(t.position)
 		FROM %s AS t
 			INNER JOIN %s AS tl ON tl.id = t.list_id
-		WHERE tl.id = $1;`, tasksTable, taskListsTable)
+		WHERE tl.id = $1 AND t.deleted_at IS NULL;`, tasksTable, taskListsTable)
 
 	row := tx.QueryRow(query, listId)
 	err := row.Scan(&position)
@@ -328,7 +380,7 @@ func checkTaskOutOfBounds(tx *sql.Tx, newPos, newListId int, is_insert bool) err
 func checkListIsExists(tx *sql.Tx, listId int) error {
 	var id int
 	query := fmt.Sprintf(
-		`SELECT id FROM %s WHERE id = $1`, taskListsTable)
+		`SELECT id FROM %s WHERE id = $1 AND deleted_at IS NULL`, taskListsTable)
 	row := tx.QueryRow(query, listId)
 	if err := row.Scan(&id); err != nil {
 		tx.Rollback()
@@ -340,3 +392,102 @@ func checkListIsExists(tx *sql.Tx, listId int) error {
 	}
 	return nil
 }
+
+// GetAllInBoard returns the live tasks of the board ordered by list and
+// position.
+func (r *TaskPg) GetAllInBoard(boardId int) ([]*models.TaskRecord, error) {
+	records := make([]*models.TaskRecord, 0)
+	query := fmt.Sprintf(
+		`SELECT tl.title, t.position, t.title, COALESCE(t.description, ''),
+			d.created, d.updated, d.accessed,
+			COALESCE(array_agg(lb.name ORDER BY lb.name) FILTER (WHERE lb.id IS NOT NULL), '{}')
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			INNER JOIN %s AS d ON t.datetimes_id = d.id
+			LEFT JOIN %s AS tlb ON tlb.task_id = t.id
+			LEFT JOIN %s AS lb ON tlb.label_id = lb.id
+		WHERE tl.board_id = $1 AND tl.deleted_at IS NULL AND t.deleted_at IS NULL
+		GROUP BY t.id, tl.id, d.id
+		ORDER BY tl.position, t.position`,
+		tasksTable, taskListsTable, datetimesTable, taskLabelsTable, labelsTable)
+
+	rows, err := r.db.Query(query, boardId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var labels pq.StringArray
+		record := &models.TaskRecord{Datetimes: &models.Datetimes{}}
+		err := rows.Scan(&record.ListTitle, &record.Position, &record.Title,
+			&record.Description, &record.Datetimes.Created, &record.Datetimes.Updated,
+			&record.Datetimes.Accessed, &labels)
+		if err != nil {
+			return nil, err
+		}
+		record.Labels = labels
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetFiltered returns the live tasks of the board that match the query,
+// only from the given list unless listId is 0.
+func (r *TaskPg) GetFiltered(boardId, listId int, query *filter.Query) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0)
+	conditions := []string{"tl.board_id = $1", "tl.deleted_at IS NULL", "t.deleted_at IS NULL"}
+	args := []interface{}{boardId}
+	if listId != 0 {
+		conditions = append(conditions, "tl.id = $2")
+		args = append(args, listId)
+	}
+
+	filterConditions, filterArgs := compileTaskFilter(query, time.Now(), len(args)+1)
+	if filterConditions != "" {
+		conditions = append(conditions, filterConditions)
+		args = append(args, filterArgs...)
+	}
+
+	order, sortArgs := compileTaskSort(query.Sort, len(args)+1)
+	args = append(args, sortArgs...)
+
+	sqlQuery := fmt.Sprintf(
+		`SELECT t.id, t.list_id, t.title, COALESCE(t.description, ''),
+			d.created, d.updated, d.accessed, t.position, t.archived, %s,
+			COALESCE(t.assignee_id, 0), COALESCE(t.sprint_id, 0), t.points, COALESCE(t.due, 0)
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			INNER JOIN %s AS d ON t.datetimes_id = d.id
+		WHERE %s
+		ORDER BY tl.position, %s`,
+		blockedCondition("t.id"), tasksTable, taskListsTable, datetimesTable,
+		strings.Join(conditions, " AND "), order)
+
+	rows, err := r.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task := &models.Task{Datetimes: &models.Datetimes{}}
+		err := rows.Scan(&task.Id, &task.ListId, &task.Title, &task.Description,
+			&task.Datetimes.Created, &task.Datetimes.Updated, &task.Datetimes.Accessed,
+			&task.Position, &task.Archived, &task.IsBlocked, &task.AssigneeId,
+			&task.SprintId, &task.Points, &task.Due)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
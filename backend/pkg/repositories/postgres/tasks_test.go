@@ -45,7 +45,7 @@ func TestTaskpermsPg_GetById(t *testing.T) {
 			},
 			mock: func(args args) {
 				rows := sqlmock.NewRows([]string{"t.id", "t.list_id", "t.title", "t.description", "d.created",
-					"d.updated", "d.accessed", "t.position"}).AddRow(1, 1, "title", "description", 1, 1, 1, 1)
+					"d.updated", "d.accessed", "t.position", "t.archived", "is_blocked", "assignee_id", "sprint_id", "points", "due"}).AddRow(1, 1, "title", "description", 1, 1, 1, 1, false, false, 0, 0, 0, 0)
 				mock.ExpectQuery("SELECT (.+) FROM tasks").WithArgs(args.listId).WillReturnRows(rows)
 			},
 		},
@@ -57,7 +57,7 @@ func TestTaskpermsPg_GetById(t *testing.T) {
 			want: nil,
 			mock: func(args args) {
 				rows := sqlmock.NewRows([]string{"t.id", "t.list_id", "t.title", "t.description", "d.created",
-					"d.updated", "d.accessed", "t.position"}).RowError(0, errors.New("Some error"))
+					"d.updated", "d.accessed", "t.position", "t.archived", "is_blocked", "assignee_id", "sprint_id", "points", "due"}).RowError(0, errors.New("Some error"))
 				mock.ExpectQuery("SELECT (.+) FROM tasks").WithArgs(args.listId).WillReturnRows(rows)
 			},
 			wantErr: true,
@@ -78,3 +78,136 @@ func TestTaskpermsPg_GetById(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskPg_GetUsers(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTaskPg(db)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		taskId  int
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:   "Ok",
+			taskId: 1,
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"assignee_id"}).AddRow(2).AddRow(3)
+				mock.ExpectQuery("SELECT t.assignee_id FROM tasks (.+) UNION (.+) FROM task_field_values").
+					WithArgs(1).WillReturnRows(rows)
+			},
+			want: []int{2, 3},
+		},
+		{
+			name:   "Error",
+			taskId: 1,
+			mock: func() {
+				mock.ExpectQuery("SELECT t.assignee_id FROM tasks").
+					WithArgs(1).WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.GetUsers(tt.taskId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTaskPg_Update_Due(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTaskPg(db)
+	due := int64(1000)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE tasks SET due=NULLIF\\(\\$1, 0\\), due_reminded=false where id=\\$2").
+		WithArgs(due, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO task_history").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.Update(1, &models.UpdateTask{Due: &due}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskPg_CreateWithLabels(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTaskPg(db)
+	task := &models.Task{ListId: 1, Title: "title", Datetimes: &models.Datetimes{Created: 1, Updated: 1, Accessed: 1}}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+			want: 3,
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO datetimes").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("SELECT (.+) FROM tasks").WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(0))
+				mock.ExpectQuery("INSERT INTO tasks").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectExec("INSERT INTO task_labels").WithArgs(3, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO task_labels").WithArgs(3, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Label Error",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO datetimes").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("SELECT (.+) FROM tasks").WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(0))
+				mock.ExpectQuery("INSERT INTO tasks").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectExec("INSERT INTO task_labels").WithArgs(3, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO task_labels").WithArgs(3, 5).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := r.CreateWithLabels(task, []int{4, 5})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type TemplatePg struct {
+	db *sqlx.DB
+}
+
+func NewTemplatePg(db *sqlx.DB) *TemplatePg {
+	return &TemplatePg{db: db}
+}
+
+func (r *TemplatePg) Create(template *models.BoardTemplate) (int, error) {
+	content, err := json.Marshal(template.Content)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	query := fmt.Sprintf(
+		`INSERT INTO %s (project_id, owner_id, title, content, created)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`, templatesTable)
+	row := r.db.QueryRow(query, template.ProjectId, template.OwnerId, template.Title,
+		content, template.Created)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *TemplatePg) GetAll(projectId int) ([]*models.BoardTemplate, error) {
+	templates := make([]*models.BoardTemplate, 0)
+	query := fmt.Sprintf(
+		`SELECT id, project_id, owner_id, title, created
+		FROM %s WHERE project_id = $1 ORDER BY id`, templatesTable)
+
+	rows, err := r.db.Query(query, projectId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		template := &models.BoardTemplate{}
+		err := rows.Scan(&template.Id, &template.ProjectId, &template.OwnerId,
+			&template.Title, &template.Created)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *TemplatePg) GetById(templateId int) (*models.BoardTemplate, error) {
+	template := &models.BoardTemplate{}
+	var content []byte
+	query := fmt.Sprintf(
+		`SELECT id, project_id, owner_id, title, created, content
+		FROM %s WHERE id = $1`, templatesTable)
+
+	row := r.db.QueryRow(query, templateId)
+	err := row.Scan(&template.Id, &template.ProjectId, &template.OwnerId,
+		&template.Title, &template.Created, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &template.Content); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (r *TemplatePg) Delete(templateId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, templatesTable)
+	_, err := r.db.Exec(query, templateId)
+	return err
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type TimePg struct {
+	db *sqlx.DB
+}
+
+func NewTimePg(db *sqlx.DB) *TimePg {
+	return &TimePg{db: db}
+}
+
+// GetEstimate returns a zero estimate for a task that has none.
+func (r *TimePg) GetEstimate(taskId int) (*models.TimeEstimate, error) {
+	estimate := &models.TimeEstimate{}
+	query := fmt.Sprintf(`SELECT original, remaining FROM %s WHERE task_id = $1`, estimatesTable)
+	err := r.db.Get(estimate, query, taskId)
+	if err == sql.ErrNoRows {
+		return estimate, nil
+	}
+	return estimate, err
+}
+
+// UpdateEstimate sets the remaining time to the original one when a task
+// gets its first estimate without it.
+func (r *TimePg) UpdateEstimate(taskId int, input *models.UpdateTimeEstimate) error {
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (task_id, original, remaining)
+		VALUES ($1, COALESCE($2, 0), COALESCE($3, $2, 0))
+		ON CONFLICT (task_id) DO UPDATE SET
+			original = COALESCE($2, %[1]s.original),
+			remaining = COALESCE($3, %[1]s.remaining)`,
+		estimatesTable)
+	_, err := r.db.Exec(query, taskId, input.Original, input.Remaining)
+	return err
+}
+
+func (r *TimePg) GetLogs(taskId int) ([]*models.TimeLog, error) {
+	logs := make([]*models.TimeLog, 0)
+	query := fmt.Sprintf(
+		`SELECT l.id, l.task_id, l.user_id, u.nickname, l.duration, l.date, l.note
+		FROM %s AS l INNER JOIN %s AS u ON l.user_id = u.id
+		WHERE l.task_id = $1 ORDER BY l.date, l.id`,
+		timeLogsTable, usersTable)
+	if err := r.db.Select(&logs, query, taskId); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+func (r *TimePg) GetLog(logId int) (*models.TimeLog, error) {
+	log := &models.TimeLog{}
+	query := fmt.Sprintf(
+		`SELECT l.id, l.task_id, l.user_id, u.nickname, l.duration, l.date, l.note
+		FROM %s AS l INNER JOIN %s AS u ON l.user_id = u.id
+		WHERE l.id = $1`,
+		timeLogsTable, usersTable)
+	if err := r.db.Get(log, query, logId); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// CreateLog takes the logged time off the remaining estimate.
+func (r *TimePg) CreateLog(log *models.TimeLog) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := createTimeLog(tx, log)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// DeleteLog gives the time the log took off the remaining estimate back,
+// which is less than its duration if the estimate ran out.
+func (r *TimePg) DeleteLog(logId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var taskId int
+	var deducted int64
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 RETURNING task_id, deducted`, timeLogsTable)
+	if err := tx.QueryRow(query, logId).Scan(&taskId, &deducted); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET remaining = remaining + $2 WHERE task_id = $1`, estimatesTable)
+	if _, err := tx.Exec(query, taskId, deducted); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *TimePg) GetTimer(userId int) (*models.Timer, error) {
+	timer := &models.Timer{}
+	query := fmt.Sprintf(`SELECT task_id, started FROM %s WHERE user_id = $1`, timersTable)
+	if err := r.db.Get(timer, query, userId); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// StartTimer stops the running timer of the user first and returns the
+// time it logged, if there was one.
+func (r *TimePg) StartTimer(userId, taskId int, now int64) (*models.TimeLog, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	stopped, err := stopTimer(tx, userId, now, "")
+	if err == sql.ErrNoRows {
+		stopped, err = nil, nil
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (user_id, task_id, started) VALUES ($1, $2, $3)`, timersTable)
+	if _, err := tx.Exec(query, userId, taskId, now); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return stopped, tx.Commit()
+}
+
+// StopTimer logs the time since the timer started.
+func (r *TimePg) StopTimer(userId int, now int64, note string) (*models.TimeLog, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := stopTimer(tx, userId, now, note)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return log, tx.Commit()
+}
+
+// GetReport returns the logs on the boards done in the date range, of one
+// user if the query has one.
+func (r *TimePg) GetReport(query *models.TimeReportQuery, boardIds []int) ([]*models.TimeReportEntry, error) {
+	entries := make([]*models.TimeReportEntry, 0)
+	ids := make(pq.Int64Array, len(boardIds))
+	for i, id := range boardIds {
+		ids[i] = int64(id)
+	}
+	sqlQuery := fmt.Sprintf(
+		`SELECT l.id, l.date, l.user_id, u.nickname, p.id AS project_id, p.title AS project,
+			b.id AS board_id, b.title AS board, t.id AS task_id, t.title AS task, l.duration, l.note
+		FROM %s AS l
+			INNER JOIN %s AS t ON l.task_id = t.id
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			INNER JOIN %s AS b ON tl.board_id = b.id
+			INNER JOIN %s AS p ON b.project_id = p.id
+			INNER JOIN %s AS u ON l.user_id = u.id
+		WHERE b.id = ANY($1) AND l.date BETWEEN $2 AND $3 AND ($4 = 0 OR l.user_id = $4)
+		ORDER BY l.date, l.id`,
+		timeLogsTable, tasksTable, taskListsTable, boardsTable, projectsTable, usersTable)
+	err := r.db.Select(&entries, sqlQuery, ids, query.From, query.To, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// createTimeLog keeps how much the log took off the remaining estimate, so
+// that deleting it gives back no more than that.
+func createTimeLog(tx *sql.Tx, log *models.TimeLog) (int, error) {
+	var deducted int64
+	query := fmt.Sprintf(
+		`WITH old AS (SELECT remaining FROM %[1]s WHERE task_id = $1 FOR UPDATE)
+		UPDATE %[1]s AS e SET remaining = GREATEST(e.remaining - $2, 0)
+		FROM old WHERE e.task_id = $1
+		RETURNING old.remaining - e.remaining`, estimatesTable)
+	err := tx.QueryRow(query, log.TaskId, log.Duration).Scan(&deducted)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	var id int
+	query = fmt.Sprintf(
+		`INSERT INTO %s (task_id, user_id, duration, deducted, date, note)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, timeLogsTable)
+	row := tx.QueryRow(query, log.TaskId, log.UserId, log.Duration, deducted, log.Date, log.Note)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func stopTimer(tx *sql.Tx, userId int, now int64, note string) (*models.TimeLog, error) {
+	log := &models.TimeLog{UserId: userId, Note: note}
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE user_id = $1 RETURNING task_id, started`, timersTable)
+	if err := tx.QueryRow(query, userId).Scan(&log.TaskId, &log.Date); err != nil {
+		return nil, err
+	}
+
+	if log.Duration = now - log.Date; log.Duration < 0 {
+		log.Duration = 0
+	}
+	id, err := createTimeLog(tx, log)
+	if err != nil {
+		return nil, err
+	}
+	log.Id = id
+	return log, nil
+}
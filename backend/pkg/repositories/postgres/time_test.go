@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestTimePg_DeleteLog(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTimePg(db)
+
+	type args struct {
+		logId int
+	}
+	type mockBehavior func(args args)
+
+	tests := []struct {
+		name    string
+		mock    mockBehavior
+		input   args
+		wantErr bool
+	}{
+		{
+			name:  "Clamped",
+			input: args{logId: 1},
+			mock: func(args args) {
+				mock.ExpectBegin()
+
+				// A 3h log that took the last hour off gives back the hour.
+				mock.ExpectQuery("DELETE FROM time_logs (.+) RETURNING task_id, deducted").WithArgs(args.logId).
+					WillReturnRows(sqlmock.NewRows([]string{"task_id", "deducted"}).AddRow(2, 3600))
+				mock.ExpectExec("UPDATE time_estimates SET remaining = remaining \\+ \\$2").
+					WithArgs(2, 3600).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Not found",
+			input: args{logId: 1},
+			mock: func(args args) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("DELETE FROM time_logs").WithArgs(args.logId).
+					WillReturnError(sql.ErrNoRows)
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(tt.input)
+
+			err := r.DeleteLog(tt.input.logId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimePg_CreateLog(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTimePg(db)
+
+	type args struct {
+		log *models.TimeLog
+	}
+	type mockBehavior func(args args, id int)
+
+	tests := []struct {
+		name    string
+		mock    mockBehavior
+		input   args
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "Clamped",
+			input: args{log: &models.TimeLog{TaskId: 2, UserId: 1, Duration: 3 * 3600, Date: 100}},
+			want:  1,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				// An hour was left, so only an hour is taken off.
+				mock.ExpectQuery("UPDATE time_estimates").WithArgs(2, int64(3*3600)).
+					WillReturnRows(sqlmock.NewRows([]string{"deducted"}).AddRow(3600))
+				mock.ExpectQuery("INSERT INTO time_logs").
+					WithArgs(2, 1, int64(3*3600), int64(3600), int64(100), "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "No estimate",
+			input: args{log: &models.TimeLog{TaskId: 2, UserId: 1, Duration: 3600, Date: 100}},
+			want:  1,
+			mock: func(args args, id int) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("UPDATE time_estimates").WithArgs(2, int64(3600)).
+					WillReturnRows(sqlmock.NewRows([]string{"deducted"}))
+				mock.ExpectQuery("INSERT INTO time_logs").
+					WithArgs(2, 1, int64(3600), int64(0), int64(100), "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(tt.input, tt.want)
+
+			got, err := r.CreateLog(tt.input.log)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
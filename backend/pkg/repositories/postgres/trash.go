@@ -0,0 +1,271 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type TrashPg struct {
+	db *sqlx.DB
+}
+
+func NewTrashPg(db *sqlx.DB) *TrashPg {
+	return &TrashPg{db: db}
+}
+
+var trashQueries = map[string]string{
+	models.TrashProject: fmt.Sprintf(
+		`SELECT 'project' AS type, p.id, p.id AS project_id, 0 AS board_id, 0 AS list_id,
+			p.owner_id, p.title, 0 AS position, p.deleted_at, false AS parent_deleted
+		FROM %s AS p
+		WHERE p.deleted_at IS NOT NULL`, projectsTable),
+	models.TrashBoard: fmt.Sprintf(
+		`SELECT 'board' AS type, b.id, b.project_id, b.id AS board_id, 0 AS list_id,
+			b.owner_id, b.title, 0 AS position, b.deleted_at, false AS parent_deleted
+		FROM %s AS b
+		WHERE b.deleted_at IS NOT NULL`, boardsTable),
+	models.TrashList: fmt.Sprintf(
+		`SELECT 'list' AS type, tl.id, b.project_id, tl.board_id, tl.id AS list_id,
+			b.owner_id, tl.title, tl.position, tl.deleted_at,
+			b.deleted_at IS NOT NULL AS parent_deleted
+		FROM %s AS tl
+			INNER JOIN %s AS b ON tl.board_id = b.id
+		WHERE tl.deleted_at IS NOT NULL`, taskListsTable, boardsTable),
+	models.TrashTask: fmt.Sprintf(
+		`SELECT 'task' AS type, t.id, b.project_id, tl.board_id, t.list_id,
+			b.owner_id, t.title, t.position, t.deleted_at,
+			tl.deleted_at IS NOT NULL OR b.deleted_at IS NOT NULL AS parent_deleted
+		FROM %s AS t
+			INNER JOIN %s AS tl ON t.list_id = tl.id
+			INNER JOIN %s AS b ON tl.board_id = b.id
+		WHERE t.deleted_at IS NOT NULL`, tasksTable, taskListsTable, boardsTable),
+}
+
+// GetAll returns the deleted boards, lists and tasks of a live project.
+func (r *TrashPg) GetAll(projectId int) ([]*models.TrashItem, error) {
+	query := fmt.Sprintf(
+		`SELECT * FROM (%s UNION ALL %s UNION ALL %s) AS items
+		WHERE items.project_id = $1
+		ORDER BY items.deleted_at DESC, items.id`,
+		trashQueries[models.TrashBoard], trashQueries[models.TrashList],
+		trashQueries[models.TrashTask])
+	return r.selectItems(query, projectId)
+}
+
+func (r *TrashPg) GetProjects(ownerId int) ([]*models.TrashItem, error) {
+	query := fmt.Sprintf(
+		`SELECT * FROM (%s) AS items
+		WHERE items.owner_id = $1
+		ORDER BY items.deleted_at DESC, items.id`,
+		trashQueries[models.TrashProject])
+	return r.selectItems(query, ownerId)
+}
+
+func (r *TrashPg) GetById(itemType string, itemId int) (*models.TrashItem, error) {
+	itemQuery, ok := trashQueries[itemType]
+	if !ok {
+		return nil, errors.New("Unknown trash item type")
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM (%s) AS items WHERE items.id = $1`, itemQuery)
+	return scanTrashItem(r.db.QueryRow(query, itemId))
+}
+
+// Restore brings the item back; lists and tasks return to their old
+// position, or to the end if there are fewer siblings left now.
+func (r *TrashPg) Restore(itemType string, itemId int) error {
+	switch itemType {
+	case models.TrashProject:
+		return r.restore(projectsTable, itemId)
+	case models.TrashBoard:
+		return r.restore(boardsTable, itemId)
+	case models.TrashList:
+		return r.restorePositioned(taskListsTable, "board_id", "t.list_id = $2", itemId)
+	case models.TrashTask:
+		return r.restorePositioned(tasksTable, "list_id", "t.id = $2", itemId)
+	}
+	return errors.New("Unknown trash item type")
+}
+
+// Purge removes the items deleted before the given time together with
+// everything they contain. It returns the storage keys of the attachments of
+// the purged tasks, whose blobs are left to the caller.
+func (r *TrashPg) Purge(before int64) ([]string, error) {
+	expiredProjects := fmt.Sprintf(
+		`SELECT id FROM %s WHERE deleted_at < $1`, projectsTable)
+	expiredBoards := fmt.Sprintf(
+		`SELECT id FROM %s WHERE deleted_at < $1 OR project_id IN (%s)`,
+		boardsTable, expiredProjects)
+	expiredLists := fmt.Sprintf(
+		`SELECT id FROM %s WHERE deleted_at < $1 OR board_id IN (%s)`,
+		taskListsTable, expiredBoards)
+	expiredTasks := fmt.Sprintf(
+		`SELECT id FROM %s WHERE deleted_at < $1 OR list_id IN (%s)`,
+		tasksTable, expiredLists)
+
+	queries := []string{
+		fmt.Sprintf(`DELETE FROM %s AS d USING %s AS t
+			WHERE d.id = t.datetimes_id AND t.id IN (%s)`,
+			datetimesTable, tasksTable, expiredTasks),
+		fmt.Sprintf(`DELETE FROM %s WHERE id IN (%s)`, taskListsTable, expiredLists),
+	}
+	for _, table := range []string{boardUsersTable, boardDenialsTable, boardGroupsTable} {
+		queries = append(queries, fmt.Sprintf(
+			`DELETE FROM %s AS per USING %s AS m
+			WHERE per.id = m.permissions_id AND m.board_id IN (%s)`,
+			permissionsTable, table, expiredBoards))
+	}
+	queries = append(queries, purgeObjectsQuery(boardsTable, expiredBoards))
+	for _, table := range []string{projectUsersTable, projectGroupsTable, invitationsTable} {
+		queries = append(queries, fmt.Sprintf(
+			`DELETE FROM %s AS per USING %s AS m
+			WHERE per.id = m.permissions_id AND m.project_id IN (%s)`,
+			permissionsTable, table, expiredProjects))
+	}
+	queries = append(queries, purgeObjectsQuery(projectsTable, expiredProjects))
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE task_id IN (%s) RETURNING storage_key`,
+		attachmentsTable, expiredTasks)
+	rows, err := tx.Query(query, before)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query, before); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *TrashPg) restore(table string, itemId int) error {
+	query := fmt.Sprintf(
+		`UPDATE %s SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`, table)
+	_, err := r.db.Exec(query, itemId)
+	return err
+}
+
+// restorePositioned records the history of the restored tasks, picked by
+// historyCondition, as they count in their sprint again.
+func (r *TrashPg) restorePositioned(table, parentColumn, historyCondition string, itemId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var parentId, position, siblings int
+	query := fmt.Sprintf(
+		`SELECT x.%[2]s, x.position,
+			(SELECT COUNT(*) FROM %[1]s AS s WHERE s.%[2]s = x.%[2]s AND s.deleted_at IS NULL)
+		FROM %[1]s AS x WHERE x.id = $1 AND x.deleted_at IS NOT NULL`,
+		table, parentColumn)
+	row := tx.QueryRow(query, itemId)
+	if err := row.Scan(&parentId, &position, &siblings); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if position > siblings {
+		position = siblings
+	}
+
+	query = fmt.Sprintf(
+		`UPDATE %s SET position = position + 1
+		WHERE %s = $1 AND position >= $2 AND deleted_at IS NULL`,
+		table, parentColumn)
+	if _, err := tx.Exec(query, parentId, position); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query = fmt.Sprintf(
+		`UPDATE %s SET deleted_at = NULL, position = $2 WHERE id = $1`, table)
+	if _, err := tx.Exec(query, itemId, position); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := recordTaskHistory(tx, time.Now().Unix(), historyCondition, itemId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *TrashPg) selectItems(query string, args ...interface{}) ([]*models.TrashItem, error) {
+	items := make([]*models.TrashItem, 0)
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanTrashItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func scanTrashItem(row rowScanner) (*models.TrashItem, error) {
+	item := &models.TrashItem{}
+	err := row.Scan(&item.Type, &item.Id, &item.ProjectId, &item.BoardId, &item.ListId,
+		&item.OwnerId, &item.Title, &item.Position, &item.DeletedAt, &item.ParentDeleted)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Boards and projects go away with their default permissions and datetimes
+// rows; the rows referencing them are removed by the cascades.
+func purgeObjectsQuery(table, expired string) string {
+	return fmt.Sprintf(
+		`WITH removed AS (
+			DELETE FROM %s WHERE id IN (%s)
+			RETURNING default_permissions_id, datetimes_id
+		), removed_permissions AS (
+			DELETE FROM %s WHERE id IN (SELECT default_permissions_id FROM removed)
+		)
+		DELETE FROM %s WHERE id IN (SELECT datetimes_id FROM removed)`,
+		table, expired, permissionsTable, datetimesTable)
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	sqlmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestTrashPg_Restore(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewTrashPg(db)
+
+	type args struct {
+		itemType string
+		itemId   int
+	}
+	type mockBehavior func(args args)
+
+	tests := []struct {
+		name    string
+		mock    mockBehavior
+		input   args
+		wantErr bool
+	}{
+		{
+			name:  "Task",
+			input: args{itemType: models.TrashTask, itemId: 4},
+			mock: func(args args) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT x.list_id, x.position").WithArgs(args.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"list_id", "position", "count"}).AddRow(3, 5, 2))
+				mock.ExpectExec("UPDATE tasks SET position = position \\+ 1").WithArgs(3, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE tasks SET deleted_at = NULL").WithArgs(args.itemId, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO task_history (.+) WHERE t.id = \\$2").
+					WithArgs(sqlmock.AnyArg(), args.itemId).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "List",
+			input: args{itemType: models.TrashList, itemId: 3},
+			mock: func(args args) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT x.board_id, x.position").WithArgs(args.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"board_id", "position", "count"}).AddRow(2, 0, 1))
+				mock.ExpectExec("UPDATE task_lists SET position = position \\+ 1").WithArgs(2, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE task_lists SET deleted_at = NULL").WithArgs(args.itemId, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO task_history (.+) WHERE t.list_id = \\$2").
+					WithArgs(sqlmock.AnyArg(), args.itemId).WillReturnResult(sqlmock.NewResult(0, 2))
+
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(tt.input)
+
+			err := r.Restore(tt.input.itemType, tt.input.itemId)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
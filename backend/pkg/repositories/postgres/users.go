@@ -93,6 +93,30 @@ func (r *UserPg) Update(id int, profile *models.UpdateUser) error {
 	return err
 }
 
+// UpdateAvatar sets the avatar and returns the previous one, so that its
+// images can be removed.
+func (r *UserPg) UpdateAvatar(id int, avatar string) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	var previous string
+	query := fmt.Sprintf(`SELECT avatar FROM %s WHERE id = $1 FOR UPDATE`, usersTable)
+	if err := tx.QueryRow(query, id).Scan(&previous); err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	query = fmt.Sprintf(`UPDATE %s SET avatar = $1 WHERE id = $2`, usersTable)
+	if _, err := tx.Exec(query, avatar, id); err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	return previous, tx.Commit()
+}
+
 func (r *UserPg) GetAll() ([]*models.User, error) {
 	var users []*models.User
 	query := fmt.Sprintf(`SELECT * FROM %s`, usersTable)
@@ -114,15 +138,27 @@ func (r *UserPg) Get(nickname, password string) (*models.User, error) {
 
 func (r *UserPg) Create(user *models.User) (int, error) {
 	var id int
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
 	query := fmt.Sprintf(
 		`INSERT INTO %s (nickname, email, password, avatar)
 		VALUES ($1, $2, $3, $4) RETURNING id`, usersTable)
 
-	row := r.db.QueryRow(query, user.Nickname, user.Email, user.Password, user.Avatar)
+	row := tx.QueryRow(query, user.Nickname, user.Email, user.Password, user.Avatar)
 	if err := row.Scan(&id); err != nil {
+		tx.Rollback()
 		return 0, err
 	}
 
+	if err := redeemInvitations(tx, id, user.Email, user.InvitationToken); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	tx.Commit()
 	return id, nil
 }
 
@@ -28,9 +28,11 @@ func TestUserPg_Create(t *testing.T) {
 		{
 			name: "Ok",
 			mock: func() {
+				mock.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 				mock.ExpectQuery("INSERT INTO users").
 					WithArgs("TestName", "test@test.com", "password", "avatar").WillReturnRows(rows)
+				mock.ExpectCommit()
 			},
 			input: &models.User{
 				Nickname: "TestName",
@@ -40,12 +42,34 @@ func TestUserPg_Create(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "Ok with invitation",
+			mock: func() {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
+				mock.ExpectQuery("INSERT INTO users").
+					WithArgs("TestName", "Test@test.com", "password", "avatar").WillReturnRows(rows)
+				mock.ExpectExec("UPDATE invitations (.+) WHERE token = \\$3 AND lower\\(email\\) = lower\\(\\$2\\)").
+					WithArgs(1, "Test@test.com", "token").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			input: &models.User{
+				Nickname:        "TestName",
+				Email:           "Test@test.com",
+				Password:        "password",
+				Avatar:          "avatar",
+				InvitationToken: "token",
+			},
+			want: 1,
+		},
 		{
 			name: "Empty Fields",
 			mock: func() {
+				mock.ExpectBegin()
 				rows := sqlmock.NewRows([]string{"id"})
 				mock.ExpectQuery("INSERT INTO users").
 					WithArgs("TestName", "test@test.com", "", "avatar").WillReturnRows(rows)
+				mock.ExpectRollback()
 			},
 			input: &models.User{
 				Nickname: "TestName",
@@ -58,9 +82,11 @@ func TestUserPg_Create(t *testing.T) {
 		{
 			name: "Not Unique Name",
 			mock: func() {
+				mock.ExpectBegin()
 				mock.ExpectQuery("INSERT INTO users").
 					WithArgs("NotUniqueName", "test@test.com", "password", "avatar").
 					WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
 			},
 			input: &models.User{
 				Nickname: "NotUniqueName",
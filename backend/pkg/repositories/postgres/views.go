@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type ViewPg struct {
+	db *sqlx.DB
+}
+
+func NewViewPg(db *sqlx.DB) *ViewPg {
+	return &ViewPg{db: db}
+}
+
+func (r *ViewPg) Create(view *models.BoardView) (int, error) {
+	var id int
+	query := fmt.Sprintf(
+		`INSERT INTO %s (board_id, owner_id, title, filter, sort, list_ids, shared, created)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`, viewsTable)
+	row := r.db.QueryRow(query, view.BoardId, view.OwnerId, view.Title, view.Filter,
+		view.Sort, pq.Array(view.ListIds), view.Shared, view.Created)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetAll returns the shared views of the board and the private views of the
+// user, marking the default one of the user.
+func (r *ViewPg) GetAll(boardId, userId int) ([]*models.BoardView, error) {
+	views := make([]*models.BoardView, 0)
+	query := fmt.Sprintf(
+		`SELECT v.id, v.board_id, v.owner_id, v.title, v.filter, v.sort,
+			COALESCE(v.list_ids, '{}'), v.shared, v.created, dv.id IS NOT NULL
+		FROM %s AS v
+			LEFT JOIN %s AS dv ON dv.view_id = v.id AND dv.user_id = $2
+		WHERE v.board_id = $1 AND (v.shared OR v.owner_id = $2)
+		ORDER BY v.id`, viewsTable, defaultViewsTable)
+
+	rows, err := r.db.Query(query, boardId, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// GetById returns the view whatever its owner; IsDefault is set when it is
+// the default view of the user.
+func (r *ViewPg) GetById(viewId, userId int) (*models.BoardView, error) {
+	query := fmt.Sprintf(
+		`SELECT v.id, v.board_id, v.owner_id, v.title, v.filter, v.sort,
+			COALESCE(v.list_ids, '{}'), v.shared, v.created, dv.id IS NOT NULL
+		FROM %s AS v
+			LEFT JOIN %s AS dv ON dv.view_id = v.id AND dv.user_id = $2
+		WHERE v.id = $1`, viewsTable, defaultViewsTable)
+	return scanView(r.db.QueryRow(query, viewId, userId))
+}
+
+func scanView(row rowScanner) (*models.BoardView, error) {
+	view := &models.BoardView{}
+	var listIds pq.Int64Array
+	err := row.Scan(&view.Id, &view.BoardId, &view.OwnerId, &view.Title, &view.Filter,
+		&view.Sort, &listIds, &view.Shared, &view.Created, &view.IsDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	view.ListIds = make([]int, 0, len(listIds))
+	for _, listId := range listIds {
+		view.ListIds = append(view.ListIds, int(listId))
+	}
+	return view, nil
+}
+
+// Update also drops the view from the defaults of other users when it stops
+// being shared.
+func (r *ViewPg) Update(viewId int, input *models.UpdateBoardView) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Title != nil {
+		setValues = append(setValues, fmt.Sprintf("title=$%d", argId))
+		args = append(args, *input.Title)
+		argId++
+	}
+
+	if input.Filter != nil {
+		setValues = append(setValues, fmt.Sprintf("filter=$%d", argId))
+		args = append(args, *input.Filter)
+		argId++
+	}
+
+	if input.Sort != nil {
+		setValues = append(setValues, fmt.Sprintf("sort=$%d", argId))
+		args = append(args, *input.Sort)
+		argId++
+	}
+
+	if input.ListIds != nil {
+		setValues = append(setValues, fmt.Sprintf("list_ids=$%d", argId))
+		args = append(args, pq.Array(*input.ListIds))
+		argId++
+	}
+
+	if input.Shared != nil {
+		setValues = append(setValues, fmt.Sprintf("shared=$%d", argId))
+		args = append(args, *input.Shared)
+		argId++
+	}
+
+	if len(setValues) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+	query := fmt.Sprintf(`UPDATE %s SET %s WHERE id=$%d`, viewsTable, setQuery, argId)
+	args = append(args, viewId)
+	if _, err := tx.Exec(query, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if input.Shared != nil && !*input.Shared {
+		query = fmt.Sprintf(
+			`DELETE FROM %s AS dv USING %s AS v
+			WHERE dv.view_id = v.id AND v.id = $1 AND dv.user_id <> v.owner_id`,
+			defaultViewsTable, viewsTable)
+		if _, err := tx.Exec(query, viewId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *ViewPg) Delete(viewId int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, viewsTable)
+	_, err := r.db.Exec(query, viewId)
+	return err
+}
+
+func (r *ViewPg) SetDefault(boardId, userId, viewId int) error {
+	query := fmt.Sprintf(
+		`INSERT INTO %s (board_id, user_id, view_id) VALUES ($1, $2, $3)
+		ON CONFLICT (board_id, user_id) DO UPDATE SET view_id = EXCLUDED.view_id`,
+		defaultViewsTable)
+	_, err := r.db.Exec(query, boardId, userId, viewId)
+	return err
+}
+
+func (r *ViewPg) DeleteDefault(boardId, userId int) error {
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE board_id = $1 AND user_id = $2`, defaultViewsTable)
+	_, err := r.db.Exec(query, boardId, userId)
+	return err
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"github.com/architectv/networking-course-project/backend/pkg/filter"
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 	"github.com/architectv/networking-course-project/backend/pkg/repositories/postgres"
 
@@ -16,6 +17,7 @@ type User interface {
 	SignOut(token string) (int, error)
 	FindToken(token string) error
 	Update(id int, profile *models.UpdateUser) error
+	UpdateAvatar(id int, avatar string) (string, error)
 }
 
 type Project interface {
@@ -24,17 +26,18 @@ type Project interface {
 	GetById(projectId int) (*models.Project, error)
 	Delete(projectId int) error
 	Update(projectId int, project *models.UpdateProject) error
-	GetPermissions(userId, projectId int) (*models.Permission, error)
 	GetMembers(projectId int) ([]*models.Member, error)
 }
 
 type Board interface {
 	Create(userId int, board *models.Board) (int, error)
-	GetAll(userId, projectId int) ([]*models.Board, error)
+	GetAll(projectId int) ([]*models.Board, error)
 	GetById(boardId int) (*models.Board, error)
 	Delete(boardId int) error
 	Update(boardId int, board *models.UpdateBoard) error
-	GetPermissions(userId, boardId int) (*models.Permission, error)
+	SetArchived(boardId int, archived bool) error
+	GetContent(boardId int, withTasks bool) (*models.BoardContent, error)
+	CreateWithContent(userId int, board *models.Board, content *models.BoardContent) (int, error)
 	GetBoardsCountByOwnerId(projectId, ownerId int) (int, error)
 	GetMembers(projectId int) ([]*models.Member, error)
 }
@@ -45,15 +48,106 @@ type TaskList interface {
 	GetById(listId int) (*models.TaskList, error)
 	Delete(listId int) error
 	Update(listId int, list *models.UpdateTaskList) error
+	SetArchived(listId int, archived bool) error
+	Move(listId, boardId int, position *int, dropUsers []int) ([]string, error)
+	GetUsers(listId int) ([]int, error)
 	// GetPermissions(userId, boardId int) (*models.Permission, error)
 }
 
 type Task interface {
 	Create(task *models.Task) (int, error)
+	CreateWithLabels(task *models.Task, labelIds []int) (int, error)
 	GetAll(taskId int) ([]*models.Task, error)
 	GetById(taskId int) (*models.Task, error)
 	Delete(taskId int) error
 	Update(taskId int, task *models.UpdateTask) error
+	GetAllInBoard(boardId int) ([]*models.TaskRecord, error)
+	GetFiltered(boardId, listId int, query *filter.Query) ([]*models.Task, error)
+	Bulk(boardId int, operations []*models.BulkTaskOperation) ([]*models.BulkTaskResult, error)
+	Move(taskId, listId int, position *int, dropUsers []int) ([]string, error)
+	GetUsers(taskId int) ([]int, error)
+}
+
+type Relation interface {
+	GetAll(taskId int) ([]*models.TaskRelation, error)
+	GetTaskBoard(taskId int) (*models.Board, error)
+	Create(taskId, relatedId int, kind string) (int, error)
+	Delete(relationId, taskId int) error
+}
+
+type Sprint interface {
+	GetAll(boardId int) ([]*models.Sprint, error)
+	GetById(sprintId int) (*models.Sprint, error)
+	Create(sprint *models.Sprint) (int, error)
+	Update(sprintId int, input *models.UpdateSprint) error
+	Delete(sprintId int) error
+	GetTasks(sprintId int) ([]*models.SprintTask, error)
+	Start(sprintId int, now int64) error
+	Complete(sprintId, carryOverTo int, now int64) ([]int, error)
+	GetHistory(sprintId int) ([]*models.TaskSnapshot, error)
+}
+
+type CustomField interface {
+	GetAll(boardId int) ([]*models.CustomField, error)
+	GetById(fieldId int) (*models.CustomField, error)
+	Create(field *models.CustomField) (int, error)
+	Update(fieldId int, input *models.UpdateCustomField) error
+	Delete(fieldId int) error
+	GetValues(taskIds []int) ([]*models.CustomValue, error)
+	SetValue(value *models.CustomValue) error
+	DeleteValue(taskId, fieldId int) error
+}
+
+type Mention interface {
+	GetAll(taskId int) ([]*models.Mention, error)
+	Set(taskId, actorId int, userIds []int, created int64) ([]int, error)
+}
+
+type Time interface {
+	GetEstimate(taskId int) (*models.TimeEstimate, error)
+	UpdateEstimate(taskId int, input *models.UpdateTimeEstimate) error
+	GetLogs(taskId int) ([]*models.TimeLog, error)
+	GetLog(logId int) (*models.TimeLog, error)
+	CreateLog(log *models.TimeLog) (int, error)
+	DeleteLog(logId int) error
+	GetTimer(userId int) (*models.Timer, error)
+	StartTimer(userId, taskId int, now int64) (*models.TimeLog, error)
+	StopTimer(userId int, now int64, note string) (*models.TimeLog, error)
+	GetReport(query *models.TimeReportQuery, boardIds []int) ([]*models.TimeReportEntry, error)
+}
+
+type Attachment interface {
+	GetAll(taskId int) ([]*models.Attachment, error)
+	GetById(attachmentId int) (*models.Attachment, error)
+	Create(attachment *models.Attachment) (int, error)
+	Delete(attachmentId int) error
+}
+
+type Notification interface {
+	Watch(userId int, watch *models.Watch) error
+	Unwatch(userId int, watch *models.Watch) error
+	GetWatches(userId int) ([]*models.Watch, error)
+	GetWatchBoard(watch *models.Watch) (*models.Board, error)
+	GetRecipients(event *models.Event) ([]int, error)
+	IsEnabled(userId int, event string) (bool, error)
+	Create(userIds []int, event *models.Event, created int64) error
+	GetAll(userId int, unread bool, limit, offset int) ([]*models.Notification, error)
+	CountUnread(userId int) (int, error)
+	MarkRead(userId, notificationId int) error
+	MarkAllRead(userId int) error
+	GetPreferences(userId int) (map[string]bool, error)
+	UpdatePreferences(userId int, preferences map[string]bool) error
+}
+
+type Mail interface {
+	GetRecipient(userId int) (*models.MailRecipient, error)
+	GetDigestRecipients(before int64) ([]*models.MailRecipient, error)
+	UpdateSettings(userId int, input *models.UpdateMailSettings) error
+	SetDigestSent(userId int, sent int64) error
+	GetDigest(userId int, since int64) ([]*models.Notification, error)
+	GetEventTitle(event *models.Event) (string, error)
+	GetReminders(before int64) ([]*models.Reminder, error)
+	SetReminded(taskId int) error
 }
 
 type Label interface {
@@ -71,8 +165,89 @@ type ObjectPerms interface {
 	Create(objectId, objectType int, memberNickname string, permissions *models.Permission) (int, error)
 	GetById(objectId, memberId, objectType int) (*models.Permission, error)
 	GetByNickname(objectId, objectType int, memberId string) (*models.Permission, error)
+	GetUserIds(nicknames []string) (map[string]int, error)
 	Delete(objectId, oldOwnerId, newOwnerId, objectType int) error
 	Update(objectId, oldOwnerId, newOwnerId, objectType int, permissions *models.UpdatePermission) error
+	SetBoardDeny(boardId, memberId int, permissions *models.Permission) error
+	DeleteBoardDeny(boardId, memberId int) error
+	GetByGroupId(objectId, groupId, objectType int) (*models.Permission, error)
+	CreateForGroup(objectId, objectType, groupId int, permissions *models.Permission) (int, error)
+	UpdateForGroup(objectId, groupId, objectType int, permissions *models.UpdatePermission) error
+	DeleteForGroup(objectId, groupId, objectType int) error
+	TransferOwnership(objectId, objectType int, transfer *models.OwnershipTransfer, oldOwnerPerms *models.Permission) (int, error)
+	GetTransfers(projectId int) ([]*models.OwnershipTransfer, error)
+}
+
+type Group interface {
+	Create(group *models.Group) (int, error)
+	GetAll(projectId int) ([]*models.Group, error)
+	GetById(groupId int) (*models.Group, error)
+	Delete(groupId int) error
+	Update(groupId int, group *models.UpdateGroup) error
+	GetMembers(groupId int) ([]*models.Member, error)
+	AddMember(groupId int, memberNickname string) (int, error)
+	DeleteMember(groupId, memberId int) error
+}
+
+type Invitation interface {
+	Create(invitation *models.Invitation) (int, error)
+	GetAll(projectId int, now int64) ([]*models.Invitation, error)
+	GetAllForUser(userId int, now int64) ([]*models.Invitation, error)
+	GetById(invitationId int) (*models.Invitation, error)
+	Accept(invitationId int) (int, error)
+	Delete(invitationId int) error
+}
+
+type BoardShare interface {
+	Create(share *models.BoardShare) (int, error)
+	GetAll(boardId int) ([]*models.BoardShare, error)
+	GetById(shareId int) (*models.BoardShare, error)
+	GetByToken(token string) (*models.BoardShare, error)
+	Delete(shareId int) error
+}
+
+type Template interface {
+	Create(template *models.BoardTemplate) (int, error)
+	GetAll(projectId int) ([]*models.BoardTemplate, error)
+	GetById(templateId int) (*models.BoardTemplate, error)
+	Delete(templateId int) error
+}
+
+type View interface {
+	Create(view *models.BoardView) (int, error)
+	GetAll(boardId, userId int) ([]*models.BoardView, error)
+	GetById(viewId, userId int) (*models.BoardView, error)
+	Update(viewId int, input *models.UpdateBoardView) error
+	Delete(viewId int) error
+	SetDefault(boardId, userId, viewId int) error
+	DeleteDefault(boardId, userId int) error
+}
+
+type Export interface {
+	Export(projectId int) (*models.ProjectExport, error)
+	Import(userId int, archive *models.ProjectExport, created, expires int64) (int, []string, error)
+}
+
+type Search interface {
+	Search(query *models.SearchQuery, projectIds, boardIds []int) ([]*models.SearchHit, error)
+}
+
+type Trash interface {
+	GetAll(projectId int) ([]*models.TrashItem, error)
+	GetProjects(ownerId int) ([]*models.TrashItem, error)
+	GetById(itemType string, itemId int) (*models.TrashItem, error)
+	Restore(itemType string, itemId int) error
+	Purge(before int64) ([]string, error)
+}
+
+type Access interface {
+	GetProjectSources(userId, projectId int) (*models.AccessSources, error)
+	GetBoardSources(userId, boardId int) (*models.AccessSources, error)
+	GetBoardsSources(userId, projectId int) ([]*models.AccessSources, error)
+	GetUsersSources(boardId int, userIds []int) ([]*models.AccessSources, error)
+	GetReadableProjects(userId int) ([]int, error)
+	GetShareSources(shareId, boardId int, now int64) (*models.AccessSources, error)
+	IsListArchived(listId int) (bool, error)
 }
 
 type Repository struct {
@@ -81,8 +256,25 @@ type Repository struct {
 	Board
 	TaskList
 	Task
+	Relation
+	Sprint
+	CustomField
+	Mention
+	Time
+	Attachment
+	Notification
+	Mail
 	Label
 	ObjectPerms
+	Group
+	Invitation
+	BoardShare
+	Template
+	View
+	Export
+	Search
+	Trash
+	Access
 }
 
 // func NewRepository(db *mongo.Database) *Repository {
@@ -97,12 +289,29 @@ type Repository struct {
 
 func NewRepository(db *sqlx.DB) *Repository {
 	return &Repository{
-		User:        postgres.NewUserPg(db),
-		Project:     postgres.NewProjectPg(db),
-		Board:       postgres.NewBoardPg(db),
-		TaskList:    postgres.NewTaskListPg(db),
-		Task:        postgres.NewTaskPg(db),
-		Label:       postgres.NewLabelPg(db),
-		ObjectPerms: postgres.NewObjectPermsPg(db),
+		User:         postgres.NewUserPg(db),
+		Project:      postgres.NewProjectPg(db),
+		Board:        postgres.NewBoardPg(db),
+		TaskList:     postgres.NewTaskListPg(db),
+		Task:         postgres.NewTaskPg(db),
+		Relation:     postgres.NewRelationPg(db),
+		Sprint:       postgres.NewSprintPg(db),
+		CustomField:  postgres.NewCustomFieldPg(db),
+		Mention:      postgres.NewMentionPg(db),
+		Time:         postgres.NewTimePg(db),
+		Attachment:   postgres.NewAttachmentPg(db),
+		Notification: postgres.NewNotificationPg(db),
+		Mail:         postgres.NewMailPg(db),
+		Label:        postgres.NewLabelPg(db),
+		ObjectPerms:  postgres.NewObjectPermsPg(db),
+		Group:        postgres.NewGroupPg(db),
+		Invitation:   postgres.NewInvitationPg(db),
+		BoardShare:   postgres.NewBoardSharePg(db),
+		Template:     postgres.NewTemplatePg(db),
+		View:         postgres.NewViewPg(db),
+		Export:       postgres.NewExportPg(db),
+		Search:       postgres.NewSearchPg(db),
+		Trash:        postgres.NewTrashPg(db),
+		Access:       postgres.NewAccessPg(db),
 	}
 }
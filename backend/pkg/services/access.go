@@ -0,0 +1,362 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+const (
+	RightRead  = "read"
+	RightWrite = "write"
+	RightAdmin = "admin"
+
+	SourceProjectOwner     = "projectOwner"
+	SourceBoardOwner       = "boardOwner"
+	SourceProjectAdmin     = "projectAdmin"
+	SourceProjectMember    = "projectMember"
+	SourceProjectGroup     = "projectGroup"
+	SourceBoardDefault     = "boardDefault"
+	SourceBoardAllow       = "boardAllow"
+	SourceBoardGroup       = "boardGroup"
+	SourceBoardDeny        = "boardDeny"
+	SourceShareLink        = "shareLink"
+	SourceArchived         = "archived"
+	SourceRequiresRead     = "requiresRead"
+	SourceRequiresWrite    = "requiresWrite"
+	SourceNotProjectMember = "notProjectMember"
+	SourceNone             = "none"
+)
+
+// Owners and project admins get every right on a board. Other project members
+// inherit the board defaults capped by their project permissions, board_users
+// and board_groups rows add rights on top and board_denials rows take them away.
+// Group grants count the same as direct ones. Share link guests may only read
+// the shared board.
+type AccessResolver struct {
+	repo repositories.Access
+}
+
+func NewAccessResolver(repo repositories.Access) *AccessResolver {
+	return &AccessResolver{repo: repo}
+}
+
+func (a *AccessResolver) Project(userId, projectId int) (*models.EffectivePermissions, error) {
+	sources, err := a.repo.GetProjectSources(userId, projectId)
+	if err != nil {
+		return nil, err
+	}
+	return resolveProjectAccess(sources), nil
+}
+
+func (a *AccessResolver) Board(userId, boardId int) (*models.EffectivePermissions, error) {
+	var sources *models.AccessSources
+	var err error
+	if IsGuest(userId) {
+		sources, err = a.repo.GetShareSources(-userId, boardId, time.Now().Unix())
+	} else {
+		sources, err = a.repo.GetBoardSources(userId, boardId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resolveBoardAccess(sources), nil
+}
+
+func (a *AccessResolver) Boards(userId, projectId int) (map[int]*models.EffectivePermissions, error) {
+	sourcesList, err := a.repo.GetBoardsSources(userId, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	access := make(map[int]*models.EffectivePermissions, len(sourcesList))
+	for _, sources := range sourcesList {
+		access[sources.BoardId] = resolveBoardAccess(sources)
+	}
+	return access, nil
+}
+
+// BoardUsers resolves the access of many users to the board at once, the users
+// unknown to the board are left out.
+func (a *AccessResolver) BoardUsers(boardId int, userIds []int) (map[int]*models.EffectivePermissions, error) {
+	sourcesList, err := a.repo.GetUsersSources(boardId, userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	access := make(map[int]*models.EffectivePermissions, len(sourcesList))
+	for _, sources := range sourcesList {
+		access[sources.UserId] = resolveBoardAccess(sources)
+	}
+	return access, nil
+}
+
+// ReadableBoards narrows a query to what the user reads: the board when
+// boardId is set, else the readable boards of the project when projectId is,
+// else the readable boards of all the user's projects. It returns the
+// projects searched along with the boards, and NoReadAccess when the user
+// can't read the board or the project asked for.
+func (a *AccessResolver) ReadableBoards(userId, projectId, boardId int) ([]int, []int, error) {
+	var projectIds, boardIds []int
+	switch {
+	case boardId != 0:
+		permissions, err := a.BoardPermissions(userId, boardId)
+		if err != nil || permissions.Read == false {
+			return nil, nil, errors.New(NoReadAccess)
+		}
+		return nil, []int{boardId}, nil
+	case projectId != 0:
+		permissions, err := a.ProjectPermissions(userId, projectId)
+		if err != nil || permissions.Read == false {
+			return nil, nil, errors.New(NoReadAccess)
+		}
+		projectIds = []int{projectId}
+	default:
+		var err error
+		if projectIds, err = a.repo.GetReadableProjects(userId); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, projectId := range projectIds {
+		access, err := a.Boards(userId, projectId)
+		if err != nil {
+			return nil, nil, err
+		}
+		for boardId, effective := range access {
+			if effective.Permissions.Read {
+				boardIds = append(boardIds, boardId)
+			}
+		}
+	}
+	sort.Ints(boardIds)
+	return projectIds, boardIds, nil
+}
+
+func accessError(r *models.ApiResponse, err error) {
+	if err.Error() == NoReadAccess {
+		r.Error(StatusForbidden, "Forbidden")
+		return
+	}
+	r.Error(StatusInternalServerError, err.Error())
+}
+
+func (a *AccessResolver) ProjectPermissions(userId, projectId int) (*models.Permission, error) {
+	effective, err := a.Project(userId, projectId)
+	if err != nil {
+		return nil, err
+	}
+	return effective.Permissions, nil
+}
+
+func (a *AccessResolver) BoardPermissions(userId, boardId int) (*models.Permission, error) {
+	effective, err := a.Board(userId, boardId)
+	if err != nil {
+		return nil, err
+	}
+	return effective.Permissions, nil
+}
+
+// UsersWithoutRead returns the users that can't read the board.
+func (a *AccessResolver) UsersWithoutRead(boardId int, userIds []int) ([]int, error) {
+	users := make([]int, 0)
+	for _, userId := range userIds {
+		permissions, err := a.BoardPermissions(userId, boardId)
+		if err != nil {
+			return nil, err
+		}
+		if permissions.Read == false {
+			users = append(users, userId)
+		}
+	}
+	return users, nil
+}
+
+// ListPermissions are the board permissions without write for archived lists.
+func (a *AccessResolver) ListPermissions(userId, boardId, listId int) (*models.Permission, error) {
+	permissions, err := a.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		return permissions, err
+	}
+
+	archived, err := a.repo.IsListArchived(listId)
+	if err != nil {
+		return nil, err
+	}
+	if archived {
+		return &models.Permission{Read: permissions.Read, Admin: permissions.Admin}, nil
+	}
+	return permissions, nil
+}
+
+// Share link guests act under the negated id of their share link, which can
+// never clash with a user id.
+func GuestPrincipal(shareId int) int {
+	return -shareId
+}
+
+func IsGuest(userId int) bool {
+	return userId < 0
+}
+
+func resolveProjectAccess(sources *models.AccessSources) *models.EffectivePermissions {
+	rights := newRights()
+
+	switch {
+	case sources.IsProjectOwner:
+		rights.grantAll(SourceProjectOwner)
+	case sources.Project == nil && sources.ProjectGroups == nil:
+		rights.denyAll(SourceNotProjectMember)
+	default:
+		rights.each(func(right *models.PermissionRight) {
+			switch {
+			case permissionHas(sources.Project, right.Name):
+				right.Granted, right.Source = true, SourceProjectMember
+			case permissionHas(sources.ProjectGroups, right.Name):
+				right.Granted, right.Source = true, SourceProjectGroup
+			}
+		})
+		rights.normalize()
+	}
+
+	return &models.EffectivePermissions{
+		UserId:      sources.UserId,
+		ProjectId:   sources.ProjectId,
+		Permissions: rights.permission(),
+		Rights:      rights,
+	}
+}
+
+func resolveBoardAccess(sources *models.AccessSources) *models.EffectivePermissions {
+	rights := newRights()
+	project := mergePermissions(sources.Project, sources.ProjectGroups)
+
+	switch {
+	case sources.IsShareGuest:
+		rights[0].Granted, rights[0].Source = true, SourceShareLink
+	case sources.IsProjectOwner:
+		rights.grantAll(SourceProjectOwner)
+	case project == nil:
+		rights.denyAll(SourceNotProjectMember)
+	case sources.IsBoardOwner:
+		rights.grantAll(SourceBoardOwner)
+	case project.Admin:
+		rights.grantAll(SourceProjectAdmin)
+	default:
+		rights.each(func(right *models.PermissionRight) {
+			switch {
+			case permissionHas(sources.BoardDeny, right.Name):
+				right.Granted, right.Source = false, SourceBoardDeny
+			case permissionHas(project, right.Name) &&
+				permissionHas(sources.BoardDefault, right.Name):
+				right.Granted, right.Source = true, SourceBoardDefault
+			case permissionHas(sources.BoardAllow, right.Name):
+				right.Granted, right.Source = true, SourceBoardAllow
+			case permissionHas(sources.BoardGroups, right.Name):
+				right.Granted, right.Source = true, SourceBoardGroup
+			}
+		})
+		rights.normalize()
+	}
+
+	// Archived boards are read-only even for their admins, who keep the
+	// admin right so that they can unarchive them.
+	if sources.IsArchived && rights[1].Granted {
+		rights[1].Granted, rights[1].Source = false, SourceArchived
+	}
+
+	return &models.EffectivePermissions{
+		UserId:      sources.UserId,
+		ProjectId:   sources.ProjectId,
+		BoardId:     sources.BoardId,
+		Permissions: rights.permission(),
+		Rights:      rights,
+	}
+}
+
+type rightsList []*models.PermissionRight
+
+func newRights() rightsList {
+	return rightsList{
+		{Name: RightRead, Source: SourceNone},
+		{Name: RightWrite, Source: SourceNone},
+		{Name: RightAdmin, Source: SourceNone},
+	}
+}
+
+func (l rightsList) each(f func(right *models.PermissionRight)) {
+	for _, right := range l {
+		f(right)
+	}
+}
+
+func (l rightsList) grantAll(source string) {
+	l.each(func(right *models.PermissionRight) {
+		right.Granted, right.Source = true, source
+	})
+}
+
+func (l rightsList) denyAll(source string) {
+	l.each(func(right *models.PermissionRight) {
+		right.Granted, right.Source = false, source
+	})
+}
+
+// Same hierarchy as permsValidation: no write without read, no admin without write.
+func (l rightsList) normalize() {
+	read, write, admin := l[0], l[1], l[2]
+	if !read.Granted {
+		if write.Granted {
+			write.Granted, write.Source = false, SourceRequiresRead
+		}
+		if admin.Granted {
+			admin.Granted, admin.Source = false, SourceRequiresRead
+		}
+	}
+	if !write.Granted && admin.Granted {
+		admin.Granted, admin.Source = false, SourceRequiresWrite
+	}
+}
+
+func (l rightsList) permission() *models.Permission {
+	return &models.Permission{
+		Read:  l[0].Granted,
+		Write: l[1].Granted,
+		Admin: l[2].Granted,
+	}
+}
+
+func permissionHas(perms *models.Permission, right string) bool {
+	if perms == nil {
+		return false
+	}
+
+	switch right {
+	case RightRead:
+		return perms.Read
+	case RightWrite:
+		return perms.Write
+	case RightAdmin:
+		return perms.Admin
+	default:
+		return false
+	}
+}
+
+func mergePermissions(a, b *models.Permission) *models.Permission {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+
+	return &models.Permission{
+		Read:  a.Read || b.Read,
+		Write: a.Write || b.Write,
+		Admin: a.Admin || b.Admin,
+	}
+}
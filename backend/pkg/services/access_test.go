@@ -0,0 +1,240 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func projectSources(perms *models.Permission) *models.AccessSources {
+	return &models.AccessSources{Project: perms}
+}
+
+func boardSources(perms *models.Permission) *models.AccessSources {
+	if perms == nil {
+		return &models.AccessSources{}
+	}
+
+	return &models.AccessSources{
+		Project:      &models.Permission{Read: true, Write: true},
+		BoardDefault: &models.Permission{},
+		BoardAllow:   perms,
+	}
+}
+
+func TestResolveProjectAccess(t *testing.T) {
+	tests := []struct {
+		name            string
+		sources         *models.AccessSources
+		expectedPerms   *models.Permission
+		expectedSources []string
+	}{
+		{
+			name:            "Owner",
+			sources:         &models.AccessSources{IsProjectOwner: true},
+			expectedPerms:   &models.Permission{Read: true, Write: true, Admin: true},
+			expectedSources: []string{SourceProjectOwner, SourceProjectOwner, SourceProjectOwner},
+		},
+		{
+			name:            "Not member",
+			sources:         &models.AccessSources{},
+			expectedPerms:   &models.Permission{},
+			expectedSources: []string{SourceNotProjectMember, SourceNotProjectMember, SourceNotProjectMember},
+		},
+		{
+			name:            "Member",
+			sources:         projectSources(&models.Permission{Read: true, Write: true}),
+			expectedPerms:   &models.Permission{Read: true, Write: true},
+			expectedSources: []string{SourceProjectMember, SourceProjectMember, SourceNone},
+		},
+		{
+			name: "Member through group",
+			sources: &models.AccessSources{
+				Project:       &models.Permission{Read: true},
+				ProjectGroups: &models.Permission{Read: true, Write: true},
+			},
+			expectedPerms:   &models.Permission{Read: true, Write: true},
+			expectedSources: []string{SourceProjectMember, SourceProjectGroup, SourceNone},
+		},
+		{
+			name:            "Broken hierarchy",
+			sources:         projectSources(&models.Permission{Read: true, Admin: true}),
+			expectedPerms:   &models.Permission{Read: true},
+			expectedSources: []string{SourceProjectMember, SourceNone, SourceRequiresWrite},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := resolveProjectAccess(test.sources)
+			assert.Equal(t, test.expectedPerms, got.Permissions)
+			for i, right := range got.Rights {
+				assert.Equal(t, test.expectedSources[i], right.Source)
+			}
+		})
+	}
+}
+
+func TestResolveBoardAccess(t *testing.T) {
+	readOnly := &models.Permission{Read: true}
+	readWrite := &models.Permission{Read: true, Write: true}
+
+	tests := []struct {
+		name            string
+		sources         *models.AccessSources
+		expectedPerms   *models.Permission
+		expectedSources []string
+	}{
+		{
+			name: "Project owner ignores deny",
+			sources: &models.AccessSources{
+				IsProjectOwner: true,
+				BoardDefault:   readOnly,
+				BoardDeny:      &models.Permission{Read: true, Write: true, Admin: true},
+			},
+			expectedPerms:   &models.Permission{Read: true, Write: true, Admin: true},
+			expectedSources: []string{SourceProjectOwner, SourceProjectOwner, SourceProjectOwner},
+		},
+		{
+			name: "Board owner",
+			sources: &models.AccessSources{
+				IsBoardOwner: true,
+				Project:      readOnly,
+				BoardDefault: readOnly,
+			},
+			expectedPerms:   &models.Permission{Read: true, Write: true, Admin: true},
+			expectedSources: []string{SourceBoardOwner, SourceBoardOwner, SourceBoardOwner},
+		},
+		{
+			name: "Board owner left project",
+			sources: &models.AccessSources{
+				IsBoardOwner: true,
+				BoardDefault: readOnly,
+			},
+			expectedPerms:   &models.Permission{},
+			expectedSources: []string{SourceNotProjectMember, SourceNotProjectMember, SourceNotProjectMember},
+		},
+		{
+			name: "Project admin",
+			sources: &models.AccessSources{
+				Project:      &models.Permission{Read: true, Write: true, Admin: true},
+				BoardDefault: readOnly,
+			},
+			expectedPerms:   &models.Permission{Read: true, Write: true, Admin: true},
+			expectedSources: []string{SourceProjectAdmin, SourceProjectAdmin, SourceProjectAdmin},
+		},
+		{
+			name: "Inherit board defaults",
+			sources: &models.AccessSources{
+				Project:      readWrite,
+				BoardDefault: readWrite,
+			},
+			expectedPerms:   readWrite,
+			expectedSources: []string{SourceBoardDefault, SourceBoardDefault, SourceNone},
+		},
+		{
+			name: "Defaults capped by project permissions",
+			sources: &models.AccessSources{
+				Project:      readOnly,
+				BoardDefault: readWrite,
+			},
+			expectedPerms:   readOnly,
+			expectedSources: []string{SourceBoardDefault, SourceNone, SourceNone},
+		},
+		{
+			name: "Allow adds rights",
+			sources: &models.AccessSources{
+				Project:      readOnly,
+				BoardDefault: readOnly,
+				BoardAllow:   &models.Permission{Read: true, Write: true, Admin: true},
+			},
+			expectedPerms:   &models.Permission{Read: true, Write: true, Admin: true},
+			expectedSources: []string{SourceBoardDefault, SourceBoardAllow, SourceBoardAllow},
+		},
+		{
+			name: "Group grants",
+			sources: &models.AccessSources{
+				ProjectGroups: readOnly,
+				BoardDefault:  readOnly,
+				BoardGroups:   readWrite,
+			},
+			expectedPerms:   readWrite,
+			expectedSources: []string{SourceBoardDefault, SourceBoardGroup, SourceNone},
+		},
+		{
+			name: "Project admin through group",
+			sources: &models.AccessSources{
+				Project:       readOnly,
+				ProjectGroups: &models.Permission{Read: true, Write: true, Admin: true},
+				BoardDefault:  readOnly,
+			},
+			expectedPerms:   &models.Permission{Read: true, Write: true, Admin: true},
+			expectedSources: []string{SourceProjectAdmin, SourceProjectAdmin, SourceProjectAdmin},
+		},
+		{
+			name:            "Share guest",
+			sources:         &models.AccessSources{IsShareGuest: true},
+			expectedPerms:   readOnly,
+			expectedSources: []string{SourceShareLink, SourceNone, SourceNone},
+		},
+		{
+			name:            "Revoked share link",
+			sources:         &models.AccessSources{},
+			expectedPerms:   &models.Permission{},
+			expectedSources: []string{SourceNotProjectMember, SourceNotProjectMember, SourceNotProjectMember},
+		},
+		{
+			name: "Archived board is read-only for its admins",
+			sources: &models.AccessSources{
+				IsArchived:   true,
+				Project:      &models.Permission{Read: true, Write: true, Admin: true},
+				BoardDefault: readOnly,
+			},
+			expectedPerms:   &models.Permission{Read: true, Admin: true},
+			expectedSources: []string{SourceProjectAdmin, SourceArchived, SourceProjectAdmin},
+		},
+		{
+			name: "Archived board is read-only for members",
+			sources: &models.AccessSources{
+				IsArchived:   true,
+				Project:      readWrite,
+				BoardDefault: readWrite,
+			},
+			expectedPerms:   readOnly,
+			expectedSources: []string{SourceBoardDefault, SourceArchived, SourceNone},
+		},
+		{
+			name: "Deny wins over allow",
+			sources: &models.AccessSources{
+				Project:      readWrite,
+				BoardDefault: readWrite,
+				BoardAllow:   &models.Permission{Read: true, Write: true, Admin: true},
+				BoardDeny:    &models.Permission{Write: true, Admin: true},
+			},
+			expectedPerms:   readOnly,
+			expectedSources: []string{SourceBoardDefault, SourceBoardDeny, SourceBoardDeny},
+		},
+		{
+			name: "Denied read drops everything",
+			sources: &models.AccessSources{
+				Project:      readWrite,
+				BoardDefault: readWrite,
+				BoardDeny:    readOnly,
+			},
+			expectedPerms:   &models.Permission{},
+			expectedSources: []string{SourceBoardDeny, SourceRequiresRead, SourceNone},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := resolveBoardAccess(test.sources)
+			assert.Equal(t, test.expectedPerms, got.Permissions)
+			for i, right := range got.Rights {
+				assert.Equal(t, test.expectedSources[i], right.Source)
+			}
+		})
+	}
+}
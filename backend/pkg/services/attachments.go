@@ -0,0 +1,230 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
+)
+
+const (
+	attachmentNameLength = 255
+	attachmentKeyLength  = 16
+	sniffLength          = 512
+)
+
+// AttachmentLimits bound the uploads. A type like image/* allows the whole
+// group, no types allow anything.
+type AttachmentLimits struct {
+	MaxSize int64
+	Types   []string
+}
+
+func (l AttachmentLimits) allows(contentType string) bool {
+	if len(l.Types) == 0 {
+		return true
+	}
+	for _, allowed := range l.Types {
+		if allowed == contentType {
+			return true
+		}
+		if strings.HasSuffix(allowed, "/*") &&
+			strings.HasPrefix(contentType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+type AttachmentService struct {
+	repo   repositories.Attachment
+	blobs  storage.Storage
+	limits AttachmentLimits
+	access *AccessResolver
+}
+
+func NewAttachmentService(repo repositories.Attachment, blobs storage.Storage, limits AttachmentLimits,
+	access *AccessResolver) *AttachmentService {
+	return &AttachmentService{repo: repo, blobs: blobs, limits: limits, access: access}
+}
+
+func (s *AttachmentService) GetAll(userId, projectId, boardId, listId, taskId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	attachments, err := s.repo.GetAll(taskId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"attachments": attachments})
+	return r
+}
+
+// Create stores the upload under a random key. The type is sniffed from the
+// content rather than taken from the client.
+func (s *AttachmentService) Create(userId, projectId, boardId, listId, taskId int, upload *models.AttachmentUpload) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if s.limits.MaxSize > 0 && upload.Size > s.limits.MaxSize {
+		r.Error(StatusRequestEntityTooLarge,
+			fmt.Sprintf("Attachment is larger than %d bytes", s.limits.MaxSize))
+		return r
+	}
+
+	name := strings.TrimSpace(upload.Name)
+	if name == "" {
+		r.Error(StatusBadRequest, "Invalid file name")
+		return r
+	}
+	if runes := []rune(name); len(runes) > attachmentNameLength {
+		name = string(runes[:attachmentNameLength])
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	head = head[:n]
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !s.limits.allows(contentType) {
+		r.Error(StatusUnsupportedMediaType,
+			fmt.Sprintf("Attachment type %s is not allowed", contentType))
+		return r
+	}
+
+	key, err := generateAttachmentKey(taskId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	content := io.MultiReader(bytes.NewReader(head), upload.Content)
+	if err := s.blobs.Put(key, content, upload.Size, contentType); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	attachment := &models.Attachment{
+		TaskId:      taskId,
+		UploaderId:  userId,
+		Name:        name,
+		ContentType: contentType,
+		Size:        upload.Size,
+		StorageKey:  key,
+		Created:     time.Now().Unix(),
+	}
+	attachmentId, err := s.repo.Create(attachment)
+	if err != nil {
+		s.blobs.Delete(key)
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"attachmentId": attachmentId})
+	return r
+}
+
+// Download opens the blob of the attachment, the response data is the
+// *models.AttachmentContent.
+func (s *AttachmentService) Download(userId, projectId, boardId, listId, taskId, attachmentId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	attachment, err := s.getAttachment(taskId, attachmentId)
+	if err != nil {
+		attachmentError(r, err)
+		return r
+	}
+
+	content, err := s.blobs.Get(attachment.StorageKey)
+	if err != nil {
+		attachmentError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", &models.AttachmentContent{Attachment: attachment, Content: content})
+	return r
+}
+
+// Delete removes the blob first so that a failure leaves the attachment in
+// place to retry.
+func (s *AttachmentService) Delete(userId, projectId, boardId, listId, taskId, attachmentId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	attachment, err := s.getAttachment(taskId, attachmentId)
+	if err != nil {
+		attachmentError(r, err)
+		return r
+	}
+
+	if err := s.blobs.Delete(attachment.StorageKey); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if err := s.repo.Delete(attachmentId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *AttachmentService) getAttachment(taskId, attachmentId int) (*models.Attachment, error) {
+	attachment, err := s.repo.GetById(attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskId != taskId {
+		return nil, storage.ErrNotFound
+	}
+	return attachment, nil
+}
+
+func generateAttachmentKey(taskId int) (string, error) {
+	buf := make([]byte, attachmentKeyLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("attachments/%d/%s", taskId, hex.EncodeToString(buf)), nil
+}
+
+func attachmentError(r *models.ApiResponse, err error) {
+	if err == storage.ErrNotFound || err.Error() == DbResultNotFound {
+		r.Error(StatusNotFound, "Attachment not found")
+		return
+	}
+	r.Error(StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentService_Create(t *testing.T) {
+	type args struct {
+		userId  int
+		boardId int
+		listId  int
+		taskId  int
+		upload  *models.AttachmentUpload
+	}
+	type mockBehavior func(r *mock_repositories.MockAttachment, a *mock_repositories.MockAccess, input args)
+
+	png := "\x89PNG\r\n\x1a\nimage"
+	upload := func(name, content string) *models.AttachmentUpload {
+		return &models.AttachmentUpload{Name: name, Size: int64(len(content)),
+			Content: strings.NewReader(content)}
+	}
+	canWrite := func(a *mock_repositories.MockAccess, input args) {
+		a.EXPECT().GetBoardSources(input.userId, input.boardId).
+			Return(boardSources(&models.Permission{Read: true, Write: true}), nil)
+		a.EXPECT().IsListArchived(input.listId).Return(false, nil)
+	}
+	limits := AttachmentLimits{MaxSize: 16, Types: []string{"image/*", "application/pdf"}}
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+		expectedBlobs       int
+	}{
+		{
+			name:  "Ok",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, upload: upload("screen.png", png)},
+			mock: func(r *mock_repositories.MockAttachment, a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				r.EXPECT().Create(gomock.Any()).DoAndReturn(func(attachment *models.Attachment) (int, error) {
+					assert.Equal(t, "image/png", attachment.ContentType)
+					assert.Equal(t, "screen.png", attachment.Name)
+					assert.True(t, strings.HasPrefix(attachment.StorageKey, "attachments/4/"))
+					return 5, nil
+				})
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"attachmentId": 5},
+			},
+			expectedBlobs: 1,
+		},
+		{
+			name:  "Too Large",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, upload: upload("big.png", png+png)},
+			mock: func(r *mock_repositories.MockAttachment, a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusRequestEntityTooLarge,
+			},
+		},
+		{
+			name:  "Type Not Allowed",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, upload: upload("screen.png", "plain text")},
+			mock: func(r *mock_repositories.MockAttachment, a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusUnsupportedMediaType,
+			},
+		},
+		{
+			name:  "Read Only",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, upload: upload("screen.png", png)},
+			mock: func(r *mock_repositories.MockAttachment, a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).
+					Return(boardSources(&models.Permission{Read: true}), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			root, err := ioutil.TempDir("", "attachments")
+			require.NoError(t, err)
+			defer os.RemoveAll(root)
+
+			repo := mock_repositories.NewMockAttachment(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo, test.input)
+			s := &AttachmentService{repo: repo, blobs: storage.NewLocal(root), limits: limits,
+				access: NewAccessResolver(accessRepo)}
+
+			got := s.Create(test.input.userId, 1, test.input.boardId, test.input.listId,
+				test.input.taskId, test.input.upload)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+
+			blobs, err := ioutil.ReadDir(root + "/attachments/4")
+			if test.expectedBlobs == 0 {
+				assert.True(t, os.IsNotExist(err) || len(blobs) == 0)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, blobs, test.expectedBlobs)
+			}
+		})
+	}
+}
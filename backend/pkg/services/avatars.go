@@ -0,0 +1,168 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/architectv/networking-course-project/backend/pkg/imaging"
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
+)
+
+const (
+	avatarURL         = "/api/v1/users/%d/avatar/%s"
+	avatarKey         = "avatars/%d/%s/%d.png"
+	avatarVersionSize = 16
+	avatarMaxSide     = 4096
+	DefaultAvatarSize = 128
+)
+
+// AvatarSizes are the square sizes an uploaded avatar is scaled to, from
+// the largest one.
+var AvatarSizes = []int{256, 128, 64, 32}
+
+var avatarTypes = []string{"image/png", "image/jpeg", "image/gif"}
+
+type AvatarService struct {
+	repo    repositories.User
+	blobs   storage.Storage
+	maxSize int64
+}
+
+func NewAvatarService(repo repositories.User, blobs storage.Storage, maxSize int64) *AvatarService {
+	return &AvatarService{repo: repo, blobs: blobs, maxSize: maxSize}
+}
+
+// Upload stores the image in every avatar size under a new version, which
+// becomes part of the avatar URL so that clients can cache it for good.
+func (s *AvatarService) Upload(userId int, content io.Reader) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	data, err := ioutil.ReadAll(io.LimitReader(content, s.maxSize+1))
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if int64(len(data)) > s.maxSize {
+		r.Error(StatusRequestEntityTooLarge,
+			fmt.Sprintf("Avatar is larger than %d bytes", s.maxSize))
+		return r
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !isAvatarType(contentType) {
+		r.Error(StatusUnsupportedMediaType,
+			fmt.Sprintf("Avatar type %s is not allowed", contentType))
+		return r
+	}
+
+	img, err := imaging.Decode(data, avatarMaxSide)
+	if err != nil {
+		r.Error(StatusBadRequest, err.Error())
+		return r
+	}
+
+	buf := make([]byte, avatarVersionSize)
+	if _, err := rand.Read(buf); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	version := hex.EncodeToString(buf)
+
+	// Each size is scaled from the previous one, which is cheaper than going
+	// back to the full image every time.
+	for _, size := range AvatarSizes {
+		square := imaging.Square(img, size)
+		img = square
+		data, err := imaging.EncodePNG(square)
+		if err == nil {
+			err = s.blobs.Put(fmt.Sprintf(avatarKey, userId, version, size),
+				bytes.NewReader(data), int64(len(data)), "image/png")
+		}
+		if err != nil {
+			s.deleteVersion(userId, version)
+			r.Error(StatusInternalServerError, err.Error())
+			return r
+		}
+	}
+
+	avatar := fmt.Sprintf(avatarURL, userId, version)
+	previous, err := s.repo.UpdateAvatar(userId, avatar)
+	if err != nil {
+		s.deleteVersion(userId, version)
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	// An avatar set as a plain URL has no images of ours to remove. Failing
+	// to remove the old images only leaves them unreferenced.
+	prefix := fmt.Sprintf(avatarURL, userId, "")
+	if version := strings.TrimPrefix(previous, prefix); version != previous && isAvatarVersion(version) {
+		s.deleteVersion(userId, version)
+	}
+
+	r.Set(StatusOK, "OK", Map{"avatar": avatar})
+	return r
+}
+
+// Get opens the avatar image, the response data is the io.ReadCloser.
+func (s *AvatarService) Get(userId int, version string, size int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if !isAvatarSize(size) {
+		r.Error(StatusBadRequest, "Invalid size")
+		return r
+	}
+	if !isAvatarVersion(version) {
+		r.Error(StatusNotFound, "Avatar not found")
+		return r
+	}
+
+	content, err := s.blobs.Get(fmt.Sprintf(avatarKey, userId, version, size))
+	if err != nil {
+		if err == storage.ErrNotFound {
+			r.Error(StatusNotFound, "Avatar not found")
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	r.Set(StatusOK, "OK", content)
+	return r
+}
+
+func (s *AvatarService) deleteVersion(userId int, version string) {
+	for _, size := range AvatarSizes {
+		s.blobs.Delete(fmt.Sprintf(avatarKey, userId, version, size))
+	}
+}
+
+func isAvatarVersion(version string) bool {
+	_, err := hex.DecodeString(version)
+	return err == nil && len(version) == 2*avatarVersionSize
+}
+
+func isAvatarType(contentType string) bool {
+	for _, avatarType := range avatarTypes {
+		if contentType == avatarType {
+			return true
+		}
+	}
+	return false
+}
+
+func isAvatarSize(size int) bool {
+	for _, avatarSize := range AvatarSizes {
+		if size == avatarSize {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvatarService_Upload(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 300, 200))))
+	picture := buf.String()
+	previousVersion := strings.Repeat("ab", avatarVersionSize)
+
+	type mockBehavior func(r *mock_repositories.MockUser, userId int)
+
+	tests := []struct {
+		name                string
+		content             string
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:    "Ok",
+			content: picture,
+			mock: func(r *mock_repositories.MockUser, userId int) {
+				r.EXPECT().UpdateAvatar(userId, gomock.Any()).
+					Return(fmt.Sprintf(avatarURL, userId, previousVersion), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+			},
+		},
+		{
+			name:    "Too Large",
+			content: picture + strings.Repeat(" ", 1<<16),
+			mock:    func(r *mock_repositories.MockUser, userId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusRequestEntityTooLarge,
+			},
+		},
+		{
+			name:    "Not An Image",
+			content: "plain text",
+			mock:    func(r *mock_repositories.MockUser, userId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusUnsupportedMediaType,
+			},
+		},
+		{
+			name:    "Broken Image",
+			content: picture[:64],
+			mock:    func(r *mock_repositories.MockUser, userId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			root, err := ioutil.TempDir("", "avatars")
+			require.NoError(t, err)
+			defer os.RemoveAll(root)
+
+			blobs := storage.NewLocal(root)
+			for _, size := range AvatarSizes {
+				key := fmt.Sprintf(avatarKey, 1, previousVersion, size)
+				require.NoError(t, blobs.Put(key, strings.NewReader(""), 0, "image/png"))
+			}
+
+			repo := mock_repositories.NewMockUser(c)
+			test.mock(repo, 1)
+			s := &AvatarService{repo: repo, blobs: blobs, maxSize: 1 << 16}
+
+			got := s.Upload(1, strings.NewReader(test.content))
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+
+			_, err = blobs.Get(fmt.Sprintf(avatarKey, 1, previousVersion, DefaultAvatarSize))
+			if test.expectedApiResponse.Code != StatusOK {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, storage.ErrNotFound, err)
+
+			avatar := got.Data.(Map)["avatar"].(string)
+			version := strings.TrimPrefix(avatar, fmt.Sprintf(avatarURL, 1, ""))
+			assert.True(t, isAvatarVersion(version))
+			for _, size := range AvatarSizes {
+				content, err := blobs.Get(fmt.Sprintf(avatarKey, 1, version, size))
+				require.NoError(t, err)
+				img, err := png.Decode(content)
+				content.Close()
+				require.NoError(t, err)
+				assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+			}
+		})
+	}
+}
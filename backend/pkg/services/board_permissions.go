@@ -1,41 +1,38 @@
 package services
 
 import (
+	"time"
+
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 	"github.com/architectv/networking-course-project/backend/pkg/repositories"
 )
 
 type BoardPermsService struct {
-	repo        repositories.ObjectPerms
-	boardRepo   repositories.Board
-	projectRepo repositories.Project
+	repo           repositories.ObjectPerms
+	boardRepo      repositories.Board
+	projectRepo    repositories.Project
+	groupRepo      repositories.Group
+	invitationRepo repositories.Invitation
+	access         *AccessResolver
 }
 
 func NewBoardPermsService(repo repositories.ObjectPerms, boardRepo repositories.Board,
-	projectRepo repositories.Project) *BoardPermsService {
-	return &BoardPermsService{repo: repo, boardRepo: boardRepo, projectRepo: projectRepo}
+	projectRepo repositories.Project, groupRepo repositories.Group, invitationRepo repositories.Invitation,
+	access *AccessResolver) *BoardPermsService {
+	return &BoardPermsService{repo: repo, boardRepo: boardRepo, projectRepo: projectRepo, groupRepo: groupRepo,
+		invitationRepo: invitationRepo, access: access}
 }
 
 func (s *BoardPermsService) Get(userId, projectId, boardId, memberId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	_, err := s.repo.GetById(projectId, userId, IsProject)
+	permissions, err := s.access.BoardPermissions(userId, boardId)
 	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not project member")
-			return r
-		}
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Board not found")
 		return r
 	}
-
-	_, err = s.repo.GetById(boardId, userId, IsBoard)
-	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not board member")
-			return r
-		}
-		r.Error(StatusInternalServerError, err.Error())
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
 		return r
 	}
 
@@ -49,7 +46,7 @@ func (s *BoardPermsService) Get(userId, projectId, boardId, memberId int) *model
 		return r
 	}
 
-	permissions, err := s.repo.GetById(boardId, memberId, IsBoard)
+	permissions, err = s.repo.GetById(boardId, memberId, IsBoard)
 	if err != nil {
 		if err.Error() == DbResultNotFound {
 			r.Error(StatusNotFound, "Board member not found")
@@ -63,6 +60,8 @@ func (s *BoardPermsService) Get(userId, projectId, boardId, memberId int) *model
 	return r
 }
 
+// Create invites a project member to the board, who joins it once the
+// invitation is accepted.
 func (s *BoardPermsService) Create(userId, projectId, boardId int, memberNickname string, boardPerms *models.Permission) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
@@ -70,7 +69,7 @@ func (s *BoardPermsService) Create(userId, projectId, boardId int, memberNicknam
 		if err.Error() == ErrPermsIsNotDefined {
 			board, err := s.boardRepo.GetById(boardId)
 			if err != nil {
-				r.Error(StatusInternalServerError, err.Error())
+				boardPermsError(r, err, "Board not found")
 				return r
 			}
 			if board.DefaultPermissions == nil {
@@ -84,22 +83,13 @@ func (s *BoardPermsService) Create(userId, projectId, boardId int, memberNicknam
 		}
 	}
 
-	_, err := s.repo.GetById(projectId, userId, IsProject)
+	permissions, err := s.access.BoardPermissions(userId, boardId)
 	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not project member")
-			return r
-		}
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Board not found")
 		return r
 	}
-	permissions, err := s.repo.GetById(boardId, userId, IsBoard)
-	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not board member")
-			return r
-		}
-		r.Error(StatusInternalServerError, err.Error())
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
 		return r
 	}
 
@@ -118,35 +108,26 @@ func (s *BoardPermsService) Create(userId, projectId, boardId int, memberNicknam
 		return r
 	}
 
-	permissionsId, err := s.repo.Create(boardId, IsBoard, memberNickname, boardPerms)
-	if err != nil {
-		r.Error(StatusInternalServerError, err.Error())
-		return r
+	invitation := &models.Invitation{
+		ProjectId:   projectId,
+		BoardId:     boardId,
+		InviterId:   userId,
+		Nickname:    memberNickname,
+		Permissions: boardPerms,
 	}
-
-	r.Set(StatusOK, "OK", Map{"Board permissions id": permissionsId})
-	return r
+	return createInvitation(s.invitationRepo, invitation)
 }
 
 func (s *BoardPermsService) Delete(userId, projectId, boardId, memberId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	_, err := s.repo.GetById(projectId, userId, IsProject)
+	permissions, err := s.access.BoardPermissions(userId, boardId)
 	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not project member")
-			return r
-		}
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Board not found")
 		return r
 	}
-	permissions, err := s.repo.GetById(boardId, userId, IsBoard)
-	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not board member")
-			return r
-		}
-		r.Error(StatusInternalServerError, err.Error())
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
 		return r
 	}
 
@@ -177,7 +158,7 @@ func (s *BoardPermsService) Delete(userId, projectId, boardId, memberId int) *mo
 
 	project, err := s.projectRepo.GetById(projectId)
 	if err != nil {
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Project not found")
 		return r
 	}
 	if project.OwnerId == memberId {
@@ -188,7 +169,7 @@ func (s *BoardPermsService) Delete(userId, projectId, boardId, memberId int) *mo
 	var projectOwnerId int
 	board, err := s.boardRepo.GetById(boardId)
 	if err != nil {
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Board not found")
 		return r
 	}
 	if board.OwnerId == memberId {
@@ -201,7 +182,7 @@ func (s *BoardPermsService) Delete(userId, projectId, boardId, memberId int) *mo
 	}
 	err = s.repo.Delete(boardId, memberId, projectOwnerId, IsBoard)
 	if err != nil {
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Board member not found")
 		return r
 	}
 
@@ -217,22 +198,13 @@ func (s *BoardPermsService) Update(userId, projectId, boardId, memberId int, boa
 		return r
 	}
 
-	_, err := s.repo.GetById(projectId, userId, IsProject)
+	permissions, err := s.access.BoardPermissions(userId, boardId)
 	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not project member")
-			return r
-		}
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Board not found")
 		return r
 	}
-	permissions, err := s.repo.GetById(boardId, userId, IsBoard)
-	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not board member")
-			return r
-		}
-		r.Error(StatusInternalServerError, err.Error())
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
 		return r
 	}
 
@@ -263,7 +235,7 @@ func (s *BoardPermsService) Update(userId, projectId, boardId, memberId int, boa
 
 	project, err := s.projectRepo.GetById(projectId)
 	if err != nil {
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Project not found")
 		return r
 	}
 	if project.OwnerId == memberId {
@@ -274,7 +246,7 @@ func (s *BoardPermsService) Update(userId, projectId, boardId, memberId int, boa
 	var projectOwnerId int
 	board, err := s.boardRepo.GetById(boardId)
 	if err != nil {
-		r.Error(StatusInternalServerError, err.Error())
+		boardPermsError(r, err, "Board not found")
 		return r
 	}
 	if board.OwnerId == memberId {
@@ -287,6 +259,226 @@ func (s *BoardPermsService) Update(userId, projectId, boardId, memberId int, boa
 	}
 	err = s.repo.Update(boardId, memberId, projectOwnerId, IsBoard, boardPerms)
 	if err != nil {
+		boardPermsError(r, err, "Board member not found")
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *BoardPermsService) GetEffective(userId, projectId, boardId, memberId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
+		return r
+	}
+
+	effective, err := s.access.Board(memberId, boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"effectivePermissions": effective})
+	return r
+}
+
+func (s *BoardPermsService) Deny(userId, projectId, boardId, memberId int, denied *models.Permission) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if denied == nil || denied.Read == false && denied.Write == false && denied.Admin == false {
+		r.Error(StatusBadRequest, ErrPermsIsNotDefined)
+		return r
+	}
+	if denied.Read {
+		denied.Write = true
+	}
+	if denied.Write {
+		denied.Admin = true
+	}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
+		return r
+	}
+	if permissions.Admin != true {
+		r.Error(StatusForbidden, "Request author is not board admin")
+		return r
+	}
+
+	_, err = s.repo.GetById(projectId, memberId, IsProject)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Denied user is not project member")
+			return r
+		}
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	project, err := s.projectRepo.GetById(projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	board, err := s.boardRepo.GetById(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if project.OwnerId == memberId || board.OwnerId == memberId {
+		r.Error(StatusBadRequest, "You can't deny permissions to the owner")
+		return r
+	}
+
+	if err = s.repo.SetBoardDeny(boardId, memberId, denied); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *BoardPermsService) DeleteDeny(userId, projectId, boardId, memberId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
+		return r
+	}
+	if permissions.Admin != true {
+		r.Error(StatusForbidden, "Request author is not board admin")
+		return r
+	}
+
+	if err = s.repo.DeleteBoardDeny(boardId, memberId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *BoardPermsService) GetForGroup(userId, projectId, boardId, groupId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.groupRepo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	permissions, err = s.repo.GetByGroupId(boardId, groupId, IsBoard)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Group has no permissions in the board")
+			return r
+		}
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"permissions": permissions})
+	return r
+}
+
+func (s *BoardPermsService) CreateForGroup(userId, projectId, boardId, groupId int, boardPerms *models.Permission) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if err := permsValidation(boardPerms); err != nil {
+		r.Error(StatusBadRequest, err.Error())
+		return r
+	}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
+		return r
+	}
+	if permissions.Admin != true {
+		r.Error(StatusForbidden, "Request author is not board admin")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.groupRepo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	permissionsId, err := s.repo.CreateForGroup(boardId, IsBoard, groupId, boardPerms)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"Board permissions id": permissionsId})
+	return r
+}
+
+func (s *BoardPermsService) UpdateForGroup(userId, projectId, boardId, groupId int, boardPerms *models.UpdatePermission) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if err := updatePermsValidation(boardPerms); err != nil {
+		r.Error(StatusBadRequest, err.Error())
+		return r
+	}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
+		return r
+	}
+	if permissions.Admin != true {
+		r.Error(StatusForbidden, "Request author is not board admin")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.groupRepo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	if err = s.repo.UpdateForGroup(boardId, groupId, IsBoard, boardPerms); err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Group has no permissions in the board")
+			return r
+		}
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
@@ -294,3 +486,103 @@ func (s *BoardPermsService) Update(userId, projectId, boardId, memberId int, boa
 	r.Set(StatusOK, "OK", Map{})
 	return r
 }
+
+func (s *BoardPermsService) DeleteForGroup(userId, projectId, boardId, groupId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not board member")
+		return r
+	}
+	if permissions.Admin != true {
+		r.Error(StatusForbidden, "Request author is not board admin")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.groupRepo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	if err = s.repo.DeleteForGroup(boardId, groupId, IsBoard); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *BoardPermsService) TransferOwnership(userId, projectId, boardId int, input *models.TransferOwnership) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if input.OldOwnerPermissions != nil {
+		if err := permsValidation(input.OldOwnerPermissions); err != nil {
+			r.Error(StatusBadRequest, err.Error())
+			return r
+		}
+	}
+
+	board, err := s.boardRepo.GetById(boardId)
+	if err != nil {
+		boardPermsError(r, err, "Board not found")
+		return r
+	}
+	project, err := s.projectRepo.GetById(projectId)
+	if err != nil {
+		boardPermsError(r, err, "Project not found")
+		return r
+	}
+	if board.OwnerId != userId && project.OwnerId != userId {
+		r.Error(StatusForbidden, "Only board or project owner can transfer the board")
+		return r
+	}
+	if input.NewOwnerId == board.OwnerId {
+		r.Error(StatusBadRequest, "User already owns the board")
+		return r
+	}
+
+	// The new owner has to see the board, which a project member may not
+	// when the board denies them.
+	permissions, err := s.access.BoardPermissions(input.NewOwnerId, boardId)
+	if err != nil {
+		boardPermsError(r, err, "Board not found")
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "New owner is not board member")
+		return r
+	}
+
+	transfer := &models.OwnershipTransfer{
+		ProjectId:  projectId,
+		BoardId:    boardId,
+		OldOwnerId: board.OwnerId,
+		NewOwnerId: input.NewOwnerId,
+		AuthorId:   userId,
+		Created:    time.Now().Unix(),
+	}
+	transferId, err := s.repo.TransferOwnership(boardId, IsBoard, transfer, input.OldOwnerPermissions)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"transferId": transferId})
+	return r
+}
+
+// boardPermsError answers 404 when the board, its project or the member is
+// gone by the time it is looked up.
+func boardPermsError(r *models.ApiResponse, err error, message string) {
+	if err.Error() == DbResultNotFound {
+		r.Error(StatusNotFound, message)
+		return
+	}
+	r.Error(StatusInternalServerError, err.Error())
+}
@@ -25,17 +25,15 @@ func TestBoardPermsService_Create(t *testing.T) {
 	}
 
 	type boardMockBehavior func(r *mock_repositories.MockBoard, boardId int)
-	type getCallerProjectPermMockBehavior func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int)
-	type getCallerBoardPermMockBehavior func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int)
+	type getCallerPermMockBehavior func(r *mock_repositories.MockAccess, userId, boardId int)
 	type getMemberProjectPermMockBehavior func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string)
-	type mockBehavior func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission)
+	type mockBehavior func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission)
 
 	tests := []struct {
 		name                 string
 		input                args
 		boardMock            boardMockBehavior
-		getCallerProjectPerm getCallerProjectPermMockBehavior
-		getCallerBoardPerm   getCallerBoardPermMockBehavior
+		getCallerPerm        getCallerPermMockBehavior
 		getMemberProjectPerm getMemberProjectPermMockBehavior
 		mock                 mockBehavior
 		expectedApiResponse  *models.ApiResponse
@@ -53,21 +51,18 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       builders.NewPermsBuilder().Build(),
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(&models.Permission{true, true, true}, nil)
-			},
-			getCallerBoardPerm: func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {
-				r.EXPECT().GetById(boardId, userId, boardType).Return(&models.Permission{true, true, true}, nil)
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {
 				r.EXPECT().GetByNickname(projectId, projectType, memberNickname).Return(&models.Permission{true, true, false}, nil)
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
-				r.EXPECT().Create(boardId, objectType, memberNickname, permissions).Return(1, nil)
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
+				r.EXPECT().Create(invitationMatcher{1, boardId, userId, memberNickname, permissions}).Return(1, nil)
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusOK,
-				Data: Map{"Board permissions id": 1},
+				Data: Map{"invitationId": 1},
 			},
 		},
 		{
@@ -84,23 +79,20 @@ func TestBoardPermsService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
-			},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(&models.Permission{true, true, true}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
-			getCallerBoardPerm: func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {
-				r.EXPECT().GetById(boardId, userId, boardType).Return(&models.Permission{true, true, true}, nil)
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {
 				r.EXPECT().GetByNickname(projectId, projectType, memberNickname).Return(&models.Permission{true, true, false}, nil)
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
-				r.EXPECT().Create(boardId, objectType, memberNickname, permissions).Return(1, nil)
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
+				r.EXPECT().Create(invitationMatcher{1, boardId, userId, memberNickname, permissions}).Return(1, nil)
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusOK,
-				Data: Map{"Board permissions id": 1},
+				Data: Map{"invitationId": 1},
 			},
 		},
 		{
@@ -118,10 +110,9 @@ func TestBoardPermsService_Create(t *testing.T) {
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(nil, errors.New("some error"))
 			},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {},
-			getCallerBoardPerm:   func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {},
+			getCallerPerm:        func(r *mock_repositories.MockAccess, userId, boardId int) {},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -141,12 +132,11 @@ func TestBoardPermsService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, nil,
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {},
-			getCallerBoardPerm:   func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {},
+			getCallerPerm:        func(r *mock_repositories.MockAccess, userId, boardId int) {},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -165,10 +155,9 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       nil,
 			},
 			boardMock:            func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {},
-			getCallerBoardPerm:   func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {},
+			getCallerPerm:        func(r *mock_repositories.MockAccess, userId, boardId int) {},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusBadRequest,
@@ -187,19 +176,18 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       nil,
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(nil, errors.New(DbResultNotFound))
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
 			},
-			getCallerBoardPerm:   func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusNotFound,
 			},
 		},
 		{
-			name: "Repo error for Get in caller ProjectPerms",
+			name: "Repo error for caller permissions",
 			input: args{
 				userId:         1,
 				projectId:      1,
@@ -211,12 +199,11 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       nil,
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(nil, errors.New("Some error"))
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(nil, errors.New("Some error"))
 			},
-			getCallerBoardPerm:   func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -235,45 +222,16 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       nil,
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(&models.Permission{true, true, true}, nil)
-			},
-			getCallerBoardPerm: func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {
-				r.EXPECT().GetById(boardId, userId, boardType).Return(nil, errors.New(DbResultNotFound))
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{false, false, false}), nil)
 			},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusNotFound,
 			},
 		},
-		{
-			name: "Repo error for Get in caller BoardPerms",
-			input: args{
-				userId:         1,
-				projectId:      1,
-				boardId:        1,
-				memberNickname: "second_user",
-				boardType:      IsBoard,
-				projectType:    IsProject,
-				perms:          builders.NewPermsBuilder().Build(),
-				defPerms:       nil,
-			},
-			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(&models.Permission{true, true, true}, nil)
-			},
-			getCallerBoardPerm: func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {
-				r.EXPECT().GetById(boardId, userId, boardType).Return(nil, errors.New("Some error"))
-			},
-			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
-			},
-			expectedApiResponse: &models.ApiResponse{
-				Code: StatusInternalServerError,
-			},
-		},
 		{
 			name: "Request author is not board admin",
 			input: args{
@@ -287,14 +245,11 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       nil,
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(&models.Permission{true, true, true}, nil)
-			},
-			getCallerBoardPerm: func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {
-				r.EXPECT().GetById(boardId, userId, boardType).Return(&models.Permission{true, true, false}, nil)
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, false}), nil)
 			},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
@@ -313,16 +268,13 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       nil,
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(&models.Permission{true, true, true}, nil)
-			},
-			getCallerBoardPerm: func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {
-				r.EXPECT().GetById(boardId, userId, boardType).Return(&models.Permission{true, true, true}, nil)
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {
 				r.EXPECT().GetByNickname(projectId, projectType, memberNickname).Return(nil, errors.New(DbResultNotFound))
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusNotFound,
@@ -341,16 +293,13 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       nil,
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(&models.Permission{true, true, true}, nil)
-			},
-			getCallerBoardPerm: func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {
-				r.EXPECT().GetById(boardId, userId, boardType).Return(&models.Permission{true, true, true}, nil)
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {
 				r.EXPECT().GetByNickname(projectId, projectType, memberNickname).Return(nil, errors.New("Some error"))
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -370,17 +319,14 @@ func TestBoardPermsService_Create(t *testing.T) {
 				defPerms:       builders.NewPermsBuilder().Build(),
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {},
-			getCallerProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, userId, projectType int) {
-				r.EXPECT().GetById(projectId, userId, projectType).Return(&models.Permission{true, true, true}, nil)
-			},
-			getCallerBoardPerm: func(r *mock_repositories.MockObjectPerms, boardId, userId, boardType int) {
-				r.EXPECT().GetById(boardId, userId, boardType).Return(&models.Permission{true, true, true}, nil)
+			getCallerPerm: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			getMemberProjectPerm: func(r *mock_repositories.MockObjectPerms, projectId, projectType int, memberNickname string) {
 				r.EXPECT().GetByNickname(projectId, projectType, memberNickname).Return(&models.Permission{true, true, false}, nil)
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, boardId, objectType int, memberNickname string, permissions *models.Permission) {
-				r.EXPECT().Create(boardId, objectType, memberNickname, permissions).Return(0, errors.New("Some error"))
+			mock: func(r *mock_repositories.MockInvitation, boardId, userId int, memberNickname string, permissions *models.Permission) {
+				r.EXPECT().Create(invitationMatcher{1, boardId, userId, memberNickname, permissions}).Return(0, errors.New("Some error"))
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -396,14 +342,16 @@ func TestBoardPermsService_Create(t *testing.T) {
 			repo := mock_repositories.NewMockObjectPerms(c)
 			projectRepo := mock_repositories.NewMockProject(c)
 			boardRepo := mock_repositories.NewMockBoard(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			invitationRepo := mock_repositories.NewMockInvitation(c)
 
 			test.boardMock(boardRepo, test.input.boardId)
-			test.getCallerProjectPerm(repo, test.input.projectId, test.input.userId, test.input.projectType)
-			test.getCallerBoardPerm(repo, test.input.boardId, test.input.userId, test.input.boardType)
+			test.getCallerPerm(accessRepo, test.input.userId, test.input.boardId)
 			test.getMemberProjectPerm(repo, test.input.projectId, test.input.projectType, test.input.memberNickname)
-			test.mock(repo, test.input.boardId, test.input.boardType, test.input.memberNickname,
+			test.mock(invitationRepo, test.input.boardId, test.input.userId, test.input.memberNickname,
 				test.input.defPerms)
-			s := &BoardPermsService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &BoardPermsService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo,
+				invitationRepo: invitationRepo, access: NewAccessResolver(accessRepo)}
 
 			got := s.Create(test.input.userId, test.input.projectId, test.input.boardId,
 				test.input.memberNickname, test.input.perms)
@@ -414,3 +362,116 @@ func TestBoardPermsService_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestBoardPermsService_NotFound(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repositories.NewMockObjectPerms(c)
+	boardRepo := mock_repositories.NewMockBoard(c)
+	projectRepo := mock_repositories.NewMockProject(c)
+	accessRepo := mock_repositories.NewMockAccess(c)
+	s := &BoardPermsService{repo: repo, boardRepo: boardRepo, projectRepo: projectRepo,
+		access: NewAccessResolver(accessRepo)}
+
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(nil, errors.New(DbResultNotFound))
+	got := s.Get(1, 1, 2, 3)
+	assert.Equal(t, StatusNotFound, got.Code)
+
+	read := true
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{true, true, true}), nil)
+	repo.EXPECT().GetById(1, 3, IsProject).Return(&models.Permission{Read: true}, nil)
+	repo.EXPECT().GetById(2, 3, IsBoard).Return(&models.Permission{Read: true}, nil)
+	projectRepo.EXPECT().GetById(1).Return(&models.Project{OwnerId: 1}, nil)
+	boardRepo.EXPECT().GetById(2).Return(nil, errors.New(DbResultNotFound))
+	got = s.Update(1, 1, 2, 3, &models.UpdatePermission{Read: &read})
+	assert.Equal(t, StatusNotFound, got.Code)
+
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{true, true, true}), nil)
+	repo.EXPECT().GetById(1, 3, IsProject).Return(&models.Permission{Read: true}, nil)
+	repo.EXPECT().GetById(2, 3, IsBoard).Return(&models.Permission{Read: true}, nil)
+	projectRepo.EXPECT().GetById(1).Return(nil, errors.New(DbResultNotFound))
+	got = s.Delete(1, 1, 2, 3)
+	assert.Equal(t, StatusNotFound, got.Code)
+
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(nil, errors.New(DbResultNotFound))
+	got = s.Create(1, 1, 2, "member", &models.Permission{Read: true})
+	assert.Equal(t, StatusNotFound, got.Code)
+
+	boardRepo.EXPECT().GetById(2).Return(nil, errors.New(DbResultNotFound))
+	got = s.Create(1, 1, 2, "member", &models.Permission{})
+	assert.Equal(t, StatusNotFound, got.Code)
+}
+
+func TestBoardPermsService_TransferOwnership(t *testing.T) {
+	type mockBehavior func(r *mock_repositories.MockObjectPerms, b *mock_repositories.MockBoard,
+		p *mock_repositories.MockProject, a *mock_repositories.MockAccess)
+
+	input := &models.TransferOwnership{NewOwnerId: 3}
+	board := &models.Board{Id: 2, ProjectId: 1, OwnerId: 1}
+
+	tests := []struct {
+		name         string
+		mock         mockBehavior
+		expectedCode int
+	}{
+		{
+			name: "Ok",
+			mock: func(r *mock_repositories.MockObjectPerms, b *mock_repositories.MockBoard,
+				p *mock_repositories.MockProject, a *mock_repositories.MockAccess) {
+				b.EXPECT().GetById(2).Return(board, nil)
+				p.EXPECT().GetById(1).Return(&models.Project{Id: 1, OwnerId: 4}, nil)
+				a.EXPECT().GetBoardSources(3, 2).Return(boardSources(&models.Permission{Read: true}), nil)
+				r.EXPECT().TransferOwnership(2, IsBoard, gomock.Any(), nil).Return(5, nil)
+			},
+			expectedCode: StatusOK,
+		},
+		{
+			name: "Board Not Found",
+			mock: func(r *mock_repositories.MockObjectPerms, b *mock_repositories.MockBoard,
+				p *mock_repositories.MockProject, a *mock_repositories.MockAccess) {
+				b.EXPECT().GetById(2).Return(nil, errors.New(DbResultNotFound))
+			},
+			expectedCode: StatusNotFound,
+		},
+		{
+			name: "Project Not Found",
+			mock: func(r *mock_repositories.MockObjectPerms, b *mock_repositories.MockBoard,
+				p *mock_repositories.MockProject, a *mock_repositories.MockAccess) {
+				b.EXPECT().GetById(2).Return(board, nil)
+				p.EXPECT().GetById(1).Return(nil, errors.New(DbResultNotFound))
+			},
+			expectedCode: StatusNotFound,
+		},
+		{
+			name: "New Owner Denied",
+			mock: func(r *mock_repositories.MockObjectPerms, b *mock_repositories.MockBoard,
+				p *mock_repositories.MockProject, a *mock_repositories.MockAccess) {
+				b.EXPECT().GetById(2).Return(board, nil)
+				p.EXPECT().GetById(1).Return(&models.Project{Id: 1, OwnerId: 4}, nil)
+				sources := boardSources(&models.Permission{Read: true})
+				sources.BoardDeny = &models.Permission{Read: true, Write: true, Admin: true}
+				a.EXPECT().GetBoardSources(3, 2).Return(sources, nil)
+			},
+			expectedCode: StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockObjectPerms(c)
+			boardRepo := mock_repositories.NewMockBoard(c)
+			projectRepo := mock_repositories.NewMockProject(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, boardRepo, projectRepo, accessRepo)
+			s := &BoardPermsService{repo: repo, boardRepo: boardRepo, projectRepo: projectRepo,
+				access: NewAccessResolver(accessRepo)}
+
+			got := s.TransferOwnership(1, 1, 2, input)
+			assert.Equal(t, test.expectedCode, got.Code)
+		})
+	}
+}
@@ -7,35 +7,54 @@ import (
 )
 
 type BoardService struct {
-	repo        repositories.Board
-	projectRepo repositories.Project
+	repo         repositories.Board
+	templateRepo repositories.Template
+	access       *AccessResolver
+	notifier     *Notifier
 }
 
-func NewBoardService(repo repositories.Board, projectRepo repositories.Project) *BoardService {
-	return &BoardService{repo: repo, projectRepo: projectRepo}
+func NewBoardService(repo repositories.Board, templateRepo repositories.Template, access *AccessResolver,
+	notifier *Notifier) *BoardService {
+	return &BoardService{repo: repo, templateRepo: templateRepo, access: access, notifier: notifier}
 }
 
-func (s *BoardService) GetAll(userId, projectId int) *models.ApiResponse {
+func (s *BoardService) GetAll(userId, projectId int, archived bool) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	permissions, err := s.projectRepo.GetPermissions(userId, projectId)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boards, err := s.repo.GetAll(userId, projectId)
+	allBoards, err := s.repo.GetAll(projectId)
 	if err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
 
+	access, err := s.access.Boards(userId, projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	boards := make([]*models.Board, 0, len(allBoards))
+	for _, board := range allBoards {
+		if board.Archived && !archived {
+			continue
+		}
+		if effective, ok := access[board.Id]; ok && effective.Permissions.Read {
+			boards = append(boards, board)
+		}
+	}
+
 	r.Set(StatusOK, "OK", Map{"boards": boards})
 	return r
 }
 
 func (s *BoardService) Create(userId, projectId int, board *models.Board) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	permissions, err := s.projectRepo.GetPermissions(userId, projectId)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
@@ -59,27 +78,95 @@ func (s *BoardService) Create(userId, projectId int, board *models.Board) *model
 		}
 	}
 
-	boardId, err := s.repo.Create(userId, board)
+	var boardId int
+	if board.TemplateId != 0 {
+		template, err := s.templateRepo.GetById(board.TemplateId)
+		if err == nil && template.ProjectId != projectId {
+			r.Error(StatusNotFound, "Template not found")
+			return r
+		}
+		if err != nil {
+			templateError(r, err)
+			return r
+		}
+		boardId, err = s.repo.CreateWithContent(userId, board, template.Content)
+	} else {
+		boardId, err = s.repo.Create(userId, board)
+	}
 	if err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
+	s.notifier.Watch(userId, models.WatchBoard, boardId)
+	s.notifier.Notify(&models.Event{Type: models.EventBoardCreated, ActorId: userId,
+		ProjectId: projectId, BoardId: boardId})
 
 	r.Set(StatusOK, "OK", Map{"boardId": boardId})
 	return r
 }
 
-func (s *BoardService) GetById(userId, projectId, boardId int) *models.ApiResponse {
+// Clone copies the board with its lists and labels, and with its tasks on
+// request, into the same or another project the user can read.
+func (s *BoardService) Clone(userId, projectId, boardId int, input *models.CloneBoard) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	// TODO: права админов и автора проекта
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boardPermissions, err := s.repo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	targetId := projectId
+	if input.ProjectId != 0 {
+		targetId = input.ProjectId
+	}
+	permissions, err = s.access.ProjectPermissions(userId, targetId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	source, err := s.repo.GetById(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	content, err := s.repo.GetContent(boardId, input.WithTasks)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	curTime := time.Now().Unix()
+	board := &models.Board{
+		ProjectId:          targetId,
+		OwnerId:            userId,
+		DefaultPermissions: source.DefaultPermissions,
+		Datetimes: &models.Datetimes{
+			Created:  curTime,
+			Updated:  curTime,
+			Accessed: curTime,
+		},
+		Title: source.Title,
+	}
+	if input.Title != "" {
+		board.Title = input.Title
+	}
+
+	cloneId, err := s.repo.CreateWithContent(userId, board, content)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"boardId": cloneId})
+	return r
+}
+
+func (s *BoardService) GetById(userId, projectId, boardId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -96,6 +183,13 @@ func (s *BoardService) GetById(userId, projectId, boardId int) *models.ApiRespon
 
 func (s *BoardService) Delete(userId, projectId, boardId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
+	// Archived boards have no write access, so they can't be deleted until
+	// they are unarchived.
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
 
 	board, err := s.repo.GetById(boardId)
 	if err != nil {
@@ -120,14 +214,8 @@ func (s *BoardService) Delete(userId, projectId, boardId int) *models.ApiRespons
 
 func (s *BoardService) Update(userId, projectId, boardId int, board *models.UpdateBoard) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.repo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Write == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -147,27 +235,70 @@ func (s *BoardService) Update(userId, projectId, boardId int, board *models.Upda
 	return r
 }
 
+func (s *BoardService) SetArchived(userId, projectId, boardId int, archived bool) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err = s.repo.SetArchived(boardId, archived); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
 func (s *BoardService) GetMembers(userId, projectId, boardId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boardPermissions, err := s.repo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
+	candidates, err := s.repo.GetMembers(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
 
-	members, err := s.repo.GetMembers(boardId)
+	// Project members without board grants are listed with the permissions
+	// they inherit, as long as these let them read the board.
+	inheriting := make([]int, 0)
+	for _, member := range candidates {
+		if member.Permissions == nil {
+			inheriting = append(inheriting, member.Id)
+		}
+	}
+	inherited, err := s.access.BoardUsers(boardId, inheriting)
 	if err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
 
+	members := make([]*models.Member, 0, len(candidates))
+	for _, member := range candidates {
+		switch {
+		case member.Permissions == nil:
+			effective, ok := inherited[member.Id]
+			if !ok || effective.Permissions.Read == false {
+				continue
+			}
+			member.Permissions = effective.Permissions
+			member.Source = effective.Rights[0].Source
+		case member.IsDirect:
+			member.Source = SourceBoardAllow
+		default:
+			member.Source = SourceBoardGroup
+		}
+		members = append(members, member)
+	}
+
 	r.Set(StatusOK, "OK", Map{"members": members})
 	return r
 }
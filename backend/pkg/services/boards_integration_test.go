@@ -27,8 +27,7 @@ func Test_Integration_BoardService_Create(t *testing.T) {
 	}
 
 	repo := postgres.NewBoardPg(db)
-	projectRepo := postgres.NewProjectPg(db)
-	s := NewBoardService(repo, projectRepo)
+	s := NewBoardService(repo, postgres.NewTemplatePg(db), NewAccessResolver(postgres.NewAccessPg(db)), nil)
 
 	tests := []struct {
 		name                string
@@ -18,13 +18,13 @@ func TestBoardService_Create(t *testing.T) {
 		projectId int
 		board     *models.Board
 	}
-	type projectMockBehavior func(r *mock_repositories.MockProject, userId, projectId int)
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, projectId int)
 	type mockBehavior func(r *mock_repositories.MockBoard, userId int, board *models.Board)
 
 	tests := []struct {
 		name                string
 		input               args
-		projectMock         projectMockBehavior
+		accessMock          accessMockBehavior
 		mock                mockBehavior
 		expectedApiResponse *models.ApiResponse
 	}{
@@ -48,8 +48,8 @@ func TestBoardService_Create(t *testing.T) {
 				// },
 				board: builders.NewBoardBuilder().WithTitle("Board Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockBoard, userId int, board *models.Board) {
 				r.EXPECT().Create(userId, board).Return(1, nil)
@@ -65,8 +65,8 @@ func TestBoardService_Create(t *testing.T) {
 				userId: 1,
 				board:  builders.NewBoardBuilder().WithTitle("Board Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(nil), nil)
 			},
 			mock: func(r *mock_repositories.MockBoard, userId int, board *models.Board) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -79,8 +79,8 @@ func TestBoardService_Create(t *testing.T) {
 				userId: 1,
 				board:  builders.NewBoardBuilder().WithTitle("Board Builder").WithoutPerm().Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockBoard, userId int, board *models.Board) {
 				r.EXPECT().Create(userId, board).Return(1, nil)
@@ -96,8 +96,8 @@ func TestBoardService_Create(t *testing.T) {
 				userId: 1,
 				board:  builders.NewBoardBuilder().WithTitle("Board Builder").WithoutPerm().Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockBoard, userId int, board *models.Board) {
 				r.EXPECT().Create(userId, board).Return(0, errors.New("repo error"))
@@ -114,10 +114,10 @@ func TestBoardService_Create(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockBoard(c)
-			projectRepo := mock_repositories.NewMockProject(c)
-			test.projectMock(projectRepo, test.input.userId, test.input.projectId)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.projectId)
 			test.mock(repo, test.input.userId, test.input.board)
-			s := &BoardService{repo: repo, projectRepo: projectRepo}
+			s := &BoardService{repo: repo, access: NewAccessResolver(accessRepo)}
 
 			got := s.Create(test.input.userId, test.input.projectId, test.input.board)
 			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
@@ -127,3 +127,104 @@ func TestBoardService_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestBoardService_GetMembers(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repositories.NewMockBoard(c)
+	accessRepo := mock_repositories.NewMockAccess(c)
+	s := &BoardService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{Read: true}), nil)
+	repo.EXPECT().GetMembers(2).Return([]*models.Member{
+		{Id: 1, IsDirect: true, Permissions: &models.Permission{Read: true}},
+		{Id: 3, Groups: []int{5}, Permissions: &models.Permission{Read: true, Write: true}},
+		{Id: 4},
+		{Id: 6},
+	}, nil)
+	accessRepo.EXPECT().GetUsersSources(2, []int{4, 6}).Return([]*models.AccessSources{
+		{
+			UserId:       4,
+			Project:      &models.Permission{Read: true, Write: true},
+			BoardDefault: &models.Permission{Read: true},
+		},
+		{
+			UserId:       6,
+			Project:      &models.Permission{Read: true},
+			BoardDefault: &models.Permission{Read: true},
+			BoardDeny:    &models.Permission{Read: true, Write: true, Admin: true},
+		},
+	}, nil)
+
+	got := s.GetMembers(1, 1, 2)
+	assert.Equal(t, StatusOK, got.Code)
+	assert.Equal(t, []*models.Member{
+		{Id: 1, IsDirect: true, Source: SourceBoardAllow, Permissions: &models.Permission{Read: true}},
+		{Id: 3, Groups: []int{5}, Source: SourceBoardGroup, Permissions: &models.Permission{Read: true, Write: true}},
+		{Id: 4, Source: SourceBoardDefault, Permissions: &models.Permission{Read: true}},
+	}, got.Data.(Map)["members"])
+}
+
+func TestBoardService_Delete(t *testing.T) {
+	type args struct {
+		userId    int
+		projectId int
+		boardId   int
+	}
+	type mockBehavior func(r *mock_repositories.MockBoard, a *mock_repositories.MockAccess, input args)
+
+	owner := &models.Permission{Read: true, Write: true, Admin: true}
+
+	tests := []struct {
+		name         string
+		input        args
+		mock         mockBehavior
+		expectedCode int
+	}{
+		{
+			name:  "Ok",
+			input: args{userId: 1, projectId: 2, boardId: 3},
+			mock: func(r *mock_repositories.MockBoard, a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(owner), nil)
+				r.EXPECT().GetById(input.boardId).Return(&models.Board{Id: input.boardId, OwnerId: input.userId}, nil)
+				r.EXPECT().Delete(input.boardId).Return(nil)
+			},
+			expectedCode: StatusOK,
+		},
+		{
+			name:  "Archived",
+			input: args{userId: 1, projectId: 2, boardId: 3},
+			mock: func(r *mock_repositories.MockBoard, a *mock_repositories.MockAccess, input args) {
+				sources := boardSources(owner)
+				sources.IsArchived = true
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(sources, nil)
+			},
+			expectedCode: StatusForbidden,
+		},
+		{
+			name:  "Not Owner",
+			input: args{userId: 1, projectId: 2, boardId: 3},
+			mock: func(r *mock_repositories.MockBoard, a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(owner), nil)
+				r.EXPECT().GetById(input.boardId).Return(&models.Board{Id: input.boardId, OwnerId: 4}, nil)
+			},
+			expectedCode: StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockBoard(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo, test.input)
+			s := &BoardService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Delete(test.input.userId, test.input.projectId, test.input.boardId)
+			assert.Equal(t, test.expectedCode, got.Code)
+		})
+	}
+}
@@ -79,6 +79,24 @@ const (
 	StatusNetworkAuthenticationRequired = 511 // RFC 6585, 6
 
 	DbResultNotFound = "sql: no rows in result set"
+	TaskOutOfBounds  = "Task position out of bounds"
+	ListOutOfBounds  = "List position out of bounds"
+	TaskBlocked      = "Task is blocked"
+	RelationCycle    = "Relation would create a cycle"
+	RelationExists   = "Relation already exists"
+	SprintNotOnBoard = "Sprint is not on the board"
+	SprintCompleted  = "Sprint is completed"
+	SprintNotPlanned = "Sprint is not planned"
+	SprintNotActive  = "Sprint is not active"
+	SprintActive     = "Another sprint is active"
+
+	MemberNotInProject = "Member is not in the project"
+	NoReadAccess       = "No read access"
+	MemberInGroup      = "Member is already in the group"
+	UserNotFound       = "User is not found"
+
+	FieldExists         = "Field already exists"
+	FieldOptionNotFound = "Option is not on the field"
 )
 
 // tests
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/architectv/networking-course-project/backend/pkg/filter"
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+const maxFieldText = 1000
+
+type CustomFieldService struct {
+	repo   repositories.CustomField
+	access *AccessResolver
+}
+
+func NewCustomFieldService(repo repositories.CustomField, access *AccessResolver) *CustomFieldService {
+	return &CustomFieldService{repo: repo, access: access}
+}
+
+func (s *CustomFieldService) GetAll(userId, projectId, boardId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	fields, err := s.repo.GetAll(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"fields": fields})
+	return r
+}
+
+// Create adds a field to the board, which only its admins can do.
+func (s *CustomFieldService) Create(userId, projectId, boardId int, field *models.CustomField) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if !models.FieldTypes[field.Type] {
+		r.Error(StatusBadRequest, "Invalid field type")
+		return r
+	}
+	if !isSelectField(field.Type) && len(field.Options) != 0 {
+		r.Error(StatusBadRequest, "Only select fields have options")
+		return r
+	}
+	if !validOptions(r, field.Options) {
+		return r
+	}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	for _, option := range field.Options {
+		option.Id = 0
+	}
+	field.BoardId = boardId
+	fieldId, err := s.repo.Create(field)
+	if err != nil {
+		fieldError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"fieldId": fieldId})
+	return r
+}
+
+// Update renames the field or replaces its options; the type of a field
+// does not change.
+func (s *CustomFieldService) Update(userId, projectId, boardId, fieldId int, input *models.UpdateCustomField) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	field, err := s.getBoardField(boardId, fieldId)
+	if err != nil {
+		fieldError(r, err)
+		return r
+	}
+
+	if input.Options != nil {
+		if !isSelectField(field.Type) {
+			r.Error(StatusBadRequest, "Only select fields have options")
+			return r
+		}
+		if !validOptions(r, *input.Options) {
+			return r
+		}
+	}
+
+	if err = s.repo.Update(fieldId, input); err != nil {
+		fieldError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *CustomFieldService) Delete(userId, projectId, boardId, fieldId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err := s.getBoardField(boardId, fieldId); err != nil {
+		fieldError(r, err)
+		return r
+	}
+
+	if err = s.repo.Delete(fieldId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// SetValue sets the field of the task to the value, which holds the member
+// matching the type of the field only. Users must be able to read the board.
+func (s *CustomFieldService) SetValue(userId, projectId, boardId, listId, taskId, fieldId int, value *models.CustomValue) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if value.UserId != nil && *value.UserId < 1 {
+		r.Error(StatusBadRequest, "Invalid user")
+		return r
+	}
+
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	field, err := s.getBoardField(boardId, fieldId)
+	if err != nil {
+		fieldError(r, err)
+		return r
+	}
+
+	if !validValue(field, value) {
+		r.Error(StatusBadRequest, fmt.Sprintf("Invalid value for the %s field", field.Type))
+		return r
+	}
+	if field.Type == models.FieldUser {
+		permissions, err = s.access.BoardPermissions(*value.UserId, boardId)
+		if err != nil || permissions.Read == false {
+			r.Error(StatusBadRequest, "User can't read the board")
+			return r
+		}
+	}
+
+	value.TaskId = taskId
+	value.FieldId = fieldId
+	if err = s.repo.SetValue(value); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *CustomFieldService) DeleteValue(userId, projectId, boardId, listId, taskId, fieldId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err := s.getBoardField(boardId, fieldId); err != nil {
+		fieldError(r, err)
+		return r
+	}
+
+	if err = s.repo.DeleteValue(taskId, fieldId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// attach sets the values of the custom fields of the tasks.
+func (s *CustomFieldService) attach(tasks ...*models.Task) error {
+	if s == nil || len(tasks) == 0 {
+		return nil
+	}
+
+	taskIds := make([]int, len(tasks))
+	tasksById := make(map[int]*models.Task, len(tasks))
+	for i, task := range tasks {
+		taskIds[i] = task.Id
+		tasksById[task.Id] = task
+	}
+
+	values, err := s.repo.GetValues(taskIds)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if task, ok := tasksById[value.TaskId]; ok {
+			task.Fields = append(task.Fields, value)
+		}
+	}
+	return nil
+}
+
+// resolve looks up the custom fields of the query on the board and checks
+// the terms against their types. It sets the response on error.
+func (s *CustomFieldService) resolve(r *models.ApiResponse, boardId int, query *filter.Query) bool {
+	if s == nil || !hasCustomFields(query) {
+		return true
+	}
+
+	fields, err := s.repo.GetAll(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return false
+	}
+	find := func(custom *filter.Custom) bool {
+		for _, field := range fields {
+			if strings.EqualFold(field.Name, custom.Name) {
+				custom.Id, custom.Type = field.Id, field.Type
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, term := range query.Terms {
+		if term.Custom == nil {
+			continue
+		}
+		if !find(term.Custom) {
+			message := fmt.Sprintf("Unknown field %q", "cf."+term.Custom.Name)
+			filterError(r, &filter.Error{Pos: term.Pos, Message: message})
+			return false
+		}
+		if message := checkCustomTerm(term); message != "" {
+			filterError(r, &filter.Error{Pos: term.Pos, Message: message})
+			return false
+		}
+	}
+
+	if query.Sort != nil && query.Sort.Custom != nil && !find(query.Sort.Custom) {
+		r.Error(StatusBadRequest, fmt.Sprintf("Invalid sort %q", "cf."+query.Sort.Custom.Name))
+		return false
+	}
+	return true
+}
+
+func (s *CustomFieldService) getBoardField(boardId, fieldId int) (*models.CustomField, error) {
+	field, err := s.repo.GetById(fieldId)
+	if err != nil {
+		return nil, err
+	}
+	if field.BoardId != boardId {
+		return nil, errors.New(DbResultNotFound)
+	}
+	return field, nil
+}
+
+func hasCustomFields(query *filter.Query) bool {
+	if query.Sort != nil && query.Sort.Custom != nil {
+		return true
+	}
+	for _, term := range query.Terms {
+		if term.Custom != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCustomTerm returns why the term does not suit the type of its field.
+// Text, select and user fields compare like text fields of the filter,
+// numbers and dates like time fields, and checkboxes take true or false.
+func checkCustomTerm(term *filter.Term) string {
+	unsupported := fmt.Sprintf("Operator %q is not supported by cf.%s", term.Op, term.Custom.Name)
+	switch term.Custom.Type {
+	case models.FieldNumber:
+		if term.Op == filter.OpContains {
+			return unsupported
+		}
+		number, err := strconv.ParseFloat(term.Value, 64)
+		if err != nil {
+			return fmt.Sprintf("Invalid number %q", term.Value)
+		}
+		term.Number = number
+	case models.FieldDate:
+		if term.Op == filter.OpContains {
+			return unsupported
+		}
+		if err := term.ParseTime(); err != nil {
+			return err.Error()
+		}
+	case models.FieldCheckbox:
+		if term.Op != filter.OpEqual {
+			return unsupported
+		}
+		if term.Value != "true" && term.Value != "false" {
+			return fmt.Sprintf("Expected true or false for cf.%s", term.Custom.Name)
+		}
+	default:
+		if term.Op != filter.OpEqual && term.Op != filter.OpContains {
+			return unsupported
+		}
+	}
+	return ""
+}
+
+func isSelectField(fieldType string) bool {
+	return fieldType == models.FieldSelect || fieldType == models.FieldMultiSelect
+}
+
+// validOptions checks that option names are unique, as filters refer to
+// options by name.
+func validOptions(r *models.ApiResponse, options []*models.FieldOption) bool {
+	names := make(map[string]bool, len(options))
+	for _, option := range options {
+		name := strings.ToLower(option.Name)
+		if names[name] {
+			r.Error(StatusBadRequest, fmt.Sprintf("Duplicate option %q", option.Name))
+			return false
+		}
+		names[name] = true
+	}
+	return true
+}
+
+// validValue checks that only the member of the value matching the type of
+// the field is set, and that select fields get their own options.
+func validValue(field *models.CustomField, value *models.CustomValue) bool {
+	set := 0
+	for _, isSet := range []bool{value.Text != nil, value.Number != nil, value.Date != nil,
+		value.Checked != nil, value.UserId != nil, value.OptionIds != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return false
+	}
+
+	switch field.Type {
+	case models.FieldText:
+		return value.Text != nil && utf8.RuneCountInString(*value.Text) <= maxFieldText
+	case models.FieldNumber:
+		return value.Number != nil
+	case models.FieldDate:
+		return value.Date != nil
+	case models.FieldCheckbox:
+		return value.Checked != nil
+	case models.FieldUser:
+		return value.UserId != nil
+	}
+
+	if len(value.OptionIds) == 0 || (field.Type == models.FieldSelect && len(value.OptionIds) > 1) {
+		return false
+	}
+	options := make(map[int]bool, len(field.Options))
+	for _, option := range field.Options {
+		options[option.Id] = true
+	}
+	for _, optionId := range value.OptionIds {
+		if !options[optionId] {
+			return false
+		}
+		// Every option is picked once.
+		delete(options, optionId)
+	}
+	return true
+}
+
+func fieldError(r *models.ApiResponse, err error) {
+	switch err.Error() {
+	case DbResultNotFound:
+		r.Error(StatusNotFound, "Field not found")
+	case FieldExists:
+		r.Error(StatusConflict, err.Error())
+	case FieldOptionNotFound:
+		r.Error(StatusBadRequest, err.Error())
+	default:
+		r.Error(StatusInternalServerError, err.Error())
+	}
+}
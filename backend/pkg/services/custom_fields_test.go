@@ -0,0 +1,205 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/filter"
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidValue(t *testing.T) {
+	text, number, checked, userId := "acme", 2.5, true, 3
+	selectField := &models.CustomField{Type: models.FieldSelect,
+		Options: []*models.FieldOption{{Id: 1, Name: "low"}, {Id: 2, Name: "high"}}}
+	multiSelectField := &models.CustomField{Type: models.FieldMultiSelect, Options: selectField.Options}
+
+	tests := []struct {
+		name  string
+		field *models.CustomField
+		value *models.CustomValue
+		valid bool
+	}{
+		{"Text", &models.CustomField{Type: models.FieldText}, &models.CustomValue{Text: &text}, true},
+		{"Number As Text", &models.CustomField{Type: models.FieldText}, &models.CustomValue{Number: &number}, false},
+		{"Two Members", &models.CustomField{Type: models.FieldNumber},
+			&models.CustomValue{Number: &number, Text: &text}, false},
+		{"Checkbox", &models.CustomField{Type: models.FieldCheckbox}, &models.CustomValue{Checked: &checked}, true},
+		{"User", &models.CustomField{Type: models.FieldUser}, &models.CustomValue{UserId: &userId}, true},
+		{"Select", selectField, &models.CustomValue{OptionIds: []int{2}}, true},
+		{"Select Of Two", selectField, &models.CustomValue{OptionIds: []int{1, 2}}, false},
+		{"Unknown Option", selectField, &models.CustomValue{OptionIds: []int{4}}, false},
+		{"Multi Select", multiSelectField, &models.CustomValue{OptionIds: []int{2, 1}}, true},
+		{"Repeated Option", multiSelectField, &models.CustomValue{OptionIds: []int{1, 1}}, false},
+		{"No Option", multiSelectField, &models.CustomValue{OptionIds: []int{}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.valid, validValue(test.field, test.value))
+		})
+	}
+}
+
+func TestCustomFieldService_SetValue(t *testing.T) {
+	type mockBehavior func(r *mock_repositories.MockCustomField, a *mock_repositories.MockAccess)
+
+	userId, guestId := 6, -6
+	field := &models.CustomField{Id: 5, BoardId: 2, Name: "reviewer", Type: models.FieldUser}
+	permissions := func(a *mock_repositories.MockAccess) {
+		a.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{Read: true, Write: true}), nil)
+		a.EXPECT().IsListArchived(3).Return(false, nil)
+	}
+
+	tests := []struct {
+		name         string
+		value        *models.CustomValue
+		mock         mockBehavior
+		expectedCode int
+	}{
+		{
+			name:  "Ok",
+			value: &models.CustomValue{UserId: &userId},
+			mock: func(r *mock_repositories.MockCustomField, a *mock_repositories.MockAccess) {
+				permissions(a)
+				r.EXPECT().GetById(5).Return(field, nil)
+				a.EXPECT().GetBoardSources(6, 2).Return(boardSources(&models.Permission{Read: true}), nil)
+				r.EXPECT().SetValue(&models.CustomValue{TaskId: 4, FieldId: 5, UserId: &userId}).Return(nil)
+			},
+			expectedCode: StatusOK,
+		},
+		{
+			name:  "User Can't Read The Board",
+			value: &models.CustomValue{UserId: &userId},
+			mock: func(r *mock_repositories.MockCustomField, a *mock_repositories.MockAccess) {
+				permissions(a)
+				r.EXPECT().GetById(5).Return(field, nil)
+				a.EXPECT().GetBoardSources(6, 2).Return(boardSources(nil), nil)
+			},
+			expectedCode: StatusBadRequest,
+		},
+		{
+			name:         "Guest",
+			value:        &models.CustomValue{UserId: &guestId},
+			mock:         func(r *mock_repositories.MockCustomField, a *mock_repositories.MockAccess) {},
+			expectedCode: StatusBadRequest,
+		},
+		{
+			name:  "Wrong Type",
+			value: &models.CustomValue{OptionIds: []int{1}},
+			mock: func(r *mock_repositories.MockCustomField, a *mock_repositories.MockAccess) {
+				permissions(a)
+				r.EXPECT().GetById(5).Return(field, nil)
+			},
+			expectedCode: StatusBadRequest,
+		},
+		{
+			name:  "Field Of Another Board",
+			value: &models.CustomValue{UserId: &userId},
+			mock: func(r *mock_repositories.MockCustomField, a *mock_repositories.MockAccess) {
+				permissions(a)
+				r.EXPECT().GetById(5).Return(&models.CustomField{Id: 5, BoardId: 7, Type: models.FieldUser}, nil)
+			},
+			expectedCode: StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockCustomField(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo)
+			s := &CustomFieldService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.SetValue(1, 1, 2, 3, 4, 5, test.value)
+			assert.Equal(t, test.expectedCode, got.Code)
+		})
+	}
+}
+
+func TestCustomFieldService_Create(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repositories.NewMockCustomField(c)
+	accessRepo := mock_repositories.NewMockAccess(c)
+	s := &CustomFieldService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+	got := s.Create(1, 1, 2, &models.CustomField{Name: "severity", Type: models.FieldSelect,
+		Options: []*models.FieldOption{{Name: "High"}, {Name: "high"}}})
+	assert.Equal(t, StatusBadRequest, got.Code)
+
+	got = s.Create(1, 1, 2, &models.CustomField{Name: "severity", Type: "color"})
+	assert.Equal(t, StatusBadRequest, got.Code)
+
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{Read: true, Write: true}), nil)
+	got = s.Create(1, 1, 2, &models.CustomField{Name: "severity", Type: models.FieldText})
+	assert.Equal(t, StatusForbidden, got.Code)
+
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{Read: true, Write: true, Admin: true}), nil)
+	repo.EXPECT().Create(&models.CustomField{BoardId: 2, Name: "severity", Type: models.FieldSelect,
+		Options: []*models.FieldOption{{Name: "low"}, {Name: "high"}}}).Return(5, nil)
+	got = s.Create(1, 1, 2, &models.CustomField{Name: "severity", Type: models.FieldSelect,
+		Options: []*models.FieldOption{{Id: 8, Name: "low"}, {Name: "high"}}})
+	assert.Equal(t, StatusOK, got.Code)
+}
+
+func TestCustomFieldService_Resolve(t *testing.T) {
+	fields := []*models.CustomField{
+		{Id: 4, BoardId: 2, Name: "Severity", Type: models.FieldSelect},
+		{Id: 5, BoardId: 2, Name: "estimate", Type: models.FieldNumber},
+		{Id: 6, BoardId: 2, Name: "due", Type: models.FieldDate},
+	}
+
+	tests := []struct {
+		name         string
+		filter       string
+		sort         string
+		expectedCode int
+		position     int
+	}{
+		{name: "Ok", filter: "label:bug cf.severity:high cf.estimate:>2 cf.due:<7d", sort: "-cf.due",
+			expectedCode: StatusOK},
+		{name: "Unknown Field", filter: "label:bug cf.owner:alex", expectedCode: StatusBadRequest, position: 11},
+		{name: "Invalid Number", filter: "cf.estimate:>two", expectedCode: StatusBadRequest, position: 1},
+		{name: "Comparison Of Options", filter: "cf.severity:>high", expectedCode: StatusBadRequest, position: 1},
+		{name: "Unknown Sort", sort: "cf.owner", expectedCode: StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockCustomField(c)
+			repo.EXPECT().GetAll(2).Return(fields, nil)
+			s := &CustomFieldService{repo: repo}
+
+			query, err := filter.Parse(test.filter)
+			assert.NoError(t, err)
+			query.Sort, err = filter.ParseSort(test.sort)
+			assert.NoError(t, err)
+
+			r := &models.ApiResponse{}
+			if s.resolve(r, 2, query) {
+				r.Code = StatusOK
+			}
+			assert.Equal(t, test.expectedCode, r.Code)
+			if test.position != 0 {
+				assert.Equal(t, Map{"position": test.position}, r.Data)
+			}
+			if test.expectedCode == StatusOK {
+				assert.Equal(t, &filter.Custom{Name: "severity", Id: 4, Type: models.FieldSelect}, query.Terms[1].Custom)
+				assert.Equal(t, 2.0, query.Terms[2].Number)
+				assert.Equal(t, 6, query.Sort.Custom.Id)
+			}
+		})
+	}
+}
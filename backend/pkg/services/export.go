@@ -0,0 +1,162 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+type ExportService struct {
+	repo   repositories.Export
+	access *AccessResolver
+}
+
+func NewExportService(repo repositories.Export, access *AccessResolver) *ExportService {
+	return &ExportService{repo: repo, access: access}
+}
+
+// Export is limited to project admins because the document lists the
+// members and their permissions.
+func (s *ExportService) Export(userId, projectId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	archive, err := s.repo.Export(projectId)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Project not found")
+			return r
+		}
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	archive.Version = models.ExportVersion
+	archive.Exported = time.Now().Unix()
+
+	r.Set(StatusOK, "OK", archive)
+	return r
+}
+
+func (s *ExportService) Import(userId int, archive *models.ProjectExport) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if archive.Version != models.ExportVersion {
+		r.Error(StatusBadRequest, "Unsupported export version")
+		return r
+	}
+	curTime := time.Now().Unix()
+	normalizeExport(archive, curTime)
+	if err := validateExport(archive); err != nil {
+		r.Error(StatusBadRequest, err.Error())
+		return r
+	}
+
+	projectId, missing, err := s.repo.Import(userId, archive, curTime,
+		curTime+int64(invitationTTL.Seconds()))
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"projectId": projectId, "missingMembers": missing})
+	return r
+}
+
+// normalizeExport fills in what hand-made or trimmed documents may omit.
+func normalizeExport(archive *models.ProjectExport, curTime int64) {
+	datetimes := func(d *models.Datetimes) *models.Datetimes {
+		if d == nil {
+			return &models.Datetimes{Created: curTime, Updated: curTime, Accessed: curTime}
+		}
+		return d
+	}
+	permissions := func(p *models.Permission) *models.Permission {
+		if p == nil {
+			return &models.Permission{}
+		}
+		return p
+	}
+	members := func(members []*models.MemberExport) {
+		for _, member := range members {
+			member.Permissions = permissions(member.Permissions)
+		}
+	}
+
+	archive.Datetimes = datetimes(archive.Datetimes)
+	archive.DefaultPermissions = permissions(archive.DefaultPermissions)
+	members(archive.Members)
+	for _, board := range archive.Boards {
+		board.Datetimes = datetimes(board.Datetimes)
+		board.DefaultPermissions = permissions(board.DefaultPermissions)
+		members(board.Members)
+		for _, list := range board.Lists {
+			for _, task := range list.Tasks {
+				task.Datetimes = datetimes(task.Datetimes)
+			}
+		}
+	}
+}
+
+// validateExport checks the document against the limits of the tables, so
+// that a bad one is rejected before anything is created. Members without
+// permissions are invited with the defaults of their project or board.
+func validateExport(archive *models.ProjectExport) error {
+	if err := titleValidation("Project title", archive.Title, 50); err != nil {
+		return err
+	}
+	if err := membersValidation(archive.DefaultPermissions, archive.Members); err != nil {
+		return err
+	}
+	for _, board := range archive.Boards {
+		if err := titleValidation("Board title", board.Title, 50); err != nil {
+			return err
+		}
+		if err := membersValidation(board.DefaultPermissions, board.Members); err != nil {
+			return err
+		}
+		for _, label := range board.Labels {
+			if utf8.RuneCountInString(label.Name) > 30 {
+				return errors.New("Label name must be at most 30 characters")
+			}
+		}
+		for _, list := range board.Lists {
+			if err := titleValidation("List title", list.Title, 30); err != nil {
+				return err
+			}
+			for _, task := range list.Tasks {
+				if err := titleValidation("Task title", task.Title, 30); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func titleValidation(field, title string, max int) error {
+	if length := utf8.RuneCountInString(title); length < 1 || length > max {
+		return fmt.Errorf("%s must be 1 to %d characters", field, max)
+	}
+	return nil
+}
+
+func membersValidation(defaultPerms *models.Permission, members []*models.MemberExport) error {
+	for _, member := range members {
+		err := permsValidation(member.Permissions)
+		if err != nil && err.Error() == ErrPermsIsNotDefined {
+			member.Permissions = defaultPerms
+			err = permsValidation(member.Permissions)
+		}
+		if err != nil {
+			return fmt.Errorf("Permissions of %s: %s", member.Nickname, err.Error())
+		}
+	}
+	return nil
+}
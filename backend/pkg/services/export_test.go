@@ -0,0 +1,141 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportService_Import(t *testing.T) {
+	type args struct {
+		userId  int
+		archive *models.ProjectExport
+	}
+	type mockBehavior func(r *mock_repositories.MockExport, userId int)
+
+	newArchive := func(version int) *models.ProjectExport {
+		return &models.ProjectExport{
+			Version:            version,
+			Title:              "Imported",
+			DefaultPermissions: &models.Permission{Read: true},
+			Members:            []*models.MemberExport{{Nickname: "alex"}},
+			Boards: []*models.BoardExport{{
+				Title: "Board",
+				Lists: []*models.ListExport{{
+					Title: "To do",
+					Tasks: []*models.TaskExport{{Title: "Task"}},
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:  "Ok",
+			input: args{userId: 1, archive: newArchive(models.ExportVersion)},
+			mock: func(r *mock_repositories.MockExport, userId int) {
+				r.EXPECT().Import(userId, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(userId int, archive *models.ProjectExport, created, expires int64) (int, []string, error) {
+						assert.NotNil(t, archive.DefaultPermissions)
+						assert.NotNil(t, archive.Datetimes)
+						assert.Equal(t, &models.Permission{Read: true}, archive.Members[0].Permissions)
+						assert.True(t, expires > created)
+						assert.NotNil(t, archive.Boards[0].DefaultPermissions)
+						assert.NotNil(t, archive.Boards[0].Lists[0].Tasks[0].Datetimes)
+						return 5, []string{"bob"}, nil
+					})
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"projectId": 5, "missingMembers": []string{"bob"}},
+			},
+		},
+		{
+			name:  "Unsupported version",
+			input: args{userId: 1, archive: newArchive(models.ExportVersion + 1)},
+			mock:  func(r *mock_repositories.MockExport, userId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name:  "Repo error",
+			input: args{userId: 1, archive: newArchive(models.ExportVersion)},
+			mock: func(r *mock_repositories.MockExport, userId int) {
+				r.EXPECT().Import(userId, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(0, nil, errors.New("repo error"))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusInternalServerError,
+			},
+		},
+		{
+			name: "Task title too long",
+			input: args{userId: 1, archive: func() *models.ProjectExport {
+				archive := newArchive(models.ExportVersion)
+				archive.Boards[0].Lists[0].Tasks[0].Title = strings.Repeat("a", 31)
+				return archive
+			}()},
+			mock: func(r *mock_repositories.MockExport, userId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name: "Invalid member permissions",
+			input: args{userId: 1, archive: func() *models.ProjectExport {
+				archive := newArchive(models.ExportVersion)
+				archive.Boards[0].Members = []*models.MemberExport{{
+					Nickname:    "alex",
+					Permissions: &models.Permission{Admin: true},
+				}}
+				return archive
+			}()},
+			mock: func(r *mock_repositories.MockExport, userId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name: "No permissions for a member",
+			input: args{userId: 1, archive: func() *models.ProjectExport {
+				archive := newArchive(models.ExportVersion)
+				archive.DefaultPermissions = nil
+				return archive
+			}()},
+			mock: func(r *mock_repositories.MockExport, userId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockExport(c)
+			test.mock(repo, test.input.userId)
+			s := &ExportService{repo: repo}
+
+			got := s.Import(test.input.userId, test.input.archive)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
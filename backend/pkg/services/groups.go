@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+// Groups belong to a project. There are no organizations to own groups yet, so
+// a team working on several projects needs a group in each of them.
+type GroupService struct {
+	repo           repositories.Group
+	projectRepo    repositories.Project
+	invitationRepo repositories.Invitation
+	access         *AccessResolver
+}
+
+func NewGroupService(repo repositories.Group, projectRepo repositories.Project,
+	invitationRepo repositories.Invitation, access *AccessResolver) *GroupService {
+	return &GroupService{repo: repo, projectRepo: projectRepo, invitationRepo: invitationRepo, access: access}
+}
+
+func (s *GroupService) GetAll(userId, projectId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	groups, err := s.repo.GetAll(projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"groups": groups})
+	return r
+}
+
+func (s *GroupService) GetById(userId, projectId, groupId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	group, err := getProjectGroup(s.repo, projectId, groupId)
+	if err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"group": group})
+	return r
+}
+
+func (s *GroupService) Create(userId, projectId int, group *models.Group) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	group.ProjectId = projectId
+	group.OwnerId = userId
+	groupId, err := s.repo.Create(group)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"groupId": groupId})
+	return r
+}
+
+func (s *GroupService) Update(userId, projectId, groupId int, group *models.UpdateGroup) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.repo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	if err = s.repo.Update(groupId, group); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *GroupService) Delete(userId, projectId, groupId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.repo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	if err = s.repo.Delete(groupId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *GroupService) GetMembers(userId, projectId, groupId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.repo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	members, err := s.repo.GetMembers(groupId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"members": members})
+	return r
+}
+
+// AddMember adds a project member to the group. Members join projects by
+// accepting an invitation, so the grants of a group only reach users who
+// agreed to take part in the project: other users are invited to the project
+// with its default permissions and join the group once they accept.
+func (s *GroupService) AddMember(userId, projectId, groupId int, memberNickname string) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.repo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	groupMemberId, err := s.repo.AddMember(groupId, memberNickname)
+	if err != nil {
+		switch err.Error() {
+		case MemberNotInProject:
+			return s.inviteMember(userId, projectId, groupId, memberNickname)
+		case UserNotFound:
+			r.Error(StatusNotFound, err.Error())
+		case MemberInGroup:
+			r.Error(StatusConflict, err.Error())
+		default:
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"groupMemberId": groupMemberId})
+	return r
+}
+
+func (s *GroupService) inviteMember(userId, projectId, groupId int, memberNickname string) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	project, err := s.projectRepo.GetById(projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if project.DefaultPermissions == nil {
+		r.Error(StatusInternalServerError, "Default permissions is not defined")
+		return r
+	}
+
+	invitation := &models.Invitation{
+		ProjectId:   projectId,
+		GroupId:     groupId,
+		InviterId:   userId,
+		Nickname:    memberNickname,
+		Permissions: project.DefaultPermissions,
+	}
+	return createInvitation(s.invitationRepo, invitation)
+}
+
+func (s *GroupService) DeleteMember(userId, projectId, groupId, memberId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.repo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	if err = s.repo.DeleteMember(groupId, memberId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// Groups of other projects are reported as missing.
+func getProjectGroup(repo repositories.Group, projectId, groupId int) (*models.Group, error) {
+	group, err := repo.GetById(groupId)
+	if err != nil {
+		return nil, err
+	}
+	if group.ProjectId != projectId {
+		return nil, errors.New(DbResultNotFound)
+	}
+	return group, nil
+}
+
+func groupError(r *models.ApiResponse, err error) {
+	if err.Error() == DbResultNotFound {
+		r.Error(StatusNotFound, "Group not found")
+		return
+	}
+	r.Error(StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupService_Create(t *testing.T) {
+	type args struct {
+		userId    int
+		projectId int
+		group     *models.Group
+	}
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, projectId int)
+	type mockBehavior func(r *mock_repositories.MockGroup, group *models.Group)
+
+	tests := []struct {
+		name                string
+		input               args
+		accessMock          accessMockBehavior
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name: "Ok",
+			input: args{
+				userId:    1,
+				projectId: 1,
+				group:     &models.Group{Title: "Developers"},
+			},
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, true}), nil)
+			},
+			mock: func(r *mock_repositories.MockGroup, group *models.Group) {
+				r.EXPECT().Create(&models.Group{ProjectId: 1, OwnerId: 1, Title: group.Title}).Return(1, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"groupId": 1},
+			},
+		},
+		{
+			name: "Not Project Admin",
+			input: args{
+				userId:    1,
+				projectId: 1,
+				group:     &models.Group{Title: "Developers"},
+			},
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, false}), nil)
+			},
+			mock: func(r *mock_repositories.MockGroup, group *models.Group) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name: "Repo Error",
+			input: args{
+				userId:    1,
+				projectId: 1,
+				group:     &models.Group{Title: "Developers"},
+			},
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, true}), nil)
+			},
+			mock: func(r *mock_repositories.MockGroup, group *models.Group) {
+				r.EXPECT().Create(gomock.Any()).Return(0, errors.New("repo error"))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockGroup(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.projectId)
+			test.mock(repo, test.input.group)
+			s := &GroupService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Create(test.input.userId, test.input.projectId, test.input.group)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
+
+func TestGroupService_AddMember(t *testing.T) {
+	type mockBehavior func(r *mock_repositories.MockGroup, p *mock_repositories.MockProject,
+		i *mock_repositories.MockInvitation)
+
+	defaults := &models.Permission{Read: true}
+
+	tests := []struct {
+		name                string
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name: "Ok",
+			mock: func(r *mock_repositories.MockGroup, p *mock_repositories.MockProject,
+				i *mock_repositories.MockInvitation) {
+				r.EXPECT().AddMember(2, "member").Return(3, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"groupMemberId": 3},
+			},
+		},
+		{
+			name: "Invite Non Member",
+			mock: func(r *mock_repositories.MockGroup, p *mock_repositories.MockProject,
+				i *mock_repositories.MockInvitation) {
+				r.EXPECT().AddMember(2, "member").Return(0, errors.New(MemberNotInProject))
+				p.EXPECT().GetById(1).Return(&models.Project{Id: 1, DefaultPermissions: defaults}, nil)
+				i.EXPECT().Create(gomock.Any()).DoAndReturn(func(invitation *models.Invitation) (int, error) {
+					assert.Equal(t, 1, invitation.ProjectId)
+					assert.Equal(t, 2, invitation.GroupId)
+					assert.Equal(t, "member", invitation.Nickname)
+					assert.Equal(t, defaults, invitation.Permissions)
+					return 4, nil
+				})
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"invitationId": 4},
+			},
+		},
+		{
+			name: "Unknown User",
+			mock: func(r *mock_repositories.MockGroup, p *mock_repositories.MockProject,
+				i *mock_repositories.MockInvitation) {
+				r.EXPECT().AddMember(2, "member").Return(0, errors.New(UserNotFound))
+			},
+			expectedApiResponse: &models.ApiResponse{Code: StatusNotFound},
+		},
+		{
+			name: "Already In Group",
+			mock: func(r *mock_repositories.MockGroup, p *mock_repositories.MockProject,
+				i *mock_repositories.MockInvitation) {
+				r.EXPECT().AddMember(2, "member").Return(0, errors.New(MemberInGroup))
+			},
+			expectedApiResponse: &models.ApiResponse{Code: StatusConflict},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockGroup(c)
+			projectRepo := mock_repositories.NewMockProject(c)
+			invitationRepo := mock_repositories.NewMockInvitation(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			accessRepo.EXPECT().GetProjectSources(1, 1).Return(projectSources(&models.Permission{true, true, true}), nil)
+			repo.EXPECT().GetById(2).Return(&models.Group{Id: 2, ProjectId: 1}, nil)
+			test.mock(repo, projectRepo, invitationRepo)
+			s := NewGroupService(repo, projectRepo, invitationRepo, NewAccessResolver(accessRepo))
+
+			got := s.AddMember(1, 1, 2, "member")
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
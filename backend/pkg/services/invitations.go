@@ -0,0 +1,144 @@
+package services
+
+import (
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+type InvitationService struct {
+	repo   repositories.Invitation
+	access *AccessResolver
+}
+
+func NewInvitationService(repo repositories.Invitation, access *AccessResolver) *InvitationService {
+	return &InvitationService{repo: repo, access: access}
+}
+
+func (s *InvitationService) GetAll(userId, projectId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	invitations, err := s.repo.GetAll(projectId, time.Now().Unix())
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"invitations": invitations})
+	return r
+}
+
+// Delete revokes the invitation, which admins of the project can do, and
+// admins of the board for board invitations.
+func (s *InvitationService) Delete(userId, projectId, invitationId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	invitation, err := s.repo.GetById(invitationId)
+	if err != nil {
+		invitationError(r, err)
+		return r
+	}
+	if invitation.ProjectId != projectId {
+		r.Error(StatusNotFound, "Invitation not found")
+		return r
+	}
+
+	if permissions.Admin == false && invitation.BoardId != 0 {
+		permissions, err = s.access.BoardPermissions(userId, invitation.BoardId)
+	}
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err = s.repo.Delete(invitationId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *InvitationService) GetAllForUser(userId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	invitations, err := s.repo.GetAllForUser(userId, time.Now().Unix())
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"invitations": invitations})
+	return r
+}
+
+func (s *InvitationService) Accept(userId, invitationId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	invitation, err := s.repo.GetById(invitationId)
+	if err != nil {
+		invitationError(r, err)
+		return r
+	}
+	if invitation.InviteeId != userId {
+		r.Error(StatusNotFound, "Invitation not found")
+		return r
+	}
+	if invitation.Expires <= time.Now().Unix() {
+		r.Error(StatusGone, "Invitation has expired")
+		return r
+	}
+
+	permissionsId, err := s.repo.Accept(invitationId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	if invitation.BoardId != 0 {
+		r.Set(StatusOK, "OK", Map{"projectId": invitation.ProjectId, "boardId": invitation.BoardId,
+			"Board permissions id": permissionsId})
+		return r
+	}
+	r.Set(StatusOK, "OK", Map{"projectId": invitation.ProjectId, "Project permissions id": permissionsId})
+	return r
+}
+
+func (s *InvitationService) Decline(userId, invitationId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	invitation, err := s.repo.GetById(invitationId)
+	if err != nil {
+		invitationError(r, err)
+		return r
+	}
+	if invitation.InviteeId != userId {
+		r.Error(StatusNotFound, "Invitation not found")
+		return r
+	}
+
+	if err = s.repo.Delete(invitationId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func invitationError(r *models.ApiResponse, err error) {
+	if err.Error() == DbResultNotFound {
+		r.Error(StatusNotFound, "Invitation not found")
+		return
+	}
+	r.Error(StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitationService_Accept(t *testing.T) {
+	type args struct {
+		userId       int
+		invitationId int
+	}
+	type mockBehavior func(r *mock_repositories.MockInvitation, invitationId int)
+
+	expires := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:  "Ok",
+			input: args{userId: 2, invitationId: 1},
+			mock: func(r *mock_repositories.MockInvitation, invitationId int) {
+				r.EXPECT().GetById(invitationId).Return(&models.Invitation{Id: invitationId, ProjectId: 3,
+					InviteeId: 2, Expires: expires}, nil)
+				r.EXPECT().Accept(invitationId).Return(5, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"projectId": 3, "Project permissions id": 5},
+			},
+		},
+		{
+			name:  "Ok for a board",
+			input: args{userId: 2, invitationId: 1},
+			mock: func(r *mock_repositories.MockInvitation, invitationId int) {
+				r.EXPECT().GetById(invitationId).Return(&models.Invitation{Id: invitationId, ProjectId: 3,
+					BoardId: 4, InviteeId: 2, Expires: expires}, nil)
+				r.EXPECT().Accept(invitationId).Return(6, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"projectId": 3, "boardId": 4, "Board permissions id": 6},
+			},
+		},
+		{
+			name:  "Invitation not found",
+			input: args{userId: 2, invitationId: 1},
+			mock: func(r *mock_repositories.MockInvitation, invitationId int) {
+				r.EXPECT().GetById(invitationId).Return(nil, errors.New(DbResultNotFound))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusNotFound,
+			},
+		},
+		{
+			name:  "Invitation of another user",
+			input: args{userId: 2, invitationId: 1},
+			mock: func(r *mock_repositories.MockInvitation, invitationId int) {
+				r.EXPECT().GetById(invitationId).Return(&models.Invitation{Id: invitationId, ProjectId: 3,
+					InviteeId: 4, Expires: expires}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusNotFound,
+			},
+		},
+		{
+			name:  "Invitation expired",
+			input: args{userId: 2, invitationId: 1},
+			mock: func(r *mock_repositories.MockInvitation, invitationId int) {
+				r.EXPECT().GetById(invitationId).Return(&models.Invitation{Id: invitationId, ProjectId: 3,
+					InviteeId: 2, Expires: time.Now().Add(-time.Hour).Unix()}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusGone,
+			},
+		},
+		{
+			name:  "Repo error for Accept",
+			input: args{userId: 2, invitationId: 1},
+			mock: func(r *mock_repositories.MockInvitation, invitationId int) {
+				r.EXPECT().GetById(invitationId).Return(&models.Invitation{Id: invitationId, ProjectId: 3,
+					InviteeId: 2, Expires: expires}, nil)
+				r.EXPECT().Accept(invitationId).Return(0, errors.New("some error"))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockInvitation(c)
+			test.mock(repo, test.input.invitationId)
+			s := &InvitationService{repo: repo}
+
+			got := s.Accept(test.input.userId, test.input.invitationId)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
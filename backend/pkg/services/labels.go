@@ -6,26 +6,19 @@ import (
 )
 
 type LabelService struct {
-	repo        repositories.Label
-	boardRepo   repositories.Board
-	projectRepo repositories.Project
+	repo   repositories.Label
+	access *AccessResolver
 }
 
-func NewLabelService(repo repositories.Label, boardRepo repositories.Board, projectRepo repositories.Project) *LabelService {
-	return &LabelService{repo: repo, boardRepo: boardRepo, projectRepo: projectRepo}
+func NewLabelService(repo repositories.Label, access *AccessResolver) *LabelService {
+	return &LabelService{repo: repo, access: access}
 }
 
 func (s *LabelService) GetAllInTask(userId, projectId, boardId, taskId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -43,14 +36,8 @@ func (s *LabelService) GetAllInTask(userId, projectId, boardId, taskId int) *mod
 func (s *LabelService) GetAll(userId, projectId, boardId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -67,14 +54,8 @@ func (s *LabelService) GetAll(userId, projectId, boardId int) *models.ApiRespons
 
 func (s *LabelService) GetById(userId, projectId, boardId, labelId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -91,14 +72,8 @@ func (s *LabelService) GetById(userId, projectId, boardId, labelId int) *models.
 
 func (s *LabelService) Create(userId, projectId, boardId int, label *models.Label) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -114,16 +89,10 @@ func (s *LabelService) Create(userId, projectId, boardId int, label *models.Labe
 	return r
 }
 
-func (s *LabelService) CreateInTask(userId, projectId, boardId, taskId, labelId int) *models.ApiResponse {
+func (s *LabelService) CreateInTask(userId, projectId, boardId, listId, taskId, labelId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -140,14 +109,8 @@ func (s *LabelService) CreateInTask(userId, projectId, boardId, taskId, labelId
 
 func (s *LabelService) Update(userId, projectId, boardId, labelId int, label *models.UpdateLabel) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Write == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -161,16 +124,10 @@ func (s *LabelService) Update(userId, projectId, boardId, labelId int, label *mo
 	return r
 }
 
-func (s *LabelService) DeleteInTask(userId, projectId, boardId, taskId, labelId int) *models.ApiResponse {
+func (s *LabelService) DeleteInTask(userId, projectId, boardId, listId, taskId, labelId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Write == false {
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -187,14 +144,8 @@ func (s *LabelService) DeleteInTask(userId, projectId, boardId, taskId, labelId
 
 func (s *LabelService) Delete(userId, projectId, boardId, labelId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Write == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
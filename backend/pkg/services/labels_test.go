@@ -20,14 +20,12 @@ func TestLabelService_Create(t *testing.T) {
 		label     *models.Label
 	}
 	type mockBehavior func(r *mock_repositories.MockLabel, label *models.Label)
-	type projectMockBehavior func(r *mock_repositories.MockProject, userId, projectId int)
-	type boardMockBehavior func(r *mock_repositories.MockBoard, userId, boardId int)
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, boardId int)
 
 	tests := []struct {
 		name                string
 		input               args
-		projectMock         projectMockBehavior
-		boardMock           boardMockBehavior
+		accessMock          accessMockBehavior
 		mock                mockBehavior
 		expectedApiResponse *models.ApiResponse
 	}{
@@ -39,11 +37,8 @@ func TestLabelService_Create(t *testing.T) {
 				boardId:   1,
 				label:     builders.NewLabelBuilder().WithName("Label Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockLabel, label *models.Label) {
 				r.EXPECT().Create(label).Return(1, nil)
@@ -54,18 +49,17 @@ func TestLabelService_Create(t *testing.T) {
 			},
 		},
 		{
-			name: "Project Perm Failed",
+			name: "Not Project Member",
 			input: args{
 				userId:    1,
 				projectId: 1,
 				boardId:   1,
 				label:     builders.NewLabelBuilder().WithName("Label Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
 			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {},
-			mock:      func(r *mock_repositories.MockLabel, label *models.Label) {},
+			mock: func(r *mock_repositories.MockLabel, label *models.Label) {},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
 			},
@@ -78,11 +72,8 @@ func TestLabelService_Create(t *testing.T) {
 				boardId:   1,
 				label:     builders.NewLabelBuilder().WithName("Label Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(nil, errors.New("Forbidden"))
 			},
 			mock: func(r *mock_repositories.MockLabel, label *models.Label) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -97,11 +88,8 @@ func TestLabelService_Create(t *testing.T) {
 				boardId:   1,
 				label:     builders.NewLabelBuilder().WithName("Label Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockLabel, label *models.Label) {
 				r.EXPECT().Create(label).Return(0, errors.New("repo error"))
@@ -118,12 +106,10 @@ func TestLabelService_Create(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockLabel(c)
-			projectRepo := mock_repositories.NewMockProject(c)
-			boardRepo := mock_repositories.NewMockBoard(c)
-			test.projectMock(projectRepo, test.input.userId, test.input.projectId)
-			test.boardMock(boardRepo, test.input.userId, test.input.boardId)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.boardId)
 			test.mock(repo, test.input.label)
-			s := &LabelService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &LabelService{repo: repo, access: NewAccessResolver(accessRepo)}
 
 			got := s.Create(test.input.userId, test.input.projectId, test.input.boardId, test.input.label)
 			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
@@ -139,18 +125,17 @@ func TestLabelService_CreateInTask(t *testing.T) {
 		userId    int
 		projectId int
 		boardId   int
+		listId    int
 		taskId    int
 		labelId   int
 	}
 	type mockBehavior func(r *mock_repositories.MockLabel, taskId, labelId int)
-	type projectMockBehavior func(r *mock_repositories.MockProject, userId, projectId int)
-	type boardMockBehavior func(r *mock_repositories.MockBoard, userId, boardId int)
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, boardId, listId int)
 
 	tests := []struct {
 		name                string
 		input               args
-		projectMock         projectMockBehavior
-		boardMock           boardMockBehavior
+		accessMock          accessMockBehavior
 		mock                mockBehavior
 		expectedApiResponse *models.ApiResponse
 	}{
@@ -162,11 +147,9 @@ func TestLabelService_CreateInTask(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(false, nil)
 			},
 			mock: func(r *mock_repositories.MockLabel, taskId, labelId int) {
 				r.EXPECT().CreateInTask(taskId, labelId).Return(1, nil)
@@ -177,18 +160,17 @@ func TestLabelService_CreateInTask(t *testing.T) {
 			},
 		},
 		{
-			name: "Project Perm Failed",
+			name: "Not Project Member",
 			input: args{
 				userId:    1,
 				projectId: 1,
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
 			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {},
-			mock:      func(r *mock_repositories.MockLabel, taskId, labelId int) {},
+			mock: func(r *mock_repositories.MockLabel, taskId, labelId int) {},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
 			},
@@ -201,11 +183,8 @@ func TestLabelService_CreateInTask(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(nil, errors.New("Forbidden"))
 			},
 			mock: func(r *mock_repositories.MockLabel, taskId, labelId int) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -220,11 +199,9 @@ func TestLabelService_CreateInTask(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(false, nil)
 			},
 			mock: func(r *mock_repositories.MockLabel, taskId, labelId int) {
 				r.EXPECT().CreateInTask(taskId, labelId).Return(0, errors.New("repo error"))
@@ -241,15 +218,13 @@ func TestLabelService_CreateInTask(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockLabel(c)
-			projectRepo := mock_repositories.NewMockProject(c)
-			boardRepo := mock_repositories.NewMockBoard(c)
-			test.projectMock(projectRepo, test.input.userId, test.input.projectId)
-			test.boardMock(boardRepo, test.input.userId, test.input.boardId)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.boardId, test.input.listId)
 			test.mock(repo, test.input.taskId, test.input.labelId)
-			s := &LabelService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &LabelService{repo: repo, access: NewAccessResolver(accessRepo)}
 
 			got := s.CreateInTask(test.input.userId, test.input.projectId, test.input.boardId,
-				test.input.taskId, test.input.labelId)
+				test.input.listId, test.input.taskId, test.input.labelId)
 			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
 			if test.expectedApiResponse.Code == StatusOK {
 				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
@@ -263,18 +238,17 @@ func TestLabelService_DeleteInTask(t *testing.T) {
 		userId    int
 		projectId int
 		boardId   int
+		listId    int
 		taskId    int
 		labelId   int
 	}
 	type mockBehavior func(r *mock_repositories.MockLabel, taskId, labelId int)
-	type projectMockBehavior func(r *mock_repositories.MockProject, userId, projectId int)
-	type boardMockBehavior func(r *mock_repositories.MockBoard, userId, boardId int)
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, boardId, listId int)
 
 	tests := []struct {
 		name                string
 		input               args
-		projectMock         projectMockBehavior
-		boardMock           boardMockBehavior
+		accessMock          accessMockBehavior
 		mock                mockBehavior
 		expectedApiResponse *models.ApiResponse
 	}{
@@ -286,11 +260,9 @@ func TestLabelService_DeleteInTask(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(false, nil)
 			},
 			mock: func(r *mock_repositories.MockLabel, taskId, labelId int) {
 				r.EXPECT().DeleteInTask(taskId, labelId).Return(nil)
@@ -301,18 +273,17 @@ func TestLabelService_DeleteInTask(t *testing.T) {
 			},
 		},
 		{
-			name: "Project Perm Failed",
+			name: "Not Project Member",
 			input: args{
 				userId:    1,
 				projectId: 1,
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
 			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {},
-			mock:      func(r *mock_repositories.MockLabel, taskId, labelId int) {},
+			mock: func(r *mock_repositories.MockLabel, taskId, labelId int) {},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
 			},
@@ -325,11 +296,8 @@ func TestLabelService_DeleteInTask(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(nil, errors.New("Forbidden"))
 			},
 			mock: func(r *mock_repositories.MockLabel, taskId, labelId int) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -344,11 +312,9 @@ func TestLabelService_DeleteInTask(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(false, nil)
 			},
 			mock: func(r *mock_repositories.MockLabel, taskId, labelId int) {
 				r.EXPECT().DeleteInTask(taskId, labelId).Return(errors.New("repo error"))
@@ -365,15 +331,13 @@ func TestLabelService_DeleteInTask(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockLabel(c)
-			projectRepo := mock_repositories.NewMockProject(c)
-			boardRepo := mock_repositories.NewMockBoard(c)
-			test.projectMock(projectRepo, test.input.userId, test.input.projectId)
-			test.boardMock(boardRepo, test.input.userId, test.input.boardId)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.boardId, test.input.listId)
 			test.mock(repo, test.input.taskId, test.input.labelId)
-			s := &LabelService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &LabelService{repo: repo, access: NewAccessResolver(accessRepo)}
 
 			got := s.DeleteInTask(test.input.userId, test.input.projectId, test.input.boardId,
-				test.input.taskId, test.input.labelId)
+				test.input.listId, test.input.taskId, test.input.labelId)
 			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
 			if test.expectedApiResponse.Code == StatusOK {
 				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
@@ -390,14 +354,12 @@ func TestLabelService_Delete(t *testing.T) {
 		labelId   int
 	}
 	type mockBehavior func(r *mock_repositories.MockLabel, labelId int)
-	type projectMockBehavior func(r *mock_repositories.MockProject, userId, projectId int)
-	type boardMockBehavior func(r *mock_repositories.MockBoard, userId, boardId int)
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, boardId int)
 
 	tests := []struct {
 		name                string
 		input               args
-		projectMock         projectMockBehavior
-		boardMock           boardMockBehavior
+		accessMock          accessMockBehavior
 		mock                mockBehavior
 		expectedApiResponse *models.ApiResponse
 	}{
@@ -409,11 +371,8 @@ func TestLabelService_Delete(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockLabel, labelId int) {
 				r.EXPECT().Delete(labelId).Return(nil)
@@ -424,18 +383,17 @@ func TestLabelService_Delete(t *testing.T) {
 			},
 		},
 		{
-			name: "Project Perm Failed",
+			name: "Not Project Member",
 			input: args{
 				userId:    1,
 				projectId: 1,
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
 			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {},
-			mock:      func(r *mock_repositories.MockLabel, labelId int) {},
+			mock: func(r *mock_repositories.MockLabel, labelId int) {},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
 			},
@@ -448,11 +406,8 @@ func TestLabelService_Delete(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(nil, errors.New("Forbidden"))
 			},
 			mock: func(r *mock_repositories.MockLabel, labelId int) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -467,11 +422,8 @@ func TestLabelService_Delete(t *testing.T) {
 				boardId:   1,
 				labelId:   1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockLabel, labelId int) {
 				r.EXPECT().Delete(labelId).Return(errors.New("repo error"))
@@ -488,12 +440,10 @@ func TestLabelService_Delete(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockLabel(c)
-			projectRepo := mock_repositories.NewMockProject(c)
-			boardRepo := mock_repositories.NewMockBoard(c)
-			test.projectMock(projectRepo, test.input.userId, test.input.projectId)
-			test.boardMock(boardRepo, test.input.userId, test.input.boardId)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.boardId)
 			test.mock(repo, test.input.labelId)
-			s := &LabelService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &LabelService{repo: repo, access: NewAccessResolver(accessRepo)}
 
 			got := s.Delete(test.input.userId, test.input.projectId, test.input.boardId, test.input.labelId)
 			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
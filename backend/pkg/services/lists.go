@@ -6,49 +6,44 @@ import (
 )
 
 type TaskListService struct {
-	repo        repositories.TaskList
-	boardRepo   repositories.Board
-	projectRepo repositories.Project
+	repo     repositories.TaskList
+	access   *AccessResolver
+	notifier *Notifier
 }
 
-func NewTaskListService(repo repositories.TaskList, boardRepo repositories.Board, projectRepo repositories.Project) *TaskListService {
-	return &TaskListService{repo: repo, boardRepo: boardRepo, projectRepo: projectRepo}
+func NewTaskListService(repo repositories.TaskList, access *AccessResolver, notifier *Notifier) *TaskListService {
+	return &TaskListService{repo: repo, access: access, notifier: notifier}
 }
 
-func (s *TaskListService) GetAll(userId, projectId, boardId int) *models.ApiResponse {
+func (s *TaskListService) GetAll(userId, projectId, boardId int, archived bool) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	lists, err := s.repo.GetAll(boardId)
+	allLists, err := s.repo.GetAll(boardId)
 	if err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
 
+	lists := make([]*models.TaskList, 0, len(allLists))
+	for _, list := range allLists {
+		if archived || !list.Archived {
+			lists = append(lists, list)
+		}
+	}
+
 	r.Set(StatusOK, "OK", Map{"lists": lists})
 	return r
 }
 
 func (s *TaskListService) GetById(userId, projectId, boardId, listId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -65,14 +60,8 @@ func (s *TaskListService) GetById(userId, projectId, boardId, listId int) *model
 
 func (s *TaskListService) Create(userId, projectId, boardId int, list *models.TaskList) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -83,6 +72,9 @@ func (s *TaskListService) Create(userId, projectId, boardId int, list *models.Ta
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
+	s.notifier.Watch(userId, models.WatchList, listId)
+	s.notifier.Notify(&models.Event{Type: models.EventListCreated, ActorId: userId,
+		ProjectId: projectId, BoardId: boardId, ListId: listId})
 
 	r.Set(StatusOK, "OK", Map{"listId": listId})
 	return r
@@ -90,15 +82,8 @@ func (s *TaskListService) Create(userId, projectId, boardId int, list *models.Ta
 
 func (s *TaskListService) Delete(userId, projectId, boardId, listId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	// TODO: права projectId = read ?
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
-	}
-
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Write == false {
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -121,20 +106,34 @@ func (s *TaskListService) Update(userId, projectId, boardId, listId int, list *m
 		return r
 	}
 
-	// TODO: права projectId = read ?
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Write == false {
+	if err = s.repo.Update(listId, list); err != nil {
+		if err.Error() == TaskBlocked {
+			r.Error(StatusConflict, err.Error())
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *TaskListService) SetArchived(userId, projectId, boardId, listId int, archived bool) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Admin == false || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	if err = s.repo.Update(listId, list); err != nil {
+	if err = s.repo.SetArchived(listId, archived); err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
@@ -142,3 +141,52 @@ func (s *TaskListService) Update(userId, projectId, boardId, listId int, list *m
 	r.Set(StatusOK, "OK", Map{})
 	return r
 }
+
+// Move needs write on the list and on the target board, which may be in
+// another project.
+func (s *TaskListService) Move(userId, projectId, boardId, listId int, input *models.MoveTaskList) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if input.Position != nil && *input.Position < 0 {
+		r.Error(StatusBadRequest, ListOutOfBounds)
+		return r
+	}
+
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	permissions, err = s.access.BoardPermissions(userId, input.BoardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	// Like for a moved task, users that can't read the board are cleared.
+	dropUsers := []int{}
+	if input.BoardId != boardId {
+		users, err := s.repo.GetUsers(listId)
+		if err == nil {
+			dropUsers, err = s.access.UsersWithoutRead(input.BoardId, users)
+		}
+		if err != nil {
+			r.Error(StatusInternalServerError, err.Error())
+			return r
+		}
+	}
+
+	dropped, err := s.repo.Move(listId, input.BoardId, input.Position, dropUsers)
+	if err != nil {
+		if err.Error() == ListOutOfBounds {
+			r.Error(StatusBadRequest, err.Error())
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"droppedLabels": dropped})
+	return r
+}
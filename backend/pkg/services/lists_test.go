@@ -20,14 +20,12 @@ func TestTaskListService_Create(t *testing.T) {
 		list      *models.TaskList
 	}
 	type mockBehavior func(r *mock_repositories.MockTaskList, list *models.TaskList)
-	type projectMockBehavior func(r *mock_repositories.MockProject, userId, projectId int)
-	type boardMockBehavior func(r *mock_repositories.MockBoard, userId, boardId int)
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, boardId int)
 
 	tests := []struct {
 		name                string
 		input               args
-		projectMock         projectMockBehavior
-		boardMock           boardMockBehavior
+		accessMock          accessMockBehavior
 		mock                mockBehavior
 		expectedApiResponse *models.ApiResponse
 	}{
@@ -39,11 +37,8 @@ func TestTaskListService_Create(t *testing.T) {
 				boardId:   1,
 				list:      builders.NewListBuilder().WithTitle("List Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockTaskList, list *models.TaskList) {
 				r.EXPECT().Create(list).Return(1, nil)
@@ -54,18 +49,17 @@ func TestTaskListService_Create(t *testing.T) {
 			},
 		},
 		{
-			name: "Project Perm Failed",
+			name: "Not Project Member",
 			input: args{
 				userId:    1,
 				projectId: 1,
 				boardId:   1,
 				list:      builders.NewListBuilder().WithTitle("List Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
 			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {},
-			mock:      func(r *mock_repositories.MockTaskList, list *models.TaskList) {},
+			mock: func(r *mock_repositories.MockTaskList, list *models.TaskList) {},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
 			},
@@ -78,11 +72,8 @@ func TestTaskListService_Create(t *testing.T) {
 				boardId:   1,
 				list:      builders.NewListBuilder().WithTitle("List Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(nil, errors.New("Forbidden"))
 			},
 			mock: func(r *mock_repositories.MockTaskList, list *models.TaskList) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -97,11 +88,8 @@ func TestTaskListService_Create(t *testing.T) {
 				boardId:   1,
 				list:      builders.NewListBuilder().WithTitle("List Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
 			},
 			mock: func(r *mock_repositories.MockTaskList, list *models.TaskList) {
 				r.EXPECT().Create(list).Return(0, errors.New("repo error"))
@@ -118,12 +106,10 @@ func TestTaskListService_Create(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockTaskList(c)
-			projectRepo := mock_repositories.NewMockProject(c)
-			boardRepo := mock_repositories.NewMockBoard(c)
-			test.projectMock(projectRepo, test.input.userId, test.input.projectId)
-			test.boardMock(boardRepo, test.input.userId, test.input.boardId)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.boardId)
 			test.mock(repo, test.input.list)
-			s := &TaskListService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &TaskListService{repo: repo, access: NewAccessResolver(accessRepo)}
 
 			got := s.Create(test.input.userId, test.input.projectId, test.input.boardId, test.input.list)
 			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
@@ -133,3 +119,50 @@ func TestTaskListService_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskListService_Delete(t *testing.T) {
+	tests := []struct {
+		name         string
+		archived     bool
+		expectedCode int
+	}{
+		{name: "Ok", expectedCode: StatusOK},
+		{name: "Archived List", archived: true, expectedCode: StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTaskList(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			accessRepo.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{true, true, false}), nil)
+			accessRepo.EXPECT().IsListArchived(3).Return(test.archived, nil)
+			if !test.archived {
+				repo.EXPECT().Delete(3).Return(nil)
+			}
+			s := &TaskListService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Delete(1, 1, 2, 3)
+			assert.Equal(t, test.expectedCode, got.Code)
+		})
+	}
+}
+
+func TestTaskListService_Update_Blocked(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	done := true
+	input := &models.UpdateTaskList{Done: &done}
+	repo := mock_repositories.NewMockTaskList(c)
+	accessRepo := mock_repositories.NewMockAccess(c)
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{Read: true, Write: true}), nil)
+	accessRepo.EXPECT().IsListArchived(3).Return(false, nil)
+	repo.EXPECT().Update(3, input).Return(errors.New(TaskBlocked))
+	s := &TaskListService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+	got := s.Update(1, 1, 2, 3, input)
+	assert.Equal(t, StatusConflict, got.Code)
+}
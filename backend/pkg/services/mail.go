@@ -0,0 +1,313 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/mail"
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	digestPeriod = 24 * time.Hour
+	// reminderLead is how long before the due date the assignee is reminded.
+	reminderLead = 24 * time.Hour
+)
+
+// MailConfig turns mail on when the transport is set. BaseURL is where the
+// links in the messages point to, Secret signs the unsubscribe links.
+type MailConfig struct {
+	Transport mail.Transport
+	BaseURL   string
+	Secret    string
+}
+
+// Validate requires a secret of its own once mail is on, anyone knowing the
+// key of the unsubscribe links could turn off mail for every user.
+func (c MailConfig) Validate() error {
+	if c.Transport != nil && c.Secret == "" {
+		return errors.New("mail secret is required when mail is enabled")
+	}
+	return nil
+}
+
+// mailEvents are the events mailed to the user they are about at once, the
+// others only make it to the digest. Due dates are reminded of by
+// SendReminders.
+var mailEvents = map[string]string{
+	models.EventTaskAssigned:  "assigned you to",
+	models.EventTaskMentioned: "mentioned you in",
+}
+
+const (
+	notificationSubject = `{{.Actor}} {{.Action}} "{{.Title}}"`
+	notificationText    = `Hi {{.Nickname}},
+
+{{.Actor}} {{.Action}} "{{.Title}}".
+{{.Link}}
+
+--
+Unsubscribe: {{.Unsubscribe}}
+`
+	notificationHTML = `<p>Hi {{.Nickname}},</p>
+<p>{{.Actor}} {{.Action}} <a href="{{.Link}}">{{.Title}}</a>.</p>
+<hr>
+<p><small><a href="{{.Unsubscribe}}">Unsubscribe</a></small></p>
+`
+
+	reminderSubject = `"{{.Title}}" is due {{.Due}}`
+	reminderText    = `Hi {{.Nickname}},
+
+"{{.Title}}" assigned to you is due {{.Due}}.
+{{.Link}}
+
+--
+Unsubscribe: {{.Unsubscribe}}
+`
+	reminderHTML = `<p>Hi {{.Nickname}},</p>
+<p><a href="{{.Link}}">{{.Title}}</a> assigned to you is due {{.Due}}.</p>
+<hr>
+<p><small><a href="{{.Unsubscribe}}">Unsubscribe</a></small></p>
+`
+
+	digestSubject = `{{len .Notifications}} new notifications`
+	digestText    = `Hi {{.Nickname}},
+
+Here is what happened since your last digest:
+{{range .Notifications}}
+* {{.Event}}: {{.Title}}{{end}}
+
+{{.Link}}
+
+--
+Unsubscribe: {{.Unsubscribe}}
+`
+	digestHTML = `<p>Hi {{.Nickname}},</p>
+<p>Here is what happened since your last digest:</p>
+<ul>{{range .Notifications}}
+<li>{{.Event}}: {{.Title}}</li>{{end}}
+</ul>
+<p><a href="{{.Link}}">Open your notifications</a></p>
+<hr>
+<p><small><a href="{{.Unsubscribe}}">Unsubscribe</a></small></p>
+`
+)
+
+var (
+	notificationTemplate = mail.MustTemplate("notification",
+		notificationSubject, notificationText, notificationHTML)
+	reminderTemplate = mail.MustTemplate("reminder", reminderSubject, reminderText, reminderHTML)
+	digestTemplate   = mail.MustTemplate("digest", digestSubject, digestText, digestHTML)
+)
+
+// MailService mails notifications right away or as a daily digest. A change
+// never fails because of a message, so Notify only logs errors.
+type MailService struct {
+	repo      repositories.Mail
+	transport mail.Transport
+	baseURL   string
+	secret    []byte
+}
+
+func NewMailService(repo repositories.Mail, cfg MailConfig) *MailService {
+	return &MailService{repo: repo, transport: cfg.Transport, baseURL: cfg.BaseURL, secret: []byte(cfg.Secret)}
+}
+
+// Notify mails the user the event is about, if it is mailed at all.
+func (s *MailService) Notify(event *models.Event) {
+	action, ok := mailEvents[event.Type]
+	if s == nil || s.transport == nil || !ok || event.UserId == 0 {
+		return
+	}
+
+	recipient, err := s.repo.GetRecipient(event.UserId)
+	if err != nil {
+		logrus.Errorf("failed to get mail recipient %d: %s", event.UserId, err.Error())
+		return
+	}
+	if !recipient.Settings.Notifications || recipient.Email == "" {
+		return
+	}
+
+	actor := "Someone"
+	if event.ActorId != 0 {
+		if sender, err := s.repo.GetRecipient(event.ActorId); err == nil {
+			actor = sender.Nickname
+		}
+	}
+	title, err := s.repo.GetEventTitle(event)
+	if err != nil {
+		logrus.Errorf("failed to get title of %s: %s", event.Type, err.Error())
+		return
+	}
+
+	link := fmt.Sprintf("%s/projects/%d/boards/%d", s.baseURL, event.ProjectId, event.BoardId)
+	s.send(recipient, models.MailNotifications, notificationTemplate, Map{
+		"Nickname": recipient.Nickname,
+		"Actor":    actor,
+		"Action":   action,
+		"Title":    title,
+		"Link":     link,
+	})
+}
+
+// SendDigests mails the notifications since the last digest to the users
+// whose digest is due.
+func (s *MailService) SendDigests(now int64) error {
+	if s.transport == nil {
+		return nil
+	}
+
+	due := now - int64(digestPeriod/time.Second)
+	recipients, err := s.repo.GetDigestRecipients(due)
+	if err != nil {
+		return err
+	}
+
+	for _, recipient := range recipients {
+		since := recipient.DigestSent
+		if since == 0 {
+			since = due
+		}
+		notifications, err := s.repo.GetDigest(recipient.Id, since)
+		if err != nil {
+			return err
+		}
+		if len(notifications) != 0 && recipient.Email != "" {
+			s.send(recipient, models.MailDigest, digestTemplate, Map{
+				"Nickname":      recipient.Nickname,
+				"Notifications": notifications,
+				"Link":          s.baseURL + "/notifications",
+			})
+		}
+		if err := s.repo.SetDigestSent(recipient.Id, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendReminders mails the assignees of the tasks due within reminderLead,
+// once per due date. The reminders are a kind of notification, so they go
+// only to the users who get notifications.
+func (s *MailService) SendReminders(now int64) error {
+	if s.transport == nil {
+		return nil
+	}
+
+	reminders, err := s.repo.GetReminders(now + int64(reminderLead/time.Second))
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		recipient, err := s.repo.GetRecipient(reminder.UserId)
+		if err != nil {
+			return err
+		}
+		if recipient.Settings.Notifications && recipient.Email != "" {
+			s.send(recipient, models.MailNotifications, reminderTemplate, Map{
+				"Nickname": recipient.Nickname,
+				"Title":    reminder.Title,
+				"Due":      time.Unix(reminder.Due, 0).UTC().Format("2006-01-02 15:04 UTC"),
+				"Link": fmt.Sprintf("%s/projects/%d/boards/%d",
+					s.baseURL, reminder.ProjectId, reminder.BoardId),
+			})
+		}
+		if err := s.repo.SetReminded(reminder.TaskId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MailService) GetSettings(userId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	recipient, err := s.repo.GetRecipient(userId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"settings": recipient.Settings})
+	return r
+}
+
+func (s *MailService) UpdateSettings(userId int, input *models.UpdateMailSettings) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if err := s.repo.UpdateSettings(userId, input); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// Unsubscribe turns a list off from the link in a message, so it checks
+// the token instead of a session.
+func (s *MailService) Unsubscribe(userId int, list, token string) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if len(s.secret) == 0 || !hmac.Equal([]byte(token), []byte(s.unsubscribeToken(userId, list))) {
+		r.Error(StatusForbidden, "Invalid token")
+		return r
+	}
+
+	off := false
+	input := &models.UpdateMailSettings{}
+	switch list {
+	case models.MailNotifications:
+		input.Notifications = &off
+	case models.MailDigest:
+		input.Digest = &off
+	default:
+		r.Error(StatusBadRequest, "Invalid list")
+		return r
+	}
+
+	if err := s.repo.UpdateSettings(userId, input); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *MailService) send(recipient *models.MailRecipient, list string, template *mail.Template, data Map) {
+	unsubscribe := s.unsubscribeURL(recipient.Id, list)
+	data["Unsubscribe"] = unsubscribe
+	msg, err := template.Render(recipient.Email, data)
+	if err != nil {
+		logrus.Errorf("failed to render %s mail: %s", list, err.Error())
+		return
+	}
+	msg.Headers = map[string]string{"List-Unsubscribe": "<" + unsubscribe + ">"}
+	if err := s.transport.Send(msg); err != nil {
+		logrus.Errorf("failed to send %s mail to user %d: %s", list, recipient.Id, err.Error())
+	}
+}
+
+func (s *MailService) unsubscribeURL(userId int, list string) string {
+	query := url.Values{
+		"user":  {strconv.Itoa(userId)},
+		"list":  {list},
+		"token": {s.unsubscribeToken(userId, list)},
+	}
+	return s.baseURL + "/api/v1/users/unsubscribe?" + query.Encode()
+}
+
+func (s *MailService) unsubscribeToken(userId int, list string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%d:%s", userId, list)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/mail"
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTransport struct {
+	messages []*mail.Message
+}
+
+func (t *recordingTransport) Send(msg *mail.Message) error {
+	t.messages = append(t.messages, msg)
+	return nil
+}
+
+func TestMailService_Notify(t *testing.T) {
+	type mockBehavior func(r *mock_repositories.MockMail, event *models.Event)
+
+	assigned := &models.Event{Type: models.EventTaskAssigned, ActorId: 1, UserId: 2,
+		ProjectId: 3, BoardId: 4, ListId: 5, TaskId: 6}
+	assignee := &models.MailRecipient{Id: 2, Nickname: "bob", Email: "bob@example.com",
+		Settings: models.MailSettings{Notifications: true}}
+
+	tests := []struct {
+		name  string
+		event *models.Event
+		mock  mockBehavior
+		sent  bool
+	}{
+		{
+			name:  "Ok",
+			event: assigned,
+			mock: func(r *mock_repositories.MockMail, event *models.Event) {
+				r.EXPECT().GetRecipient(2).Return(assignee, nil)
+				r.EXPECT().GetRecipient(1).Return(&models.MailRecipient{Id: 1, Nickname: "alice"}, nil)
+				r.EXPECT().GetEventTitle(event).Return("Fix <the> bug", nil)
+			},
+			sent: true,
+		},
+		{
+			name:  "Turned Off",
+			event: assigned,
+			mock: func(r *mock_repositories.MockMail, event *models.Event) {
+				r.EXPECT().GetRecipient(2).Return(&models.MailRecipient{Id: 2, Email: "bob@example.com"}, nil)
+			},
+		},
+		{
+			name:  "Not Mailed",
+			event: &models.Event{Type: models.EventTaskUpdated, ActorId: 1, UserId: 2, ProjectId: 3},
+			mock:  func(r *mock_repositories.MockMail, event *models.Event) {},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockMail(c)
+			test.mock(repo, test.event)
+			transport := &recordingTransport{}
+			s := NewMailService(repo, MailConfig{Transport: transport, BaseURL: "http://yak", Secret: "secret"})
+
+			s.Notify(test.event)
+
+			if !test.sent {
+				assert.Empty(t, transport.messages)
+				return
+			}
+			assert.Len(t, transport.messages, 1)
+			msg := transport.messages[0]
+			assert.Equal(t, "bob@example.com", msg.To)
+			assert.Equal(t, `alice assigned you to "Fix <the> bug"`, msg.Subject)
+			assert.Contains(t, msg.HTML, "Fix &lt;the&gt; bug")
+			assert.Contains(t, msg.Headers["List-Unsubscribe"], "http://yak/api/v1/users/unsubscribe?")
+		})
+	}
+}
+
+func TestMailService_Unsubscribe(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repositories.NewMockMail(c)
+	s := NewMailService(repo, MailConfig{BaseURL: "http://yak", Secret: "secret"})
+
+	link, err := url.Parse(s.unsubscribeURL(2, models.MailDigest))
+	assert.NoError(t, err)
+	query := link.Query()
+	assert.Equal(t, "2", query.Get("user"))
+
+	r := s.Unsubscribe(3, models.MailDigest, query.Get("token"))
+	assert.Equal(t, StatusForbidden, r.Code)
+	r = s.Unsubscribe(2, models.MailNotifications, query.Get("token"))
+	assert.Equal(t, StatusForbidden, r.Code)
+
+	off := false
+	repo.EXPECT().UpdateSettings(2, &models.UpdateMailSettings{Digest: &off}).Return(nil)
+	r = s.Unsubscribe(2, models.MailDigest, query.Get("token"))
+	assert.Equal(t, StatusOK, r.Code)
+
+	s = NewMailService(repo, MailConfig{BaseURL: "http://yak"})
+	r = s.Unsubscribe(2, models.MailDigest, s.unsubscribeToken(2, models.MailDigest))
+	assert.Equal(t, StatusForbidden, r.Code)
+}
+
+func TestMailConfig_Validate(t *testing.T) {
+	transport := &recordingTransport{}
+	assert.NoError(t, MailConfig{}.Validate())
+	assert.NoError(t, MailConfig{Transport: transport, Secret: "secret"}.Validate())
+	assert.Error(t, MailConfig{Transport: transport}.Validate())
+}
+
+func TestMailService_SendDigests(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	const now = 200000
+	repo := mock_repositories.NewMockMail(c)
+	repo.EXPECT().GetDigestRecipients(int64(now-86400)).Return([]*models.MailRecipient{
+		{Id: 1, Nickname: "alice", Email: "alice@example.com", DigestSent: 100000},
+		{Id: 2, Nickname: "bob", Email: "bob@example.com"},
+	}, nil)
+	repo.EXPECT().GetDigest(1, int64(100000)).Return([]*models.Notification{
+		{Event: models.EventTaskCreated, Title: "First"},
+		{Event: models.EventTaskMoved, Title: "Second"},
+	}, nil)
+	repo.EXPECT().GetDigest(2, int64(now-86400)).Return([]*models.Notification{}, nil)
+	repo.EXPECT().SetDigestSent(1, int64(now)).Return(nil)
+	repo.EXPECT().SetDigestSent(2, int64(now)).Return(nil)
+
+	transport := &recordingTransport{}
+	s := NewMailService(repo, MailConfig{Transport: transport, BaseURL: "http://yak", Secret: "secret"})
+
+	assert.NoError(t, s.SendDigests(now))
+	assert.Len(t, transport.messages, 1)
+	msg := transport.messages[0]
+	assert.Equal(t, "alice@example.com", msg.To)
+	assert.Equal(t, "2 new notifications", msg.Subject)
+	assert.Contains(t, msg.Text, "task.created: First")
+	assert.Contains(t, msg.Text, "task.moved: Second")
+}
+
+func TestMailService_SendReminders(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	const now = 200000
+	repo := mock_repositories.NewMockMail(c)
+	repo.EXPECT().GetReminders(int64(now+86400)).Return([]*models.Reminder{
+		{TaskId: 5, ProjectId: 1, BoardId: 2, UserId: 3, Title: "Release", Due: 250000},
+		{TaskId: 6, ProjectId: 1, BoardId: 2, UserId: 4, Title: "Muted", Due: 260000},
+	}, nil)
+	repo.EXPECT().GetRecipient(3).Return(&models.MailRecipient{Id: 3, Nickname: "bob",
+		Email: "bob@example.com", Settings: models.MailSettings{Notifications: true}}, nil)
+	repo.EXPECT().GetRecipient(4).Return(&models.MailRecipient{Id: 4, Nickname: "eve",
+		Email: "eve@example.com"}, nil)
+	repo.EXPECT().SetReminded(5).Return(nil)
+	repo.EXPECT().SetReminded(6).Return(nil)
+
+	transport := &recordingTransport{}
+	s := NewMailService(repo, MailConfig{Transport: transport, BaseURL: "http://yak", Secret: "secret"})
+
+	assert.NoError(t, s.SendReminders(now))
+	assert.Len(t, transport.messages, 1)
+	msg := transport.messages[0]
+	assert.Equal(t, "bob@example.com", msg.To)
+	assert.Equal(t, `"Release" is due 1970-01-03 21:26 UTC`, msg.Subject)
+	assert.Contains(t, msg.Text, "http://yak/projects/1/boards/2")
+}
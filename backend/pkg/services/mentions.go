@@ -0,0 +1,101 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+
+	"github.com/sirupsen/logrus"
+)
+
+// mentionPattern matches @nickname unless it follows a letter, a digit or
+// an underscore, so that e-mail addresses are not mentions. Nicknames are not
+// limited to ASCII, so neither is the pattern.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@([\p{L}\p{N}_.-]+)`)
+
+// Mentioner keeps the users mentioned in a task as @nickname and notifies
+// the newly mentioned ones. Only the users who can read the board can be
+// mentioned. Like the Notifier it only logs errors, and a nil Mentioner
+// does nothing.
+type Mentioner struct {
+	repo     repositories.Mention
+	users    repositories.ObjectPerms
+	access   *AccessResolver
+	notifier *Notifier
+}
+
+func NewMentioner(repo repositories.Mention, users repositories.ObjectPerms, access *AccessResolver,
+	notifier *Notifier) *Mentioner {
+	return &Mentioner{repo: repo, users: users, access: access, notifier: notifier}
+}
+
+func (m *Mentioner) Get(taskId int) []*models.Mention {
+	if m == nil {
+		return nil
+	}
+	mentions, err := m.repo.GetAll(taskId)
+	if err != nil {
+		logrus.Errorf("failed to get mentions of task %d: %s", taskId, err.Error())
+		return nil
+	}
+	return mentions
+}
+
+// Update replaces the mentions of the task with those in the text, the
+// event tells where the task is and who changed it.
+func (m *Mentioner) Update(event models.Event, text string) {
+	if m == nil {
+		return
+	}
+
+	userIds := make([]int, 0)
+	if nicknames := parseMentions(text); len(nicknames) != 0 {
+		found, err := m.users.GetUserIds(nicknames)
+		if err != nil {
+			logrus.Errorf("failed to look up mentions: %s", err.Error())
+			return
+		}
+		for _, nickname := range nicknames {
+			userId, ok := found[nickname]
+			if !ok {
+				continue
+			}
+			permissions, err := m.access.BoardPermissions(userId, event.BoardId)
+			if err == nil && permissions.Read {
+				userIds = append(userIds, userId)
+			}
+		}
+	}
+
+	added, err := m.repo.Set(event.TaskId, event.ActorId, userIds, time.Now().Unix())
+	if err != nil {
+		logrus.Errorf("failed to save mentions of task %d: %s", event.TaskId, err.Error())
+		return
+	}
+
+	event.Type = models.EventTaskMentioned
+	for _, userId := range added {
+		mention := event
+		mention.UserId = userId
+		m.notifier.NotifyUser(&mention)
+	}
+}
+
+// parseMentions returns the mentioned nicknames in order, each one once.
+func parseMentions(text string) []string {
+	nicknames := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// A mention may end a sentence.
+		nickname := strings.TrimRight(match[1], ".-")
+		if nickname == "" || seen[nickname] {
+			continue
+		}
+		seen[nickname] = true
+		nicknames = append(nicknames, nickname)
+	}
+	return nicknames
+}
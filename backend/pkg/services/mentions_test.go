@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		text      string
+		nicknames []string
+	}{
+		{text: "", nicknames: []string{}},
+		{text: "@alice please look", nicknames: []string{"alice"}},
+		{text: "cc @bob_1, @carol.d and @bob_1.", nicknames: []string{"bob_1", "carol.d"}},
+		{text: "(@dave)\n@eve-", nicknames: []string{"dave", "eve"}},
+		{text: "mail alice@example.com or @@frank", nicknames: []string{}},
+		{text: "спасибо, @иван.петров!", nicknames: []string{"иван.петров"}},
+		{text: "пишите на иван@почта.рф", nicknames: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			assert.Equal(t, test.nicknames, parseMentions(test.text))
+		})
+	}
+}
+
+func TestMentioner_Update(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repositories.NewMockMention(c)
+	users := mock_repositories.NewMockObjectPerms(c)
+	accessRepo := mock_repositories.NewMockAccess(c)
+	notificationRepo := mock_repositories.NewMockNotification(c)
+
+	event := models.Event{Type: models.EventTaskUpdated, ActorId: 1,
+		ProjectId: 2, BoardId: 3, ListId: 4, TaskId: 5}
+	mentioned := &models.Event{Type: models.EventTaskMentioned, ActorId: 1, UserId: 6,
+		ProjectId: 2, BoardId: 3, ListId: 4, TaskId: 5}
+
+	users.EXPECT().GetUserIds([]string{"alice", "bob", "nobody"}).
+		Return(map[string]int{"alice": 6, "bob": 7}, nil)
+	accessRepo.EXPECT().GetBoardSources(6, 3).Return(boardSources(&models.Permission{Read: true}), nil)
+	accessRepo.EXPECT().GetBoardSources(7, 3).Return(boardSources(nil), nil)
+	repo.EXPECT().Set(5, 1, []int{6}, gomock.Any()).Return([]int{6}, nil)
+	notificationRepo.EXPECT().IsEnabled(6, models.EventTaskMentioned).Return(true, nil)
+	accessRepo.EXPECT().GetBoardSources(6, 3).Return(boardSources(&models.Permission{Read: true}), nil)
+	notificationRepo.EXPECT().Create([]int{6}, mentioned, gomock.Any()).Return(nil)
+
+	access := NewAccessResolver(accessRepo)
+	m := NewMentioner(repo, users, access, NewNotifier(notificationRepo, access, nil))
+	m.Update(event, "@alice, @bob and @nobody")
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+const (
+	notificationsLimit    = 50
+	maxNotificationsLimit = 200
+)
+
+type NotificationService struct {
+	repo   repositories.Notification
+	access *AccessResolver
+}
+
+func NewNotificationService(repo repositories.Notification, access *AccessResolver) *NotificationService {
+	return &NotificationService{repo: repo, access: access}
+}
+
+// GetAll returns a page of the notifications along with the number of the
+// unread ones.
+func (s *NotificationService) GetAll(userId int, unread bool, limit, offset int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if limit == 0 {
+		limit = notificationsLimit
+	}
+	if limit < 0 || limit > maxNotificationsLimit || offset < 0 {
+		r.Error(StatusBadRequest, "Invalid page")
+		return r
+	}
+
+	notifications, err := s.repo.GetAll(userId, unread, limit, offset)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	count, err := s.repo.CountUnread(userId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"notifications": notifications, "unread": count})
+	return r
+}
+
+func (s *NotificationService) MarkRead(userId, notificationId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if err := s.repo.MarkRead(userId, notificationId); err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Notification not found")
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *NotificationService) MarkAllRead(userId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if err := s.repo.MarkAllRead(userId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// GetPreferences returns every event, enabled unless the user turned it off.
+func (s *NotificationService) GetPreferences(userId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	stored, err := s.repo.GetPreferences(userId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	preferences := make(map[string]bool, len(models.Events))
+	for _, event := range models.Events {
+		enabled, ok := stored[event]
+		preferences[event] = enabled || !ok
+	}
+
+	r.Set(StatusOK, "OK", Map{"preferences": preferences})
+	return r
+}
+
+func (s *NotificationService) UpdatePreferences(userId int, preferences map[string]bool) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	for event := range preferences {
+		if !isEvent(event) {
+			r.Error(StatusBadRequest, "Unknown event "+event)
+			return r
+		}
+	}
+
+	if err := s.repo.UpdatePreferences(userId, preferences); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *NotificationService) GetWatches(userId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	watches, err := s.repo.GetWatches(userId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"watches": watches})
+	return r
+}
+
+// Watch needs read access to the object.
+func (s *NotificationService) Watch(userId int, watch *models.Watch) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	switch watch.ObjectType {
+	case models.WatchProject, models.WatchBoard, models.WatchList, models.WatchTask:
+	default:
+		r.Error(StatusBadRequest, "Invalid object type")
+		return r
+	}
+
+	board, err := s.repo.GetWatchBoard(watch)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Object not found")
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	var permissions *models.Permission
+	if board.Id == 0 {
+		permissions, err = s.access.ProjectPermissions(userId, board.ProjectId)
+	} else {
+		permissions, err = s.access.BoardPermissions(userId, board.Id)
+	}
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err := s.repo.Watch(userId, watch); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *NotificationService) Unwatch(userId int, watch *models.Watch) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if err := s.repo.Unwatch(userId, watch); err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Not watching")
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func isEvent(event string) bool {
+	for _, known := range models.Events {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
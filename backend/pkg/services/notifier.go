@@ -0,0 +1,96 @@
+package services
+
+import (
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Notifier records watches and turns events into notifications for the
+// watchers who can still read the object. A change never fails because of a
+// notification, so errors are only logged. A nil Notifier drops everything.
+// The user an event is about is also mailed, if they are notified at all.
+type Notifier struct {
+	repo   repositories.Notification
+	access *AccessResolver
+	mailer *MailService
+}
+
+func NewNotifier(repo repositories.Notification, access *AccessResolver, mailer *MailService) *Notifier {
+	return &Notifier{repo: repo, access: access, mailer: mailer}
+}
+
+func (n *Notifier) Watch(userId int, objectType string, objectId int) {
+	if n == nil {
+		return
+	}
+	watch := &models.Watch{ObjectType: objectType, ObjectId: objectId}
+	if err := n.repo.Watch(userId, watch); err != nil {
+		logrus.Errorf("failed to watch %s %d: %s", objectType, objectId, err.Error())
+	}
+}
+
+func (n *Notifier) Notify(event *models.Event) {
+	if n == nil {
+		return
+	}
+	recipients, err := n.repo.GetRecipients(event)
+	if err != nil {
+		logrus.Errorf("failed to get recipients of %s: %s", event.Type, err.Error())
+		return
+	}
+
+	allowed := make([]int, 0, len(recipients))
+	for _, userId := range recipients {
+		if n.canRead(userId, event) {
+			allowed = append(allowed, userId)
+		}
+	}
+	n.create(allowed, event)
+}
+
+// NotifyUser notifies only the user the event is about, not the watchers.
+func (n *Notifier) NotifyUser(event *models.Event) {
+	if n == nil || event.UserId == 0 || event.UserId == event.ActorId {
+		return
+	}
+	enabled, err := n.repo.IsEnabled(event.UserId, event.Type)
+	if err != nil {
+		logrus.Errorf("failed to get preferences of user %d: %s", event.UserId, err.Error())
+		return
+	}
+	if enabled && n.canRead(event.UserId, event) {
+		n.create([]int{event.UserId}, event)
+	}
+}
+
+func (n *Notifier) create(userIds []int, event *models.Event) {
+	if len(userIds) == 0 {
+		return
+	}
+
+	if err := n.repo.Create(userIds, event, time.Now().Unix()); err != nil {
+		logrus.Errorf("failed to notify about %s: %s", event.Type, err.Error())
+		return
+	}
+
+	for _, userId := range userIds {
+		if userId == event.UserId {
+			n.mailer.Notify(event)
+		}
+	}
+}
+
+func (n *Notifier) canRead(userId int, event *models.Event) bool {
+	var permissions *models.Permission
+	var err error
+	if event.BoardId == 0 {
+		permissions, err = n.access.ProjectPermissions(userId, event.ProjectId)
+	} else {
+		permissions, err = n.access.BoardPermissions(userId, event.BoardId)
+	}
+	return err == nil && permissions.Read
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	type mockBehavior func(r *mock_repositories.MockNotification, a *mock_repositories.MockAccess, event *models.Event)
+
+	taskEvent := &models.Event{Type: models.EventTaskUpdated, ActorId: 1,
+		ProjectId: 2, BoardId: 3, ListId: 4, TaskId: 5}
+	boardEvent := &models.Event{Type: models.EventBoardCreated, ActorId: 1, ProjectId: 2, BoardId: 3}
+	projectEvent := &models.Event{Type: models.EventBoardCreated, ActorId: 1, ProjectId: 2}
+
+	tests := []struct {
+		name  string
+		event *models.Event
+		mock  mockBehavior
+	}{
+		{
+			name:  "Readers Only",
+			event: taskEvent,
+			mock: func(r *mock_repositories.MockNotification, a *mock_repositories.MockAccess, event *models.Event) {
+				r.EXPECT().GetRecipients(event).Return([]int{6, 7}, nil)
+				a.EXPECT().GetBoardSources(6, 3).Return(boardSources(&models.Permission{Read: true}), nil)
+				a.EXPECT().GetBoardSources(7, 3).Return(boardSources(nil), nil)
+				r.EXPECT().Create([]int{6}, event, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "Project Event",
+			event: projectEvent,
+			mock: func(r *mock_repositories.MockNotification, a *mock_repositories.MockAccess, event *models.Event) {
+				r.EXPECT().GetRecipients(event).Return([]int{6}, nil)
+				a.EXPECT().GetProjectSources(6, 2).Return(projectSources(&models.Permission{Read: true}), nil)
+				r.EXPECT().Create([]int{6}, event, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "Nobody Can Read",
+			event: boardEvent,
+			mock: func(r *mock_repositories.MockNotification, a *mock_repositories.MockAccess, event *models.Event) {
+				r.EXPECT().GetRecipients(event).Return([]int{6}, nil)
+				a.EXPECT().GetBoardSources(6, 3).Return(nil, errors.New("Some error"))
+			},
+		},
+		{
+			name:  "No Watchers",
+			event: taskEvent,
+			mock: func(r *mock_repositories.MockNotification, a *mock_repositories.MockAccess, event *models.Event) {
+				r.EXPECT().GetRecipients(event).Return([]int{}, nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockNotification(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo, test.event)
+			n := NewNotifier(repo, NewAccessResolver(accessRepo), nil)
+
+			n.Notify(test.event)
+		})
+	}
+
+	var n *Notifier
+	n.Notify(taskEvent)
+	n.Watch(1, models.WatchTask, 5)
+}
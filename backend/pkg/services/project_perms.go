@@ -1,43 +1,53 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"time"
 
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 	"github.com/architectv/networking-course-project/backend/pkg/repositories"
 )
 
 const (
-	IsProject            = 1
-	IsBoard              = 2
-	ErrPermsIsNotDefined = "Permissions is not defined"
-	ErrPermsIncor        = "Permissions are set incorrectly"
+	IsProject             = 1
+	IsBoard               = 2
+	ErrPermsIsNotDefined  = "Permissions is not defined"
+	ErrPermsIncor         = "Permissions are set incorrectly"
+	invitationTTL         = 7 * 24 * time.Hour
+	invitationTokenLength = 24
 )
 
 type ProjectPermsService struct {
-	repo        repositories.ObjectPerms
-	projectRepo repositories.Project
-	boardRepo   repositories.Board
+	repo           repositories.ObjectPerms
+	projectRepo    repositories.Project
+	boardRepo      repositories.Board
+	groupRepo      repositories.Group
+	invitationRepo repositories.Invitation
+	access         *AccessResolver
 }
 
-func NewProjectPermsService(repo repositories.ObjectPerms, projectRepo repositories.Project, boardRepo repositories.Board) *ProjectPermsService {
-	return &ProjectPermsService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+func NewProjectPermsService(repo repositories.ObjectPerms, projectRepo repositories.Project, boardRepo repositories.Board,
+	groupRepo repositories.Group, invitationRepo repositories.Invitation, access *AccessResolver) *ProjectPermsService {
+	return &ProjectPermsService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo, groupRepo: groupRepo,
+		invitationRepo: invitationRepo, access: access}
 }
 
 func (s *ProjectPermsService) Get(userId, projectId, memberId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	_, err := s.repo.GetById(projectId, userId, IsProject)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not project member")
-			return r
-		}
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
 
-	permissions, err := s.repo.GetById(projectId, memberId, IsProject)
+	permissions, err = s.repo.GetById(projectId, memberId, IsProject)
 	if err != nil {
 		if err.Error() == DbResultNotFound {
 			r.Error(StatusNotFound, "Project member not found")
@@ -52,7 +62,19 @@ func (s *ProjectPermsService) Get(userId, projectId, memberId int) *models.ApiRe
 }
 
 func (s *ProjectPermsService) Create(userId, projectId int, memberNickname string, projectPerms *models.Permission) *models.ApiResponse {
+	invitation := &models.Invitation{Nickname: memberNickname, Permissions: projectPerms}
+	return s.invite(userId, projectId, invitation)
+}
+
+func (s *ProjectPermsService) InviteByEmail(userId, projectId int, email string, projectPerms *models.Permission) *models.ApiResponse {
+	invitation := &models.Invitation{Email: email, Permissions: projectPerms}
+	return s.invite(userId, projectId, invitation)
+}
+
+// New members are only invited: they join the project once they accept.
+func (s *ProjectPermsService) invite(userId, projectId int, invitation *models.Invitation) *models.ApiResponse {
 	r := &models.ApiResponse{}
+	projectPerms := invitation.Permissions
 
 	if err := permsValidation(projectPerms); err != nil {
 		if err.Error() == ErrPermsIsNotDefined {
@@ -72,43 +94,77 @@ func (s *ProjectPermsService) Create(userId, projectId int, memberNickname strin
 		}
 	}
 
-	permissions, err := s.repo.GetById(projectId, userId, IsProject)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not project member")
-			return r
-		}
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
 
 	if permissions.Admin != true {
 		r.Error(StatusForbidden, "Request author is not project admin")
 		return r
 	}
 
-	permissionsId, err := s.repo.Create(projectId, IsProject, memberNickname, projectPerms)
+	invitation.ProjectId = projectId
+	invitation.InviterId = userId
+	invitation.Permissions = projectPerms
+	return createInvitation(s.invitationRepo, invitation)
+}
+
+// createInvitation returns the token of an invitation to an email nobody has
+// signed up with yet. The invitee signs up with it to get the invitation, the
+// email alone is not verified.
+func createInvitation(repo repositories.Invitation, invitation *models.Invitation) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	curTime := time.Now().Unix()
+	invitation.Created = curTime
+	invitation.Expires = curTime + int64(invitationTTL.Seconds())
+	if invitation.Email != "" {
+		token, err := generateInvitationToken()
+		if err != nil {
+			r.Error(StatusInternalServerError, err.Error())
+			return r
+		}
+		invitation.Token = token
+	}
+	invitationId, err := repo.Create(invitation)
 	if err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
 
-	r.Set(StatusOK, "OK", Map{"Project permissions id": permissionsId})
+	data := Map{"invitationId": invitationId}
+	if invitation.Email != "" && invitation.InviteeId == 0 {
+		data["token"] = invitation.Token
+	}
+	r.Set(StatusOK, "OK", data)
 	return r
 }
 
+func generateInvitationToken() (string, error) {
+	buf := make([]byte, invitationTokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 func (s *ProjectPermsService) Delete(userId, projectId, memberId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	permissions, err := s.repo.GetById(projectId, userId, IsProject)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not project member")
-			return r
-		}
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
 
 	if permissions.Admin != true {
 		r.Error(StatusForbidden, "Request author is not project admin")
@@ -169,15 +225,15 @@ func (s *ProjectPermsService) Update(userId, projectId, memberId int, projectPer
 		return r
 	}
 
-	permissions, err := s.repo.GetById(projectId, userId, IsProject)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 	if err != nil {
-		if err.Error() == DbResultNotFound {
-			r.Error(StatusNotFound, "Request author is not project member")
-			return r
-		}
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
 
 	if permissions.Admin != true {
 		r.Error(StatusForbidden, "Request author is not project admin")
@@ -229,6 +285,169 @@ func (s *ProjectPermsService) Update(userId, projectId, memberId int, projectPer
 	return r
 }
 
+func (s *ProjectPermsService) GetEffective(userId, projectId, memberId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
+
+	effective, err := s.access.Project(memberId, projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"effectivePermissions": effective})
+	return r
+}
+
+func (s *ProjectPermsService) GetForGroup(userId, projectId, groupId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.groupRepo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	permissions, err = s.repo.GetByGroupId(projectId, groupId, IsProject)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Group has no permissions in the project")
+			return r
+		}
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"permissions": permissions})
+	return r
+}
+
+func (s *ProjectPermsService) CreateForGroup(userId, projectId, groupId int, projectPerms *models.Permission) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if err := permsValidation(projectPerms); err != nil {
+		r.Error(StatusBadRequest, err.Error())
+		return r
+	}
+
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
+	if permissions.Admin != true {
+		r.Error(StatusForbidden, "Request author is not project admin")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.groupRepo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	permissionsId, err := s.repo.CreateForGroup(projectId, IsProject, groupId, projectPerms)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"Project permissions id": permissionsId})
+	return r
+}
+
+func (s *ProjectPermsService) UpdateForGroup(userId, projectId, groupId int, projectPerms *models.UpdatePermission) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if err := updatePermsValidation(projectPerms); err != nil {
+		r.Error(StatusBadRequest, err.Error())
+		return r
+	}
+
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
+	if permissions.Admin != true {
+		r.Error(StatusForbidden, "Request author is not project admin")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.groupRepo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	if err = s.repo.UpdateForGroup(projectId, groupId, IsProject, projectPerms); err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Group has no permissions in the project")
+			return r
+		}
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *ProjectPermsService) DeleteForGroup(userId, projectId, groupId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
+	if permissions.Admin != true {
+		r.Error(StatusForbidden, "Request author is not project admin")
+		return r
+	}
+
+	if _, err = getProjectGroup(s.groupRepo, projectId, groupId); err != nil {
+		groupError(r, err)
+		return r
+	}
+
+	if err = s.repo.DeleteForGroup(projectId, groupId, IsProject); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
 func updatePermsValidation(updPerms *models.UpdatePermission) error {
 	if updPerms != nil {
 		switch {
@@ -263,6 +482,80 @@ func updatePermsValidation(updPerms *models.UpdatePermission) error {
 	}
 }
 
+func (s *ProjectPermsService) TransferOwnership(userId, projectId int, input *models.TransferOwnership) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if input.OldOwnerPermissions != nil {
+		if err := permsValidation(input.OldOwnerPermissions); err != nil {
+			r.Error(StatusBadRequest, err.Error())
+			return r
+		}
+	}
+
+	project, err := s.projectRepo.GetById(projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if project.OwnerId != userId {
+		r.Error(StatusForbidden, "Only project owner can transfer the project")
+		return r
+	}
+	if input.NewOwnerId == userId {
+		r.Error(StatusBadRequest, "User already owns the project")
+		return r
+	}
+
+	_, err = s.repo.GetById(projectId, input.NewOwnerId, IsProject)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "New owner is not project member")
+			return r
+		}
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	transfer := &models.OwnershipTransfer{
+		ProjectId:  projectId,
+		OldOwnerId: userId,
+		NewOwnerId: input.NewOwnerId,
+		AuthorId:   userId,
+		Created:    time.Now().Unix(),
+	}
+	transferId, err := s.repo.TransferOwnership(projectId, IsProject, transfer, input.OldOwnerPermissions)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"transferId": transferId})
+	return r
+}
+
+func (s *ProjectPermsService) GetTransfers(userId, projectId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if permissions.Read == false {
+		r.Error(StatusNotFound, "Request author is not project member")
+		return r
+	}
+
+	transfers, err := s.repo.GetTransfers(projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"transfers": transfers})
+	return r
+}
+
 func permsValidation(perms *models.Permission) error {
 	if perms != nil {
 		if perms.Read == true && perms.Write == false && perms.Admin == false ||
@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"github.com/architectv/networking-course-project/backend/pkg/builders"
 	"github.com/architectv/networking-course-project/backend/pkg/models"
@@ -23,8 +24,8 @@ func TestProjectPermsService_Create(t *testing.T) {
 	}
 
 	type projectMockBehavior func(r *mock_repositories.MockProject, projectId int)
-	type getMockBehavior func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int)
-	type mockBehavior func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission)
+	type getMockBehavior func(r *mock_repositories.MockAccess, userId, projectId int)
+	type mockBehavior func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission)
 
 	tests := []struct {
 		name                string
@@ -45,15 +46,15 @@ func TestProjectPermsService_Create(t *testing.T) {
 				defPerms:       builders.NewPermsBuilder().Build(),
 			},
 			projectMock: func(r *mock_repositories.MockProject, projectId int) {},
-			getMock: func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {
-				r.EXPECT().GetById(projectId, userId, objectType).Return(&models.Permission{true, true, true}, nil)
+			getMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, true}), nil)
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
-				r.EXPECT().Create(projectId, objectType, memberNickname, permissions).Return(1, nil)
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
+				r.EXPECT().Create(invitationMatcher{projectId, 0, userId, memberNickname, permissions}).Return(1, nil)
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusOK,
-				Data: Map{"Project permissions id": 1},
+				Data: Map{"invitationId": 1},
 			},
 		},
 		{
@@ -70,15 +71,15 @@ func TestProjectPermsService_Create(t *testing.T) {
 				r.EXPECT().GetById(projectId).Return(&models.Project{1, 1, &models.Permission{true, true, false},
 					&models.Datetimes{1, 1, 1}, "title", "description"}, nil)
 			},
-			getMock: func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {
-				r.EXPECT().GetById(projectId, userId, objectType).Return(&models.Permission{true, true, true}, nil)
+			getMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, true}), nil)
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
-				r.EXPECT().Create(projectId, objectType, memberNickname, permissions).Return(1, nil)
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
+				r.EXPECT().Create(invitationMatcher{projectId, 0, userId, memberNickname, permissions}).Return(1, nil)
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusOK,
-				Data: Map{"Project permissions id": 1},
+				Data: Map{"invitationId": 1},
 			},
 		},
 		{
@@ -94,8 +95,8 @@ func TestProjectPermsService_Create(t *testing.T) {
 			projectMock: func(r *mock_repositories.MockProject, projectId int) {
 				r.EXPECT().GetById(projectId).Return(nil, errors.New("some error"))
 			},
-			getMock: func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
+			getMock: func(r *mock_repositories.MockAccess, userId, projectId int) {},
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -115,8 +116,8 @@ func TestProjectPermsService_Create(t *testing.T) {
 				r.EXPECT().GetById(projectId).Return(&models.Project{1, 1, nil,
 					&models.Datetimes{1, 1, 1}, "title", "description"}, nil)
 			},
-			getMock: func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
+			getMock: func(r *mock_repositories.MockAccess, userId, projectId int) {},
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -133,8 +134,8 @@ func TestProjectPermsService_Create(t *testing.T) {
 				defPerms:       builders.NewPermsBuilder().Build(),
 			},
 			projectMock: func(r *mock_repositories.MockProject, projectId int) {},
-			getMock:     func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
+			getMock:     func(r *mock_repositories.MockAccess, userId, projectId int) {},
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusBadRequest,
@@ -151,10 +152,10 @@ func TestProjectPermsService_Create(t *testing.T) {
 				defPerms:       builders.NewPermsBuilder().Build(),
 			},
 			projectMock: func(r *mock_repositories.MockProject, projectId int) {},
-			getMock: func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {
-				r.EXPECT().GetById(projectId, userId, objectType).Return(nil, errors.New(DbResultNotFound))
+			getMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(nil), nil)
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusNotFound,
@@ -171,10 +172,10 @@ func TestProjectPermsService_Create(t *testing.T) {
 				defPerms:       builders.NewPermsBuilder().Build(),
 			},
 			projectMock: func(r *mock_repositories.MockProject, projectId int) {},
-			getMock: func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {
-				r.EXPECT().GetById(projectId, userId, objectType).Return(nil, errors.New("some error"))
+			getMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(nil, errors.New("some error"))
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -191,10 +192,10 @@ func TestProjectPermsService_Create(t *testing.T) {
 				defPerms:       builders.NewPermsBuilder().Build(),
 			},
 			projectMock: func(r *mock_repositories.MockProject, projectId int) {},
-			getMock: func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {
-				r.EXPECT().GetById(projectId, userId, objectType).Return(&models.Permission{true, true, false}, nil)
+			getMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, false}), nil)
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
@@ -211,11 +212,11 @@ func TestProjectPermsService_Create(t *testing.T) {
 				defPerms:       builders.NewPermsBuilder().Build(),
 			},
 			projectMock: func(r *mock_repositories.MockProject, projectId int) {},
-			getMock: func(r *mock_repositories.MockObjectPerms, projectId, userId, objectType int) {
-				r.EXPECT().GetById(projectId, userId, objectType).Return(&models.Permission{true, true, true}, nil)
+			getMock: func(r *mock_repositories.MockAccess, userId, projectId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{true, true, true}), nil)
 			},
-			mock: func(r *mock_repositories.MockObjectPerms, projectId, objectType int, memberNickname string, permissions *models.Permission) {
-				r.EXPECT().Create(projectId, objectType, memberNickname, permissions).Return(0, errors.New("Some error"))
+			mock: func(r *mock_repositories.MockInvitation, projectId, userId int, memberNickname string, permissions *models.Permission) {
+				r.EXPECT().Create(invitationMatcher{projectId, 0, userId, memberNickname, permissions}).Return(0, errors.New("Some error"))
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusInternalServerError,
@@ -229,14 +230,17 @@ func TestProjectPermsService_Create(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockObjectPerms(c)
+			invitationRepo := mock_repositories.NewMockInvitation(c)
 			projectRepo := mock_repositories.NewMockProject(c)
 			boardRepo := mock_repositories.NewMockBoard(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
 
 			test.projectMock(projectRepo, test.input.projectId)
-			test.getMock(repo, test.input.projectId, test.input.userId, test.input.objectType)
-			test.mock(repo, test.input.projectId, test.input.objectType, test.input.memberNickname,
+			test.getMock(accessRepo, test.input.userId, test.input.projectId)
+			test.mock(invitationRepo, test.input.projectId, test.input.userId, test.input.memberNickname,
 				test.input.defPerms)
-			s := &ProjectPermsService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &ProjectPermsService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo,
+				invitationRepo: invitationRepo, access: NewAccessResolver(accessRepo)}
 
 			got := s.Create(test.input.userId, test.input.projectId, test.input.memberNickname, test.input.perms)
 			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
@@ -246,3 +250,197 @@ func TestProjectPermsService_Create(t *testing.T) {
 		})
 	}
 }
+
+type invitationMatcher struct {
+	projectId   int
+	boardId     int
+	inviterId   int
+	nickname    string
+	permissions *models.Permission
+}
+
+func (m invitationMatcher) Matches(x interface{}) bool {
+	invitation, ok := x.(*models.Invitation)
+	if !ok {
+		return false
+	}
+	return invitation.ProjectId == m.projectId && invitation.BoardId == m.boardId &&
+		invitation.InviterId == m.inviterId &&
+		invitation.Nickname == m.nickname && assert.ObjectsAreEqual(m.permissions, invitation.Permissions) &&
+		invitation.Expires > invitation.Created
+}
+
+func (m invitationMatcher) String() string {
+	return fmt.Sprintf("is invitation of %s to project %d board %d", m.nickname, m.projectId, m.boardId)
+}
+
+func TestProjectPermsService_TransferOwnership(t *testing.T) {
+	type args struct {
+		userId    int
+		projectId int
+		input     *models.TransferOwnership
+	}
+
+	type projectMockBehavior func(r *mock_repositories.MockProject, projectId int)
+	type mockBehavior func(r *mock_repositories.MockObjectPerms, args args)
+
+	tests := []struct {
+		name                string
+		input               args
+		projectMock         projectMockBehavior
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name: "Ok",
+			input: args{
+				userId:    1,
+				projectId: 1,
+				input: &models.TransferOwnership{
+					NewOwnerId:          2,
+					OldOwnerPermissions: &models.Permission{Read: true, Write: true},
+				},
+			},
+			projectMock: func(r *mock_repositories.MockProject, projectId int) {
+				r.EXPECT().GetById(projectId).Return(&models.Project{Id: projectId, OwnerId: 1}, nil)
+			},
+			mock: func(r *mock_repositories.MockObjectPerms, args args) {
+				r.EXPECT().GetById(args.projectId, args.input.NewOwnerId, IsProject).
+					Return(&models.Permission{Read: true}, nil)
+				r.EXPECT().TransferOwnership(args.projectId, IsProject, gomock.Any(), args.input.OldOwnerPermissions).
+					DoAndReturn(func(objectId, objectType int, transfer *models.OwnershipTransfer,
+						oldOwnerPerms *models.Permission) (int, error) {
+						if transfer.OldOwnerId != args.userId || transfer.NewOwnerId != args.input.NewOwnerId {
+							return 0, errors.New("unexpected transfer")
+						}
+						return 1, nil
+					})
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"transferId": 1},
+			},
+		},
+		{
+			name: "Incorrect old owner permissions",
+			input: args{
+				userId:    1,
+				projectId: 1,
+				input: &models.TransferOwnership{
+					NewOwnerId:          2,
+					OldOwnerPermissions: &models.Permission{Write: true},
+				},
+			},
+			projectMock: func(r *mock_repositories.MockProject, projectId int) {},
+			mock:        func(r *mock_repositories.MockObjectPerms, args args) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name: "Request author is not project owner",
+			input: args{
+				userId:    2,
+				projectId: 1,
+				input:     &models.TransferOwnership{NewOwnerId: 3},
+			},
+			projectMock: func(r *mock_repositories.MockProject, projectId int) {
+				r.EXPECT().GetById(projectId).Return(&models.Project{Id: projectId, OwnerId: 1}, nil)
+			},
+			mock: func(r *mock_repositories.MockObjectPerms, args args) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name: "Transfer to the owner",
+			input: args{
+				userId:    1,
+				projectId: 1,
+				input:     &models.TransferOwnership{NewOwnerId: 1},
+			},
+			projectMock: func(r *mock_repositories.MockProject, projectId int) {
+				r.EXPECT().GetById(projectId).Return(&models.Project{Id: projectId, OwnerId: 1}, nil)
+			},
+			mock: func(r *mock_repositories.MockObjectPerms, args args) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name: "New owner is not project member",
+			input: args{
+				userId:    1,
+				projectId: 1,
+				input:     &models.TransferOwnership{NewOwnerId: 3},
+			},
+			projectMock: func(r *mock_repositories.MockProject, projectId int) {
+				r.EXPECT().GetById(projectId).Return(&models.Project{Id: projectId, OwnerId: 1}, nil)
+			},
+			mock: func(r *mock_repositories.MockObjectPerms, args args) {
+				r.EXPECT().GetById(args.projectId, args.input.NewOwnerId, IsProject).
+					Return(nil, errors.New(DbResultNotFound))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusNotFound,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockObjectPerms(c)
+			projectRepo := mock_repositories.NewMockProject(c)
+
+			test.projectMock(projectRepo, test.input.projectId)
+			test.mock(repo, test.input)
+			s := &ProjectPermsService{repo: repo, projectRepo: projectRepo}
+
+			got := s.TransferOwnership(test.input.userId, test.input.projectId, test.input.input)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
+
+func TestCreateInvitation_Token(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		inviteeId int
+		wantToken bool
+	}{
+		{name: "Unknown email", email: "new@test.com", wantToken: true},
+		{name: "Email of a user", email: "member@test.com", inviteeId: 2},
+		{name: "Nickname"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockInvitation(c)
+			repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(invitation *models.Invitation) (int, error) {
+				assert.Equal(t, test.email != "", invitation.Token != "")
+				invitation.InviteeId = test.inviteeId
+				return 1, nil
+			})
+
+			invitation := &models.Invitation{ProjectId: 1, InviterId: 1, Email: test.email,
+				Permissions: &models.Permission{Read: true}}
+			got := createInvitation(repo, invitation)
+			assert.Equal(t, StatusOK, got.Code)
+			token, ok := got.Data.(Map)["token"]
+			assert.Equal(t, test.wantToken, ok)
+			if ok {
+				assert.Equal(t, invitation.Token, token)
+			}
+		})
+	}
+}
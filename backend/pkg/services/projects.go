@@ -7,11 +7,13 @@ import (
 )
 
 type ProjectService struct {
-	repo repositories.Project
+	repo     repositories.Project
+	access   *AccessResolver
+	notifier *Notifier
 }
 
-func NewProjectService(repo repositories.Project) *ProjectService {
-	return &ProjectService{repo: repo}
+func NewProjectService(repo repositories.Project, access *AccessResolver, notifier *Notifier) *ProjectService {
+	return &ProjectService{repo: repo, access: access, notifier: notifier}
 }
 
 func (s *ProjectService) Create(userId int, project *models.Project) *models.ApiResponse {
@@ -38,6 +40,7 @@ func (s *ProjectService) Create(userId int, project *models.Project) *models.Api
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
+	s.notifier.Watch(userId, models.WatchProject, projectId)
 
 	r.Set(StatusOK, "OK", Map{"projectId": projectId})
 	return r
@@ -57,7 +60,7 @@ func (s *ProjectService) GetAll(userId int) *models.ApiResponse {
 func (s *ProjectService) GetById(userId, projectId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	permissions, err := s.repo.GetPermissions(userId, projectId)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
@@ -74,7 +77,7 @@ func (s *ProjectService) GetById(userId, projectId int) *models.ApiResponse {
 
 func (s *ProjectService) Update(userId, projectId int, project *models.UpdateProject) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	permissions, err := s.repo.GetPermissions(userId, projectId)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 
 	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
@@ -120,7 +123,7 @@ func (s *ProjectService) Delete(userId, projectId int) *models.ApiResponse {
 func (s *ProjectService) GetMembers(userId, projectId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	permissions, err := s.repo.GetPermissions(userId, projectId)
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
 	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
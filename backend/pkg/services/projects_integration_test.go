@@ -29,7 +29,7 @@ func Test_Integration_ProjectService_Create(t *testing.T) {
 	defer db.Close()
 
 	r := postgres.NewProjectPg(db)
-	s := NewProjectService(r)
+	s := NewProjectService(r, NewAccessResolver(postgres.NewAccessPg(db)), nil)
 	type mockBehavior func(args args, id int)
 
 	tests := []struct {
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+type RelationService struct {
+	repo   repositories.Relation
+	access *AccessResolver
+}
+
+func NewRelationService(repo repositories.Relation, access *AccessResolver) *RelationService {
+	return &RelationService{repo: repo, access: access}
+}
+
+// GetAll leaves out the relations with tasks on boards the user can't read,
+// their titles and progress are not for everyone on this board to see.
+func (s *RelationService) GetAll(userId, projectId, boardId, listId, taskId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	relations, err := s.repo.GetAll(taskId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	readable := map[int]bool{boardId: true}
+	visible := make([]*models.TaskRelation, 0, len(relations))
+	for _, relation := range relations {
+		relatedBoardId := relation.Task.BoardId
+		read, ok := readable[relatedBoardId]
+		if !ok {
+			permissions, err := s.access.BoardPermissions(userId, relatedBoardId)
+			read = err == nil && permissions.Read
+			readable[relatedBoardId] = read
+		}
+		if read {
+			visible = append(visible, relation)
+		}
+	}
+
+	r.Set(StatusOK, "OK", Map{"relations": visible})
+	return r
+}
+
+// Create links the task with a task of the same project, which may be on
+// another board the user can read. Blocking links can't form a cycle.
+func (s *RelationService) Create(userId, projectId, boardId, listId, taskId int, input *models.CreateTaskRelation) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	fromId, toId := taskId, input.TaskId
+	kind := input.Kind
+	switch kind {
+	case models.RelationBlocks, models.RelationRelates:
+	case models.RelationBlockedBy:
+		fromId, toId, kind = toId, fromId, models.RelationBlocks
+	default:
+		r.Error(StatusBadRequest, "Invalid relation kind")
+		return r
+	}
+	if input.TaskId == taskId {
+		r.Error(StatusBadRequest, "Task can't be related to itself")
+		return r
+	}
+
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	board, err := s.repo.GetTaskBoard(input.TaskId)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Task not found")
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+	if board.ProjectId != projectId {
+		r.Error(StatusBadRequest, "Task is in another project")
+		return r
+	}
+	if board.Id != boardId {
+		permissions, err = s.access.BoardPermissions(userId, board.Id)
+		if err != nil || permissions.Read == false {
+			r.Error(StatusForbidden, "Forbidden")
+			return r
+		}
+	}
+
+	relationId, err := s.repo.Create(fromId, toId, kind)
+	if err != nil {
+		relationError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"relationId": relationId})
+	return r
+}
+
+func (s *RelationService) Delete(userId, projectId, boardId, listId, taskId, relationId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err := s.repo.Delete(relationId, taskId); err != nil {
+		relationError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func relationError(r *models.ApiResponse, err error) {
+	switch err.Error() {
+	case DbResultNotFound:
+		r.Error(StatusNotFound, "Relation not found")
+	case RelationCycle, RelationExists:
+		r.Error(StatusConflict, err.Error())
+	default:
+		r.Error(StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelationService_Create(t *testing.T) {
+	type args struct {
+		userId  int
+		boardId int
+		listId  int
+		taskId  int
+		input   *models.CreateTaskRelation
+	}
+	type mockBehavior func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess, input args)
+
+	write := &models.Permission{Read: true, Write: true}
+	canWrite := func(a *mock_repositories.MockAccess, input args) {
+		a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+		a.EXPECT().IsListArchived(input.listId).Return(false, nil)
+	}
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name: "Blocked By",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4,
+				input: &models.CreateTaskRelation{Kind: models.RelationBlockedBy, TaskId: 5}},
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				r.EXPECT().GetTaskBoard(5).Return(&models.Board{Id: 2, ProjectId: 1}, nil)
+				r.EXPECT().Create(5, 4, models.RelationBlocks).Return(6, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"relationId": 6},
+			},
+		},
+		{
+			name: "Other Board",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4,
+				input: &models.CreateTaskRelation{Kind: models.RelationRelates, TaskId: 5}},
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				r.EXPECT().GetTaskBoard(5).Return(&models.Board{Id: 7, ProjectId: 1}, nil)
+				a.EXPECT().GetBoardSources(input.userId, 7).
+					Return(boardSources(&models.Permission{Read: true}), nil)
+				r.EXPECT().Create(4, 5, models.RelationRelates).Return(6, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"relationId": 6},
+			},
+		},
+		{
+			name: "Other Board Without Access",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4,
+				input: &models.CreateTaskRelation{Kind: models.RelationRelates, TaskId: 5}},
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				r.EXPECT().GetTaskBoard(5).Return(&models.Board{Id: 7, ProjectId: 1}, nil)
+				a.EXPECT().GetBoardSources(input.userId, 7).Return(boardSources(nil), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name: "Other Project",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4,
+				input: &models.CreateTaskRelation{Kind: models.RelationBlocks, TaskId: 5}},
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				r.EXPECT().GetTaskBoard(5).Return(&models.Board{Id: 8, ProjectId: 9}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name: "Cycle",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4,
+				input: &models.CreateTaskRelation{Kind: models.RelationBlocks, TaskId: 5}},
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				r.EXPECT().GetTaskBoard(5).Return(&models.Board{Id: 2, ProjectId: 1}, nil)
+				r.EXPECT().Create(4, 5, models.RelationBlocks).Return(0, errors.New(RelationCycle))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusConflict,
+			},
+		},
+		{
+			name: "Itself",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4,
+				input: &models.CreateTaskRelation{Kind: models.RelationBlocks, TaskId: 4}},
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess, input args) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name: "Invalid Kind",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4,
+				input: &models.CreateTaskRelation{Kind: "duplicates", TaskId: 5}},
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess, input args) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockRelation(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo, test.input)
+			s := &RelationService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Create(test.input.userId, 1, test.input.boardId, test.input.listId,
+				test.input.taskId, test.input.input)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
+
+func TestRelationService_GetAll(t *testing.T) {
+	type mockBehavior func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess)
+
+	read := &models.Permission{Read: true}
+	relation := func(id, boardId int) *models.TaskRelation {
+		return &models.TaskRelation{Id: id, Kind: models.RelationRelates,
+			Task: &models.RelatedTask{Id: id + 10, Title: "Task", BoardId: boardId}}
+	}
+
+	tests := []struct {
+		name                string
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name: "Ok",
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess) {
+				a.EXPECT().GetBoardSources(1, 2).Return(boardSources(read), nil)
+				r.EXPECT().GetAll(4).Return([]*models.TaskRelation{
+					relation(1, 2), relation(2, 7), relation(3, 8), relation(4, 7),
+				}, nil)
+				a.EXPECT().GetBoardSources(1, 7).Return(boardSources(read), nil)
+				a.EXPECT().GetBoardSources(1, 8).Return(boardSources(nil), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"relations": []*models.TaskRelation{
+					relation(1, 2), relation(2, 7), relation(4, 7),
+				}},
+			},
+		},
+		{
+			name: "Forbidden",
+			mock: func(r *mock_repositories.MockRelation, a *mock_repositories.MockAccess) {
+				a.EXPECT().GetBoardSources(1, 2).Return(boardSources(nil), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockRelation(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo)
+			s := &RelationService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.GetAll(1, 1, 2, 3, 4)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
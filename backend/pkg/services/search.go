@@ -0,0 +1,57 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+const (
+	searchDefaultLimit = 20
+	searchMaxLimit     = 100
+)
+
+type SearchService struct {
+	repo   repositories.Search
+	access *AccessResolver
+}
+
+func NewSearchService(repo repositories.Search, access *AccessResolver) *SearchService {
+	return &SearchService{repo: repo, access: access}
+}
+
+// Search returns only the hits the user can read: the projects they are
+// members of and the boards of those projects they can read.
+func (s *SearchService) Search(userId int, query *models.SearchQuery) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" {
+		r.Error(StatusBadRequest, "Empty query")
+		return r
+	}
+	if query.Limit <= 0 {
+		query.Limit = searchDefaultLimit
+	}
+	if query.Limit > searchMaxLimit {
+		query.Limit = searchMaxLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	projectIds, boardIds, err := s.access.ReadableBoards(userId, query.ProjectId, query.BoardId)
+	if err != nil {
+		accessError(r, err)
+		return r
+	}
+
+	hits, err := s.repo.Search(query, projectIds, boardIds)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"hits": hits})
+	return r
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	type mockBehavior func(r *mock_repositories.MockSearch, a *mock_repositories.MockAccess, userId int)
+
+	hits := []*models.SearchHit{{Type: models.SearchTask, Id: 7, ProjectId: 1, BoardId: 2}}
+	readable := boardSources(&models.Permission{Read: true})
+	readable.BoardId = 2
+	hidden := boardSources(nil)
+	hidden.BoardId = 3
+
+	tests := []struct {
+		name                string
+		userId              int
+		query               *models.SearchQuery
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:   "Ok",
+			userId: 1,
+			query:  &models.SearchQuery{Text: " deploy "},
+			mock: func(r *mock_repositories.MockSearch, a *mock_repositories.MockAccess, userId int) {
+				a.EXPECT().GetReadableProjects(userId).Return([]int{1}, nil)
+				a.EXPECT().GetBoardsSources(userId, 1).Return([]*models.AccessSources{readable, hidden}, nil)
+				r.EXPECT().Search(&models.SearchQuery{Text: "deploy", Limit: searchDefaultLimit},
+					[]int{1}, []int{2}).Return(hits, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"hits": hits},
+			},
+		},
+		{
+			name:   "Empty query",
+			userId: 1,
+			query:  &models.SearchQuery{Text: "  "},
+			mock: func(r *mock_repositories.MockSearch, a *mock_repositories.MockAccess, userId int) {
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name:   "Board is not readable",
+			userId: 1,
+			query:  &models.SearchQuery{Text: "deploy", BoardId: 3},
+			mock: func(r *mock_repositories.MockSearch, a *mock_repositories.MockAccess, userId int) {
+				a.EXPECT().GetBoardSources(userId, 3).Return(hidden, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockSearch(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo, test.userId)
+			s := &SearchService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Search(test.userId, test.query)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
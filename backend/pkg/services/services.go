@@ -1,8 +1,11 @@
 package services
 
 import (
+	"io"
+
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
 )
 
 type User interface {
@@ -15,6 +18,11 @@ type User interface {
 	Update(id int, profile *models.UpdateUser) *models.ApiResponse
 }
 
+type Avatar interface {
+	Upload(userId int, content io.Reader) *models.ApiResponse
+	Get(userId int, version string, size int) *models.ApiResponse
+}
+
 type Project interface {
 	Create(userId int, project *models.Project) *models.ApiResponse
 	GetAll(userId int) *models.ApiResponse
@@ -26,36 +34,108 @@ type Project interface {
 
 type Board interface {
 	Create(userId, projectId int, board *models.Board) *models.ApiResponse
-	GetAll(userId, projectId int) *models.ApiResponse
+	GetAll(userId, projectId int, archived bool) *models.ApiResponse
 	GetById(userId, projectId, boardId int) *models.ApiResponse
 	Delete(userId, projectId, boardId int) *models.ApiResponse
 	Update(userId, projectId, boardId int, board *models.UpdateBoard) *models.ApiResponse
+	SetArchived(userId, projectId, boardId int, archived bool) *models.ApiResponse
+	Clone(userId, projectId, boardId int, input *models.CloneBoard) *models.ApiResponse
+	ImportTrello(userId, projectId int, title string, export *models.TrelloBoard, dryRun bool) *models.ApiResponse
 	GetMembers(userId, projectId, boardId int) *models.ApiResponse
 }
 
 type TaskList interface {
 	Create(userId, projectId, boardId int, list *models.TaskList) *models.ApiResponse
-	GetAll(userId, projectId, boardId int) *models.ApiResponse
+	GetAll(userId, projectId, boardId int, archived bool) *models.ApiResponse
 	GetById(userId, projectId, boardId, listId int) *models.ApiResponse
 	Delete(userId, projectId, boardId, listId int) *models.ApiResponse
 	Update(userId, projectId, boardId, listId int, list *models.UpdateTaskList) *models.ApiResponse
+	SetArchived(userId, projectId, boardId, listId int, archived bool) *models.ApiResponse
+	Move(userId, projectId, boardId, listId int, input *models.MoveTaskList) *models.ApiResponse
 }
 
 type Task interface {
 	Create(userId, projectId, boardId, listId int, list *models.Task) *models.ApiResponse
-	GetAll(userId, projectId, boardId, listId int) *models.ApiResponse
+	GetAll(userId, projectId, boardId, listId int, filterQuery, sort string, archived bool) *models.ApiResponse
+	GetSnapshot(userId, projectId, boardId int, filterQuery, sort string, archived bool) *models.ApiResponse
 	GetById(userId, projectId, boardId, listId, taskId int) *models.ApiResponse
 	Delete(userId, projectId, boardId, listId, taskId int) *models.ApiResponse
 	Update(userId, projectId, boardId, listId, taskId int, list *models.UpdateTask) *models.ApiResponse
+	Bulk(userId, projectId, boardId int, input *models.BulkTasks) *models.ApiResponse
+	Move(userId, projectId, boardId, listId, taskId int, input *models.MoveTask) *models.ApiResponse
+}
+
+type Relation interface {
+	GetAll(userId, projectId, boardId, listId, taskId int) *models.ApiResponse
+	Create(userId, projectId, boardId, listId, taskId int, input *models.CreateTaskRelation) *models.ApiResponse
+	Delete(userId, projectId, boardId, listId, taskId, relationId int) *models.ApiResponse
+}
+
+type Time interface {
+	Get(userId, projectId, boardId, listId, taskId int) *models.ApiResponse
+	UpdateEstimate(userId, projectId, boardId, listId, taskId int, input *models.UpdateTimeEstimate) *models.ApiResponse
+	CreateLog(userId, projectId, boardId, listId, taskId int, input *models.CreateTimeLog) *models.ApiResponse
+	DeleteLog(userId, projectId, boardId, listId, taskId, logId int) *models.ApiResponse
+	GetTimer(userId int) *models.ApiResponse
+	StartTimer(userId, projectId, boardId, listId, taskId int) *models.ApiResponse
+	StopTimer(userId int, input *models.StopTimer) *models.ApiResponse
+	Report(userId int, query *models.TimeReportQuery) *models.ApiResponse
+	ExportReport(userId int, query *models.TimeReportQuery) *models.ApiResponse
+}
+
+type Sprint interface {
+	GetAll(userId, projectId, boardId int) *models.ApiResponse
+	GetById(userId, projectId, boardId, sprintId int) *models.ApiResponse
+	Create(userId, projectId, boardId int, sprint *models.Sprint) *models.ApiResponse
+	Update(userId, projectId, boardId, sprintId int, input *models.UpdateSprint) *models.ApiResponse
+	Delete(userId, projectId, boardId, sprintId int) *models.ApiResponse
+	Start(userId, projectId, boardId, sprintId int) *models.ApiResponse
+	Complete(userId, projectId, boardId, sprintId int, input *models.CompleteSprint) *models.ApiResponse
+	Burndown(userId, projectId, boardId, sprintId int) *models.ApiResponse
+}
+
+type CustomField interface {
+	GetAll(userId, projectId, boardId int) *models.ApiResponse
+	Create(userId, projectId, boardId int, field *models.CustomField) *models.ApiResponse
+	Update(userId, projectId, boardId, fieldId int, input *models.UpdateCustomField) *models.ApiResponse
+	Delete(userId, projectId, boardId, fieldId int) *models.ApiResponse
+	SetValue(userId, projectId, boardId, listId, taskId, fieldId int, value *models.CustomValue) *models.ApiResponse
+	DeleteValue(userId, projectId, boardId, listId, taskId, fieldId int) *models.ApiResponse
+}
+
+type Attachment interface {
+	GetAll(userId, projectId, boardId, listId, taskId int) *models.ApiResponse
+	Create(userId, projectId, boardId, listId, taskId int, upload *models.AttachmentUpload) *models.ApiResponse
+	Download(userId, projectId, boardId, listId, taskId, attachmentId int) *models.ApiResponse
+	Delete(userId, projectId, boardId, listId, taskId, attachmentId int) *models.ApiResponse
+}
+
+type Notification interface {
+	GetAll(userId int, unread bool, limit, offset int) *models.ApiResponse
+	MarkRead(userId, notificationId int) *models.ApiResponse
+	MarkAllRead(userId int) *models.ApiResponse
+	GetPreferences(userId int) *models.ApiResponse
+	UpdatePreferences(userId int, preferences map[string]bool) *models.ApiResponse
+	GetWatches(userId int) *models.ApiResponse
+	Watch(userId int, watch *models.Watch) *models.ApiResponse
+	Unwatch(userId int, watch *models.Watch) *models.ApiResponse
+}
+
+type Mail interface {
+	GetSettings(userId int) *models.ApiResponse
+	UpdateSettings(userId int, input *models.UpdateMailSettings) *models.ApiResponse
+	Unsubscribe(userId int, list, token string) *models.ApiResponse
+	SendDigests(now int64) error
+	SendReminders(now int64) error
 }
 
 type Label interface {
 	Create(userId, projectId, boardId int, label *models.Label) *models.ApiResponse
-	CreateInTask(userId, projectId, boardId, taskId, labelId int) *models.ApiResponse
+	CreateInTask(userId, projectId, boardId, listId, taskId, labelId int) *models.ApiResponse
 	GetAllInTask(userId, projectId, boardId, taskId int) *models.ApiResponse
 	GetAll(userId, projectId, boardId int) *models.ApiResponse
 	GetById(userId, projectId, boardId, labelId int) *models.ApiResponse
-	DeleteInTask(userId, projectId, boardId, taskId, labelId int) *models.ApiResponse
+	DeleteInTask(userId, projectId, boardId, listId, taskId, labelId int) *models.ApiResponse
 	Delete(userId, projectId, boardId, labelId int) *models.ApiResponse
 	Update(userId, projectId, boardId, labelId int, label *models.UpdateLabel) *models.ApiResponse
 }
@@ -66,9 +146,17 @@ type UrlValidator interface {
 
 type ProjectPerms interface {
 	Create(userId, projectId int, memberNickname string, permissions *models.Permission) *models.ApiResponse
+	InviteByEmail(userId, projectId int, email string, permissions *models.Permission) *models.ApiResponse
 	Get(userId, projectId, memberId int) *models.ApiResponse
 	Delete(userId, projectId, memberId int) *models.ApiResponse
 	Update(userId, projectId, memberId int, list *models.UpdatePermission) *models.ApiResponse
+	GetEffective(userId, projectId, memberId int) *models.ApiResponse
+	GetForGroup(userId, projectId, groupId int) *models.ApiResponse
+	CreateForGroup(userId, projectId, groupId int, permissions *models.Permission) *models.ApiResponse
+	UpdateForGroup(userId, projectId, groupId int, permissions *models.UpdatePermission) *models.ApiResponse
+	DeleteForGroup(userId, projectId, groupId int) *models.ApiResponse
+	TransferOwnership(userId, projectId int, input *models.TransferOwnership) *models.ApiResponse
+	GetTransfers(userId, projectId int) *models.ApiResponse
 }
 
 type BoardPerms interface {
@@ -76,30 +164,143 @@ type BoardPerms interface {
 	Get(userId, projectId, boardId, memberId int) *models.ApiResponse
 	Delete(userId, projectId, boardId, memberId int) *models.ApiResponse
 	Update(userId, projectId, boardId, memberId int, list *models.UpdatePermission) *models.ApiResponse
+	GetEffective(userId, projectId, boardId, memberId int) *models.ApiResponse
+	Deny(userId, projectId, boardId, memberId int, permissions *models.Permission) *models.ApiResponse
+	DeleteDeny(userId, projectId, boardId, memberId int) *models.ApiResponse
+	GetForGroup(userId, projectId, boardId, groupId int) *models.ApiResponse
+	CreateForGroup(userId, projectId, boardId, groupId int, permissions *models.Permission) *models.ApiResponse
+	UpdateForGroup(userId, projectId, boardId, groupId int, permissions *models.UpdatePermission) *models.ApiResponse
+	DeleteForGroup(userId, projectId, boardId, groupId int) *models.ApiResponse
+	TransferOwnership(userId, projectId, boardId int, input *models.TransferOwnership) *models.ApiResponse
+}
+
+type Group interface {
+	Create(userId, projectId int, group *models.Group) *models.ApiResponse
+	GetAll(userId, projectId int) *models.ApiResponse
+	GetById(userId, projectId, groupId int) *models.ApiResponse
+	Delete(userId, projectId, groupId int) *models.ApiResponse
+	Update(userId, projectId, groupId int, group *models.UpdateGroup) *models.ApiResponse
+	GetMembers(userId, projectId, groupId int) *models.ApiResponse
+	AddMember(userId, projectId, groupId int, memberNickname string) *models.ApiResponse
+	DeleteMember(userId, projectId, groupId, memberId int) *models.ApiResponse
+}
+
+type Invitation interface {
+	GetAll(userId, projectId int) *models.ApiResponse
+	Delete(userId, projectId, invitationId int) *models.ApiResponse
+	GetAllForUser(userId int) *models.ApiResponse
+	Accept(userId, invitationId int) *models.ApiResponse
+	Decline(userId, invitationId int) *models.ApiResponse
+}
+
+type BoardShare interface {
+	Create(userId, projectId, boardId int, input *models.BoardShareInput) *models.ApiResponse
+	GetAll(userId, projectId, boardId int) *models.ApiResponse
+	Delete(userId, projectId, boardId, shareId int) *models.ApiResponse
+	ParseShareToken(token, password string) (*models.BoardShare, error)
+}
+
+type Template interface {
+	Create(userId, projectId, boardId int, input *models.SaveTemplate) *models.ApiResponse
+	GetAll(userId, projectId int) *models.ApiResponse
+	GetById(userId, projectId, templateId int) *models.ApiResponse
+	Delete(userId, projectId, templateId int) *models.ApiResponse
+}
+
+type View interface {
+	Create(userId, projectId, boardId int, view *models.BoardView) *models.ApiResponse
+	GetAll(userId, projectId, boardId int) *models.ApiResponse
+	GetById(userId, projectId, boardId, viewId int) *models.ApiResponse
+	Update(userId, projectId, boardId, viewId int, input *models.UpdateBoardView) *models.ApiResponse
+	Delete(userId, projectId, boardId, viewId int) *models.ApiResponse
+	SetDefault(userId, projectId, boardId, viewId int) *models.ApiResponse
+	DeleteDefault(userId, projectId, boardId int) *models.ApiResponse
+}
+
+type Export interface {
+	Export(userId, projectId int) *models.ApiResponse
+	Import(userId int, archive *models.ProjectExport) *models.ApiResponse
+}
+
+type TaskCsv interface {
+	Export(userId, projectId, boardId int) *models.ApiResponse
+	Import(userId, projectId, boardId int, data []byte, createLists bool) *models.ApiResponse
+}
+
+type Search interface {
+	Search(userId int, query *models.SearchQuery) *models.ApiResponse
+}
+
+type Trash interface {
+	GetAll(userId, projectId int) *models.ApiResponse
+	GetProjects(userId int) *models.ApiResponse
+	Restore(userId, projectId int, itemType string, itemId int) *models.ApiResponse
+	RestoreProject(userId, projectId int) *models.ApiResponse
+	Purge(before int64) error
 }
 
 type Service struct {
 	User
+	Avatar
 	Project
 	Board
 	TaskList
 	Task
+	Relation
+	Time
+	Sprint
+	CustomField
+	Attachment
+	Notification
+	Mail
 	Label
 	UrlValidator
 	ProjectPerms
 	BoardPerms
+	Group
+	Invitation
+	BoardShare
+	Template
+	View
+	Export
+	TaskCsv
+	Search
+	Trash
 }
 
-func NewService(repos *repositories.Repository) *Service {
+func NewService(repos *repositories.Repository, blobs storage.Storage, limits AttachmentLimits,
+	avatarMaxSize int64, mail MailConfig) *Service {
+	access := NewAccessResolver(repos.Access)
+	mailer := NewMailService(repos.Mail, mail)
+	notifier := NewNotifier(repos.Notification, access, mailer)
+	mentions := NewMentioner(repos.Mention, repos.ObjectPerms, access, notifier)
+	fields := NewCustomFieldService(repos.CustomField, access)
 	return &Service{
 		User:         NewUserService(repos.User),
-		Project:      NewProjectService(repos.Project),
-		Board:        NewBoardService(repos.Board, repos.Project),
-		TaskList:     NewTaskListService(repos.TaskList, repos.Board, repos.Project),
-		Task:         NewTaskService(repos.Task, repos.Board, repos.Project),
-		Label:        NewLabelService(repos.Label, repos.Board, repos.Project),
+		Avatar:       NewAvatarService(repos.User, blobs, avatarMaxSize),
+		Project:      NewProjectService(repos.Project, access, notifier),
+		Board:        NewBoardService(repos.Board, repos.Template, access, notifier),
+		TaskList:     NewTaskListService(repos.TaskList, access, notifier),
+		Task:         NewTaskService(repos.Task, repos.TaskList, access, notifier, mentions, fields),
+		Relation:     NewRelationService(repos.Relation, access),
+		Time:         NewTimeService(repos.Time, access),
+		Sprint:       NewSprintService(repos.Sprint, access),
+		CustomField:  fields,
+		Attachment:   NewAttachmentService(repos.Attachment, blobs, limits, access),
+		Notification: NewNotificationService(repos.Notification, access),
+		Mail:         mailer,
+		Label:        NewLabelService(repos.Label, access),
 		UrlValidator: NewUrlValidatorService(repos.Board, repos.TaskList, repos.Task),
-		ProjectPerms: NewProjectPermsService(repos.ObjectPerms, repos.Project, repos.Board),
-		BoardPerms:   NewBoardPermsService(repos.ObjectPerms, repos.Board, repos.Project),
+		ProjectPerms: NewProjectPermsService(repos.ObjectPerms, repos.Project, repos.Board, repos.Group, repos.Invitation, access),
+		BoardPerms:   NewBoardPermsService(repos.ObjectPerms, repos.Board, repos.Project, repos.Group, repos.Invitation, access),
+		Group:        NewGroupService(repos.Group, repos.Project, repos.Invitation, access),
+		Invitation:   NewInvitationService(repos.Invitation, access),
+		BoardShare:   NewBoardShareService(repos.BoardShare, access),
+		Template:     NewTemplateService(repos.Template, repos.Board, access),
+		View:         NewViewService(repos.View, repos.TaskList, access, fields),
+		Export:       NewExportService(repos.Export, access),
+		TaskCsv:      NewTaskCsvService(repos.Task, repos.TaskList, repos.Label, access),
+		Search:       NewSearchService(repos.Search, access),
+		Trash:        NewTrashService(repos.Trash, blobs, access),
 	}
 }
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+const shareTokenLength = 24
+
+type BoardShareService struct {
+	repo   repositories.BoardShare
+	access *AccessResolver
+}
+
+func NewBoardShareService(repo repositories.BoardShare, access *AccessResolver) *BoardShareService {
+	return &BoardShareService{repo: repo, access: access}
+}
+
+func (s *BoardShareService) GetAll(userId, projectId, boardId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	shares, err := s.repo.GetAll(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"shares": shares})
+	return r
+}
+
+func (s *BoardShareService) Create(userId, projectId, boardId int, input *models.BoardShareInput) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	curTime := time.Now().Unix()
+	if input.Expires != 0 && input.Expires <= curTime {
+		r.Error(StatusBadRequest, "Share link expiration is in the past")
+		return r
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	share := &models.BoardShare{
+		BoardId: boardId,
+		OwnerId: userId,
+		Token:   token,
+		Created: curTime,
+		Expires: input.Expires,
+	}
+	if input.Password != "" {
+		share.Password = generatePasswordHash(input.Password)
+	}
+
+	shareId, err := s.repo.Create(share)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"shareId": shareId, "token": token})
+	return r
+}
+
+func (s *BoardShareService) Delete(userId, projectId, boardId, shareId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	share, err := s.repo.GetById(shareId)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Share link not found")
+			return r
+		}
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	if share.BoardId != boardId {
+		r.Error(StatusNotFound, "Share link not found")
+		return r
+	}
+
+	if err = s.repo.Delete(shareId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *BoardShareService) ParseShareToken(token, password string) (*models.BoardShare, error) {
+	share, err := s.repo.GetByToken(token)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			return nil, errors.New("Invalid share link")
+		}
+		return nil, err
+	}
+
+	if share.Expires != 0 && share.Expires <= time.Now().Unix() {
+		return nil, errors.New("Share link has expired")
+	}
+	if share.HasPassword && generatePasswordHash(password) != share.Password {
+		return nil, errors.New("Invalid share link password")
+	}
+
+	return share, nil
+}
+
+func generateShareToken() (string, error) {
+	buf := make([]byte, shareTokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
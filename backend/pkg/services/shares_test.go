@@ -0,0 +1,107 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardShareService_ParseShareToken(t *testing.T) {
+	type args struct {
+		token    string
+		password string
+	}
+	type mockBehavior func(r *mock_repositories.MockBoardShare, token string)
+
+	tests := []struct {
+		name    string
+		input   args
+		mock    mockBehavior
+		want    *models.BoardShare
+		wantErr bool
+	}{
+		{
+			name:  "Ok",
+			input: args{token: "token"},
+			mock: func(r *mock_repositories.MockBoardShare, token string) {
+				r.EXPECT().GetByToken(token).Return(&models.BoardShare{Id: 1, BoardId: 2, Token: token}, nil)
+			},
+			want: &models.BoardShare{Id: 1, BoardId: 2, Token: "token"},
+		},
+		{
+			name:  "Ok with password",
+			input: args{token: "token", password: "secret"},
+			mock: func(r *mock_repositories.MockBoardShare, token string) {
+				r.EXPECT().GetByToken(token).Return(&models.BoardShare{Id: 1, BoardId: 2, Token: token,
+					Password: generatePasswordHash("secret"), HasPassword: true}, nil)
+			},
+			want: &models.BoardShare{Id: 1, BoardId: 2, Token: "token",
+				Password: generatePasswordHash("secret"), HasPassword: true},
+		},
+		{
+			name:  "Wrong password",
+			input: args{token: "token", password: "guess"},
+			mock: func(r *mock_repositories.MockBoardShare, token string) {
+				r.EXPECT().GetByToken(token).Return(&models.BoardShare{Id: 1, BoardId: 2, Token: token,
+					Password: generatePasswordHash("secret"), HasPassword: true}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Expired",
+			input: args{token: "token"},
+			mock: func(r *mock_repositories.MockBoardShare, token string) {
+				r.EXPECT().GetByToken(token).Return(&models.BoardShare{Id: 1, BoardId: 2, Token: token,
+					Expires: time.Now().Add(-time.Hour).Unix()}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Revoked",
+			input: args{token: "token"},
+			mock: func(r *mock_repositories.MockBoardShare, token string) {
+				r.EXPECT().GetByToken(token).Return(nil, errors.New(DbResultNotFound))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockBoardShare(c)
+			test.mock(repo, test.input.token)
+			s := &BoardShareService{repo: repo}
+
+			got, err := s.ParseShareToken(test.input.token, test.input.password)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestAccessResolver_BoardGuest(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repositories.NewMockAccess(c)
+	repo.EXPECT().GetShareSources(3, 2, gomock.Any()).
+		Return(&models.AccessSources{ProjectId: 1, BoardId: 2, IsShareGuest: true}, nil)
+
+	permissions, err := NewAccessResolver(repo).BoardPermissions(GuestPrincipal(3), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Permission{Read: true}, permissions)
+}
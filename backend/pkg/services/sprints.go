@@ -0,0 +1,310 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+const burndownStep = int64(24 * time.Hour / time.Second)
+
+type SprintService struct {
+	repo   repositories.Sprint
+	access *AccessResolver
+}
+
+func NewSprintService(repo repositories.Sprint, access *AccessResolver) *SprintService {
+	return &SprintService{repo: repo, access: access}
+}
+
+func (s *SprintService) GetAll(userId, projectId, boardId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	sprints, err := s.repo.GetAll(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"sprints": sprints})
+	return r
+}
+
+// GetById returns the sprint with its tasks and their points.
+func (s *SprintService) GetById(userId, projectId, boardId, sprintId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	sprint, err := s.getBoardSprint(boardId, sprintId)
+	if err != nil {
+		sprintError(r, err)
+		return r
+	}
+
+	tasks, err := s.repo.GetTasks(sprintId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	var points, completed int
+	for _, task := range tasks {
+		points += task.Points
+		if task.Done {
+			completed += task.Points
+		}
+	}
+
+	r.Set(StatusOK, "OK", Map{"sprint": sprint, "tasks": tasks,
+		"points": points, "completedPoints": completed})
+	return r
+}
+
+func (s *SprintService) Create(userId, projectId, boardId int, sprint *models.Sprint) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if sprint.Start >= sprint.End {
+		r.Error(StatusBadRequest, "Invalid sprint dates")
+		return r
+	}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	sprint.BoardId = boardId
+	sprintId, err := s.repo.Create(sprint)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"sprintId": sprintId})
+	return r
+}
+
+func (s *SprintService) Update(userId, projectId, boardId, sprintId int, input *models.UpdateSprint) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	sprint, err := s.getBoardSprint(boardId, sprintId)
+	if err != nil {
+		sprintError(r, err)
+		return r
+	}
+
+	start, end := sprint.Start, sprint.End
+	if input.Start != nil {
+		start = *input.Start
+	}
+	if input.End != nil {
+		end = *input.End
+	}
+	if start >= end {
+		r.Error(StatusBadRequest, "Invalid sprint dates")
+		return r
+	}
+
+	if err = s.repo.Update(sprintId, input); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// Delete puts the tasks of the sprint back to the backlog.
+func (s *SprintService) Delete(userId, projectId, boardId, sprintId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err := s.getBoardSprint(boardId, sprintId); err != nil {
+		sprintError(r, err)
+		return r
+	}
+
+	if err = s.repo.Delete(sprintId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// Start makes a planned sprint the active one, a board has one at most.
+func (s *SprintService) Start(userId, projectId, boardId, sprintId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err := s.getBoardSprint(boardId, sprintId); err != nil {
+		sprintError(r, err)
+		return r
+	}
+
+	if err = s.repo.Start(sprintId, time.Now().Unix()); err != nil {
+		sprintError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// Complete closes the active sprint, its unfinished tasks go to the planned
+// sprint of the input or to the backlog.
+func (s *SprintService) Complete(userId, projectId, boardId, sprintId int, input *models.CompleteSprint) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if input.CarryOverTo == sprintId {
+		r.Error(StatusBadRequest, "Invalid carry over sprint")
+		return r
+	}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err := s.getBoardSprint(boardId, sprintId); err != nil {
+		sprintError(r, err)
+		return r
+	}
+
+	carried, err := s.repo.Complete(sprintId, input.CarryOverTo, time.Now().Unix())
+	if err != nil {
+		sprintError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"carriedOver": carried})
+	return r
+}
+
+// Burndown returns the scope and the completed points of the sprint at the
+// end of each of its days so far, which draw both burndown and burnup charts.
+func (s *SprintService) Burndown(userId, projectId, boardId, sprintId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	sprint, err := s.getBoardSprint(boardId, sprintId)
+	if err != nil {
+		sprintError(r, err)
+		return r
+	}
+
+	snapshots, err := s.repo.GetHistory(sprintId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"sprint": sprint,
+		"burndown": burndown(sprint, snapshots, time.Now().Unix())})
+	return r
+}
+
+func (s *SprintService) getBoardSprint(boardId, sprintId int) (*models.Sprint, error) {
+	sprint, err := s.repo.GetById(sprintId)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.BoardId != boardId {
+		return nil, errors.New(DbResultNotFound)
+	}
+	return sprint, nil
+}
+
+// burndown replays the snapshots, sorted by time, a day at a time from the
+// start of the sprint until it ended or now.
+func burndown(sprint *models.Sprint, snapshots []*models.TaskSnapshot, now int64) []*models.BurndownPoint {
+	start, end := sprint.Start, sprint.End
+	if sprint.Started != 0 {
+		start = sprint.Started
+	}
+	if sprint.Completed != 0 {
+		end = sprint.Completed
+	}
+	if now < end {
+		end = now
+	}
+
+	points := make([]*models.BurndownPoint, 0)
+	if end < start {
+		return points
+	}
+	tasks := make(map[int]*models.TaskSnapshot)
+	next := 0
+	for date := start; len(points) == 0 || points[len(points)-1].Date < end; date += burndownStep {
+		if date > end {
+			// The last point is the current or the final state.
+			date = end
+		}
+		for ; next < len(snapshots) && snapshots[next].Created <= date; next++ {
+			tasks[snapshots[next].TaskId] = snapshots[next]
+		}
+
+		point := &models.BurndownPoint{Date: date}
+		for _, task := range tasks {
+			if task.SprintId != sprint.Id {
+				continue
+			}
+			point.Scope += task.Points
+			if task.Done {
+				point.Completed += task.Points
+			}
+		}
+		point.Remaining = point.Scope - point.Completed
+		points = append(points, point)
+	}
+
+	scope := float64(points[0].Scope)
+	for _, point := range points {
+		if sprint.End > start && point.Date < sprint.End {
+			point.Ideal = scope * float64(sprint.End-point.Date) / float64(sprint.End-start)
+		}
+	}
+	return points
+}
+
+func sprintError(r *models.ApiResponse, err error) {
+	switch err.Error() {
+	case DbResultNotFound:
+		r.Error(StatusNotFound, "Sprint not found")
+	case SprintNotOnBoard:
+		r.Error(StatusBadRequest, err.Error())
+	case SprintCompleted, SprintNotPlanned, SprintNotActive, SprintActive:
+		r.Error(StatusConflict, err.Error())
+	default:
+		r.Error(StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBurndown(t *testing.T) {
+	day := burndownStep
+	sprint := &models.Sprint{Id: 1, Start: 0, End: 4 * day, Started: 0, State: models.SprintActive}
+	snapshots := []*models.TaskSnapshot{
+		{TaskId: 1, SprintId: 1, Points: 3, Created: -day},
+		{TaskId: 2, SprintId: 1, Points: 5, Created: -day},
+		{TaskId: 1, SprintId: 1, Points: 3, Done: true, Created: day / 2},
+		{TaskId: 3, SprintId: 1, Points: 2, Created: day + 1},
+		{TaskId: 2, SprintId: 0, Points: 5, Created: 2 * day},
+	}
+
+	got := burndown(sprint, snapshots, 2*day+day/2)
+	assert.Equal(t, []*models.BurndownPoint{
+		{Date: 0, Scope: 8, Completed: 0, Remaining: 8, Ideal: 8},
+		{Date: day, Scope: 8, Completed: 3, Remaining: 5, Ideal: 6},
+		{Date: 2 * day, Scope: 5, Completed: 3, Remaining: 2, Ideal: 4},
+		{Date: 2*day + day/2, Scope: 5, Completed: 3, Remaining: 2, Ideal: 3},
+	}, got)
+
+	assert.Equal(t, []*models.BurndownPoint{}, burndown(sprint, snapshots, -1))
+}
+
+func TestSprintService_Complete(t *testing.T) {
+	type mockBehavior func(r *mock_repositories.MockSprint, a *mock_repositories.MockAccess)
+
+	permissions := func(a *mock_repositories.MockAccess) {
+		a.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{Read: true, Write: true}), nil)
+	}
+
+	tests := []struct {
+		name         string
+		carryOverTo  int
+		mock         mockBehavior
+		expectedCode int
+	}{
+		{
+			name:        "Ok",
+			carryOverTo: 4,
+			mock: func(r *mock_repositories.MockSprint, a *mock_repositories.MockAccess) {
+				permissions(a)
+				r.EXPECT().GetById(3).Return(&models.Sprint{Id: 3, BoardId: 2}, nil)
+				r.EXPECT().Complete(3, 4, gomock.Any()).Return([]int{5}, nil)
+			},
+			expectedCode: StatusOK,
+		},
+		{
+			name: "Sprint Of Another Board",
+			mock: func(r *mock_repositories.MockSprint, a *mock_repositories.MockAccess) {
+				permissions(a)
+				r.EXPECT().GetById(3).Return(&models.Sprint{Id: 3, BoardId: 6}, nil)
+			},
+			expectedCode: StatusNotFound,
+		},
+		{
+			name: "Not Active",
+			mock: func(r *mock_repositories.MockSprint, a *mock_repositories.MockAccess) {
+				permissions(a)
+				r.EXPECT().GetById(3).Return(&models.Sprint{Id: 3, BoardId: 2}, nil)
+				r.EXPECT().Complete(3, 0, gomock.Any()).Return(nil, errors.New(SprintNotActive))
+			},
+			expectedCode: StatusConflict,
+		},
+		{
+			name:        "Carry Over To Another Board",
+			carryOverTo: 4,
+			mock: func(r *mock_repositories.MockSprint, a *mock_repositories.MockAccess) {
+				permissions(a)
+				r.EXPECT().GetById(3).Return(&models.Sprint{Id: 3, BoardId: 2}, nil)
+				r.EXPECT().Complete(3, 4, gomock.Any()).Return(nil, errors.New(SprintNotOnBoard))
+			},
+			expectedCode: StatusBadRequest,
+		},
+		{
+			name:         "Carry Over To Itself",
+			carryOverTo:  3,
+			mock:         func(r *mock_repositories.MockSprint, a *mock_repositories.MockAccess) {},
+			expectedCode: StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockSprint(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo)
+			s := &SprintService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Complete(1, 1, 2, 3, &models.CompleteSprint{CarryOverTo: test.carryOverTo})
+			assert.Equal(t, test.expectedCode, got.Code)
+		})
+	}
+}
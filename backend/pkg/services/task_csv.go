@@ -0,0 +1,254 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+const csvLabelsSeparator = ";"
+
+var taskCsvHeader = []string{
+	"list", "position", "title", "description", "labels", "created", "updated", "accessed",
+}
+
+type TaskCsvService struct {
+	repo      repositories.Task
+	listRepo  repositories.TaskList
+	labelRepo repositories.Label
+	access    *AccessResolver
+}
+
+func NewTaskCsvService(repo repositories.Task, listRepo repositories.TaskList,
+	labelRepo repositories.Label, access *AccessResolver) *TaskCsvService {
+	return &TaskCsvService{repo: repo, listRepo: listRepo, labelRepo: labelRepo, access: access}
+}
+
+// Export returns the tasks of the board as CSV text.
+func (s *TaskCsvService) Export(userId, projectId, boardId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	records, err := s.repo.GetAllInBoard(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	writer.Write(taskCsvHeader)
+	for _, record := range records {
+		writer.Write([]string{
+			record.ListTitle,
+			strconv.Itoa(record.Position),
+			record.Title,
+			record.Description,
+			strings.Join(record.Labels, csvLabelsSeparator),
+			formatCsvTime(record.Datetimes.Created),
+			formatCsvTime(record.Datetimes.Updated),
+			formatCsvTime(record.Datetimes.Accessed),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", buf.String())
+	return r
+}
+
+// Import appends a task to the named list for every row of the CSV. The list
+// and title columns are required, description and labels are optional, and
+// the rest are ignored. Rows that cannot be imported are reported by number,
+// counting the header as the first row, and do not stop the others.
+func (s *TaskCsvService) Import(userId, projectId, boardId int, data []byte, createLists bool) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		r.Error(StatusBadRequest, "Invalid CSV header")
+		return r
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	if _, ok := columns["list"]; !ok {
+		r.Error(StatusBadRequest, "Missing list column")
+		return r
+	}
+	if _, ok := columns["title"]; !ok {
+		r.Error(StatusBadRequest, "Missing title column")
+		return r
+	}
+
+	lists, err := s.listRepo.GetAll(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	listsByTitle := make(map[string]*models.TaskList, len(lists))
+	for _, list := range lists {
+		if _, ok := listsByTitle[list.Title]; !ok {
+			listsByTitle[list.Title] = list
+		}
+	}
+
+	labels, err := s.labelRepo.GetAll(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	labelsByName := make(map[string]int, len(labels))
+	for _, label := range labels {
+		labelId, err := strconv.Atoi(label.Id)
+		if err != nil {
+			r.Error(StatusInternalServerError, err.Error())
+			return r
+		}
+		labelsByName[strings.ToLower(label.Name)] = labelId
+	}
+
+	rowErrors := make([]*models.CsvRowError, 0)
+	tasksCount, listsCount := 0, 0
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, &models.CsvRowError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		input, err := parseCsvTask(record, columns, labelsByName)
+		if err != nil {
+			rowErrors = append(rowErrors, &models.CsvRowError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		list, ok := listsByTitle[input.listTitle]
+		switch {
+		case ok && list.Archived:
+			err = fmt.Errorf("List %q is archived", input.listTitle)
+		case !ok && !createLists:
+			err = fmt.Errorf("List %q not found", input.listTitle)
+		case !ok:
+			list = &models.TaskList{BoardId: boardId, Title: input.listTitle}
+			list.Id, err = s.listRepo.Create(list)
+			if err == nil {
+				listsByTitle[list.Title] = list
+				listsCount++
+			}
+		}
+		if err == nil {
+			err = s.createCsvTask(list.Id, input)
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, &models.CsvRowError{Row: row, Message: err.Error()})
+			continue
+		}
+		tasksCount++
+	}
+
+	r.Set(StatusOK, "OK", Map{"tasks": tasksCount, "lists": listsCount, "errors": rowErrors})
+	return r
+}
+
+type csvTask struct {
+	listTitle   string
+	title       string
+	description string
+	labelIds    []int
+}
+
+func parseCsvTask(record []string, columns map[string]int, labelsByName map[string]int) (*csvTask, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	task := &csvTask{
+		listTitle:   strings.TrimSpace(field("list")),
+		title:       strings.TrimSpace(field("title")),
+		description: field("description"),
+	}
+	if task.listTitle == "" {
+		return nil, errors.New("Empty list")
+	}
+	if len([]rune(task.listTitle)) > listTitleLength {
+		return nil, fmt.Errorf("List title is longer than %d characters", listTitleLength)
+	}
+	if task.title == "" {
+		return nil, errors.New("Empty title")
+	}
+	if len([]rune(task.title)) > taskTitleLength {
+		return nil, fmt.Errorf("Title is longer than %d characters", taskTitleLength)
+	}
+
+	for _, name := range strings.Split(field("labels"), csvLabelsSeparator) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		labelId, ok := labelsByName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("Unknown label %q", name)
+		}
+		task.labelIds = append(task.labelIds, labelId)
+	}
+	return task, nil
+}
+
+func (s *TaskCsvService) createCsvTask(listId int, input *csvTask) error {
+	curTime := time.Now().Unix()
+	task := &models.Task{
+		ListId:      listId,
+		Title:       input.title,
+		Description: input.description,
+		Datetimes: &models.Datetimes{
+			Created:  curTime,
+			Updated:  curTime,
+			Accessed: curTime,
+		},
+	}
+	_, err := s.repo.CreateWithLabels(task, input.labelIds)
+	return err
+}
+
+func formatCsvTime(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
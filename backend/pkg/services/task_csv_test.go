@@ -0,0 +1,122 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskCsvService_Import(t *testing.T) {
+	type args struct {
+		userId      int
+		boardId     int
+		data        string
+		createLists bool
+	}
+	type mockBehavior func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+		lb *mock_repositories.MockLabel, boardId int)
+
+	lists := []*models.TaskList{
+		{Id: 1, BoardId: 2, Title: "To do"},
+		{Id: 3, BoardId: 2, Title: "Old", Archived: true},
+	}
+	labels := []*models.Label{{Id: "4", BoardId: 2, Name: "Bug"}}
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name: "Ok with row errors",
+			input: args{userId: 1, boardId: 2, data: "List,Title,Labels\n" +
+				"To do,First,bug\n" +
+				"To do,Second,feature\n" +
+				"Old,Third,\n" +
+				"Done,Fourth,\n" +
+				",Fifth,\n"},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				lb *mock_repositories.MockLabel, boardId int) {
+				l.EXPECT().GetAll(boardId).Return(lists, nil)
+				lb.EXPECT().GetAll(boardId).Return(labels, nil)
+				r.EXPECT().CreateWithLabels(gomock.Any(), []int{4}).Return(10, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"tasks": 1, "lists": 0, "errors": []*models.CsvRowError{
+					{Row: 3, Message: `Unknown label "feature"`},
+					{Row: 4, Message: `List "Old" is archived`},
+					{Row: 5, Message: `List "Done" not found`},
+					{Row: 6, Message: "Empty list"},
+				}},
+			},
+		},
+		{
+			name:  "Create missing lists",
+			input: args{userId: 1, boardId: 2, data: "list,title\nDone,First\nDone,Second\n", createLists: true},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				lb *mock_repositories.MockLabel, boardId int) {
+				l.EXPECT().GetAll(boardId).Return(lists, nil)
+				lb.EXPECT().GetAll(boardId).Return(labels, nil)
+				l.EXPECT().Create(&models.TaskList{BoardId: boardId, Title: "Done"}).Return(5, nil)
+				r.EXPECT().CreateWithLabels(gomock.Any(), []int(nil)).Return(10, nil).Times(2)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"tasks": 2, "lists": 1, "errors": []*models.CsvRowError{}},
+			},
+		},
+		{
+			name:  "Missing title column",
+			input: args{userId: 1, boardId: 2, data: "list,description\nTo do,First\n"},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				lb *mock_repositories.MockLabel, boardId int) {
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name:  "Repo error for lists",
+			input: args{userId: 1, boardId: 2, data: "list,title\nTo do,First\n"},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				lb *mock_repositories.MockLabel, boardId int) {
+				l.EXPECT().GetAll(boardId).Return(nil, errors.New("repo error"))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTask(c)
+			listRepo := mock_repositories.NewMockTaskList(c)
+			labelRepo := mock_repositories.NewMockLabel(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			accessRepo.EXPECT().GetBoardSources(test.input.userId, test.input.boardId).
+				Return(boardSources(&models.Permission{Read: true, Write: true}), nil)
+			test.mock(repo, listRepo, labelRepo, test.input.boardId)
+			s := &TaskCsvService{repo: repo, listRepo: listRepo, labelRepo: labelRepo,
+				access: NewAccessResolver(accessRepo)}
+
+			got := s.Import(test.input.userId, 1, test.input.boardId, []byte(test.input.data),
+				test.input.createLists)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
@@ -1,37 +1,55 @@
 package services
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/filter"
 	"github.com/architectv/networking-course-project/backend/pkg/models"
 	"github.com/architectv/networking-course-project/backend/pkg/repositories"
 )
 
 type TaskService struct {
-	repo        repositories.Task
-	boardRepo   repositories.Board
-	projectRepo repositories.Project
+	repo     repositories.Task
+	listRepo repositories.TaskList
+	access   *AccessResolver
+	notifier *Notifier
+	mentions *Mentioner
+	fields   *CustomFieldService
 }
 
-func NewTaskService(repo repositories.Task, boardRepo repositories.Board, projectRepo repositories.Project) *TaskService {
-	return &TaskService{repo: repo, boardRepo: boardRepo, projectRepo: projectRepo}
+func NewTaskService(repo repositories.Task, listRepo repositories.TaskList, access *AccessResolver,
+	notifier *Notifier, mentions *Mentioner, fields *CustomFieldService) *TaskService {
+	return &TaskService{repo: repo, listRepo: listRepo, access: access, notifier: notifier,
+		mentions: mentions, fields: fields}
 }
 
-func (s *TaskService) GetAll(userId, projectId, boardId, listId int) *models.ApiResponse {
+// GetAll returns the tasks of the list, only those matching the filter
+// query if it is not empty, in the sort order if there is one. Archived
+// tasks are left out unless asked for.
+func (s *TaskService) GetAll(userId, projectId, boardId, listId int, filterQuery, sort string, archived bool) *models.ApiResponse {
 	r := &models.ApiResponse{}
 
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
-		return r
+	var tasks []*models.Task
+	if filterQuery == "" && sort == "" {
+		tasks, err = s.repo.GetAll(listId)
+	} else {
+		query, ok := s.parseQuery(r, boardId, filterQuery, sort)
+		if !ok {
+			return r
+		}
+		tasks, err = s.repo.GetFiltered(boardId, listId, query)
+	}
+	if err == nil {
+		tasks = withoutArchived(tasks, archived)
+		err = s.fields.attach(tasks...)
 	}
-
-	tasks, err := s.repo.GetAll(listId)
 	if err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
@@ -41,40 +59,86 @@ func (s *TaskService) GetAll(userId, projectId, boardId, listId int) *models.Api
 	return r
 }
 
-func (s *TaskService) GetById(userId, projectId, boardId, listId, taksId int) *models.ApiResponse {
+// GetSnapshot returns the lists of the board with their tasks matching the
+// filter query in the sort order. Archived lists and tasks are left out
+// unless asked for.
+func (s *TaskService) GetSnapshot(userId, projectId, boardId int, filterQuery, sort string, archived bool) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
+	query, ok := s.parseQuery(r, boardId, filterQuery, sort)
+	if !ok {
 		return r
 	}
 
-	task, err := s.repo.GetById(taksId)
+	lists, err := s.listRepo.GetAll(boardId)
 	if err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
 
-	r.Set(StatusOK, "OK", Map{"task": task})
+	tasks, err := s.repo.GetFiltered(boardId, 0, query)
+	if err == nil {
+		tasks = withoutArchived(tasks, archived)
+		err = s.fields.attach(tasks...)
+	}
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	snapshot := make([]*models.ListSnapshot, 0, len(lists))
+	listsById := make(map[int]*models.ListSnapshot, len(lists))
+	for _, list := range lists {
+		if list.Archived && !archived {
+			continue
+		}
+		listSnapshot := &models.ListSnapshot{TaskList: list, Tasks: make([]*models.Task, 0)}
+		listsById[list.Id] = listSnapshot
+		snapshot = append(snapshot, listSnapshot)
+	}
+	for _, task := range tasks {
+		if list, ok := listsById[task.ListId]; ok {
+			list.Tasks = append(list.Tasks, task)
+		}
+	}
+
+	r.Set(StatusOK, "OK", Map{"lists": snapshot})
 	return r
 }
 
-func (s *TaskService) Create(userId, projectId, boardId, listId int, task *models.Task) *models.ApiResponse {
+func (s *TaskService) GetById(userId, projectId, boardId, listId, taksId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Read == false {
+	task, err := s.repo.GetById(taksId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	task.Mentions = s.mentions.Get(taksId)
+	if err := s.fields.attach(task); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"task": task})
+	return r
+}
+
+func (s *TaskService) Create(userId, projectId, boardId, listId int, task *models.Task) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
@@ -93,6 +157,13 @@ func (s *TaskService) Create(userId, projectId, boardId, listId int, task *model
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
+	event := models.Event{Type: models.EventTaskCreated, ActorId: userId,
+		ProjectId: projectId, BoardId: boardId, ListId: listId, TaskId: taskId}
+	s.notifier.Watch(userId, models.WatchTask, taskId)
+	s.notifier.Notify(&event)
+	if task.Description != "" {
+		s.mentions.Update(event, task.Description)
+	}
 
 	r.Set(StatusOK, "OK", Map{"taskId": taskId})
 	return r
@@ -111,18 +182,53 @@ func (s *TaskService) Update(userId, projectId, boardId, listId, taskId int, tas
 		return r
 	}
 
-	// TODO: права projectId = read ?
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
-		r.Error(StatusForbidden, "Forbidden")
+	if task.Points != nil && *task.Points < 0 {
+		r.Error(StatusBadRequest, "Invalid points")
+		return r
+	}
+	if task.Due != nil && *task.Due < 0 {
+		r.Error(StatusBadRequest, "Invalid due")
 		return r
 	}
 
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Write == false {
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
+	if task.ListId != nil && *task.ListId != listId {
+		// Moves to other boards go through Move, which handles the labels.
+		target, err := s.listRepo.GetById(*task.ListId)
+		if err != nil || target.BoardId != boardId {
+			r.Error(StatusBadRequest, "List is not on the board")
+			return r
+		}
+		permissions, err = s.access.ListPermissions(userId, boardId, *task.ListId)
+		if err != nil || permissions.Write == false {
+			r.Error(StatusForbidden, "Forbidden")
+			return r
+		}
+	}
+
+	assigned := false
+	if task.AssigneeId != nil && *task.AssigneeId < 0 {
+		// Negative ids are share link guests, which can't be assigned.
+		r.Error(StatusBadRequest, "Invalid assignee")
+		return r
+	}
+	if task.AssigneeId != nil && *task.AssigneeId != 0 {
+		permissions, err = s.access.BoardPermissions(*task.AssigneeId, boardId)
+		if err != nil || permissions.Read == false {
+			r.Error(StatusBadRequest, "Assignee can't read the board")
+			return r
+		}
+		current, err := s.repo.GetById(taskId)
+		if err != nil {
+			r.Error(StatusInternalServerError, err.Error())
+			return r
+		}
+		assigned = current.AssigneeId != *task.AssigneeId
+	}
 
 	curTime := time.Now().Unix()
 	task.Datetimes = &models.UpdateDatetimes{
@@ -131,35 +237,237 @@ func (s *TaskService) Update(userId, projectId, boardId, listId, taskId int, tas
 	}
 
 	if err = s.repo.Update(taskId, task); err != nil {
-		r.Error(StatusInternalServerError, err.Error())
+		switch err.Error() {
+		case TaskBlocked, SprintCompleted:
+			r.Error(StatusConflict, err.Error())
+		case SprintNotOnBoard:
+			r.Error(StatusBadRequest, err.Error())
+		default:
+			r.Error(StatusInternalServerError, err.Error())
+		}
 		return r
 	}
 
+	event := &models.Event{Type: models.EventTaskUpdated, ActorId: userId,
+		ProjectId: projectId, BoardId: boardId, ListId: listId, TaskId: taskId}
+	if task.ListId != nil {
+		event.ListId = *task.ListId
+	}
+	if assigned {
+		event.Type = models.EventTaskAssigned
+		event.UserId = *task.AssigneeId
+		s.notifier.Watch(*task.AssigneeId, models.WatchTask, taskId)
+	}
+	s.notifier.Notify(event)
+	if task.Description != nil {
+		s.mentions.Update(*event, *task.Description)
+	}
+
 	r.Set(StatusOK, "OK", Map{})
 	return r
 }
 
 func (s *TaskService) Delete(userId, projectId, boardId, listId, taskId int) *models.ApiResponse {
 	r := &models.ApiResponse{}
-	// TODO: права projectId = read ?
-	projectPermissions, err := s.projectRepo.GetPermissions(userId, projectId)
-	if err != nil || projectPermissions.Read == false {
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	boardPermissions, err := s.boardRepo.GetPermissions(userId, boardId)
-	if err != nil || boardPermissions.Write == false {
+	err = s.repo.Delete(taskId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+	s.notifier.Notify(&models.Event{Type: models.EventTaskDeleted, ActorId: userId,
+		ProjectId: projectId, BoardId: boardId, ListId: listId, TaskId: taskId})
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// Move needs write on the list of the task and on the target list, which
+// may be on another board of any project.
+func (s *TaskService) Move(userId, projectId, boardId, listId, taskId int, input *models.MoveTask) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if input.Position != nil && *input.Position < 0 {
+		r.Error(StatusBadRequest, TaskOutOfBounds)
+		return r
+	}
+
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
 		r.Error(StatusForbidden, "Forbidden")
 		return r
 	}
 
-	err = s.repo.Delete(taskId)
+	target, err := s.listRepo.GetById(input.ListId)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "List not found")
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	permissions, err = s.access.ListPermissions(userId, target.BoardId, target.Id)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	// The assignee and the users of custom fields have to be able to read
+	// the board the task moves to, or they are cleared.
+	dropUsers := []int{}
+	if target.BoardId != boardId {
+		users, err := s.repo.GetUsers(taskId)
+		if err == nil {
+			dropUsers, err = s.access.UsersWithoutRead(target.BoardId, users)
+		}
+		if err != nil {
+			r.Error(StatusInternalServerError, err.Error())
+			return r
+		}
+	}
+
+	dropped, err := s.repo.Move(taskId, target.Id, input.Position, dropUsers)
+	if err != nil {
+		if err.Error() == TaskOutOfBounds {
+			r.Error(StatusBadRequest, err.Error())
+		} else if err.Error() == TaskBlocked {
+			r.Error(StatusConflict, err.Error())
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+	// Only the watchers of where the task came from are notified, it may
+	// have left the project.
+	s.notifier.Notify(&models.Event{Type: models.EventTaskMoved, ActorId: userId,
+		ProjectId: projectId, BoardId: boardId, ListId: listId, TaskId: taskId})
+
+	r.Set(StatusOK, "OK", Map{"droppedLabels": dropped})
+	return r
+}
+
+const bulkTasksLimit = 100
+
+var bulkOperations = map[string]bool{
+	models.BulkMove:        true,
+	models.BulkAddLabel:    true,
+	models.BulkRemoveLabel: true,
+	models.BulkDelete:      true,
+	models.BulkArchive:     true,
+	models.BulkUnarchive:   true,
+}
+
+// Bulk applies the operations atomically: either all of them succeed or the
+// results tell which one failed and nothing is changed.
+func (s *TaskService) Bulk(userId, projectId, boardId int, input *models.BulkTasks) *models.ApiResponse {
+	r := &models.ApiResponse{}
+
+	if len(input.Operations) == 0 {
+		r.Error(StatusBadRequest, "No operations")
+		return r
+	}
+	if len(input.Operations) > bulkTasksLimit {
+		r.Error(StatusBadRequest, fmt.Sprintf("Too many operations, the limit is %d", bulkTasksLimit))
+		return r
+	}
+	for i, operation := range input.Operations {
+		if message := validateBulkOperation(operation); message != "" {
+			r.Set(StatusBadRequest, message, Map{"operation": i})
+			return r
+		}
+	}
+
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	results, err := s.repo.Bulk(boardId, input.Operations)
 	if err != nil {
 		r.Error(StatusInternalServerError, err.Error())
 		return r
 	}
 
-	r.Set(StatusOK, "OK", Map{})
+	for _, result := range results {
+		if result.Status == models.BulkStatusFailed {
+			r.Set(StatusBadRequest, "Bulk operation failed", Map{"results": results})
+			return r
+		}
+	}
+
+	r.Set(StatusOK, "OK", Map{"results": results})
 	return r
 }
+
+func validateBulkOperation(operation *models.BulkTaskOperation) string {
+	switch {
+	case operation == nil || !bulkOperations[operation.Op]:
+		return "Invalid operation"
+	case operation.TaskId < 1:
+		return "Invalid taskId"
+	}
+
+	switch operation.Op {
+	case models.BulkMove:
+		if operation.ListId < 1 {
+			return "Invalid listId"
+		}
+		if operation.Position != nil && *operation.Position < 0 {
+			return "Task position out of bounds"
+		}
+	case models.BulkAddLabel, models.BulkRemoveLabel:
+		if operation.LabelId < 1 {
+			return "Invalid labelId"
+		}
+	}
+	return ""
+}
+
+// parseQuery reads the filter query and the sort, resolving the custom
+// fields they refer to, and sets the response on error.
+func (s *TaskService) parseQuery(r *models.ApiResponse, boardId int, filterQuery, sort string) (*filter.Query, bool) {
+	query, err := filter.Parse(filterQuery)
+	if err != nil {
+		filterError(r, err)
+		return nil, false
+	}
+
+	if query.Sort, err = filter.ParseSort(sort); err != nil {
+		r.Error(StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	if !s.fields.resolve(r, boardId, query) {
+		return nil, false
+	}
+	return query, true
+}
+
+func withoutArchived(tasks []*models.Task, archived bool) []*models.Task {
+	if archived {
+		return tasks
+	}
+	live := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.Archived {
+			live = append(live, task)
+		}
+	}
+	return live
+}
+
+func filterError(r *models.ApiResponse, err error) {
+	if filterErr, ok := err.(*filter.Error); ok {
+		r.Set(StatusBadRequest, filterErr.Error(), Map{"position": filterErr.Pos})
+		return
+	}
+	r.Error(StatusBadRequest, err.Error())
+}
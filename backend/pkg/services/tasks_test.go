@@ -21,14 +21,12 @@ func TestTaskService_Create(t *testing.T) {
 		task      *models.Task
 	}
 	type mockBehavior func(r *mock_repositories.MockTask, task *models.Task)
-	type projectMockBehavior func(r *mock_repositories.MockProject, userId, projectId int)
-	type boardMockBehavior func(r *mock_repositories.MockBoard, userId, boardId int)
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, boardId, listId int)
 
 	tests := []struct {
 		name                string
 		input               args
-		projectMock         projectMockBehavior
-		boardMock           boardMockBehavior
+		accessMock          accessMockBehavior
 		mock                mockBehavior
 		expectedApiResponse *models.ApiResponse
 	}{
@@ -40,11 +38,9 @@ func TestTaskService_Create(t *testing.T) {
 				boardId:   1,
 				task:      builders.NewTaskBuilder().WithTitle("Task Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(false, nil)
 			},
 			mock: func(r *mock_repositories.MockTask, task *models.Task) {
 				r.EXPECT().Create(task).Return(1, nil)
@@ -55,35 +51,49 @@ func TestTaskService_Create(t *testing.T) {
 			},
 		},
 		{
-			name: "Project Perm Failed",
+			name: "Archived List",
 			input: args{
 				userId:    1,
 				projectId: 1,
 				boardId:   1,
+				listId:    1,
 				task:      builders.NewTaskBuilder().WithTitle("Task Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(true, nil)
 			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {},
-			mock:      func(r *mock_repositories.MockTask, task *models.Task) {},
+			mock: func(r *mock_repositories.MockTask, task *models.Task) {},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
 			},
 		},
 		{
-			name: "Board Perm Failed",
+			name: "Not Project Member",
 			input: args{
 				userId:    1,
 				projectId: 1,
 				boardId:   1,
 				task:      builders.NewTaskBuilder().WithTitle("Task Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
 			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(nil, errors.New("Forbidden"))
+			mock: func(r *mock_repositories.MockTask, task *models.Task) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name: "Board Perm Failed",
+			input: args{
+				userId:    1,
+				projectId: 1,
+				boardId:   1,
+				task:      builders.NewTaskBuilder().WithTitle("Task Builder").Build(),
+			},
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(nil, errors.New("Forbidden"))
 			},
 			mock: func(r *mock_repositories.MockTask, task *models.Task) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -98,11 +108,9 @@ func TestTaskService_Create(t *testing.T) {
 				boardId:   1,
 				task:      builders.NewTaskBuilder().WithTitle("Task Builder").Build(),
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(false, nil)
 			},
 			mock: func(r *mock_repositories.MockTask, task *models.Task) {
 				r.EXPECT().Create(task).Return(0, errors.New("repo error"))
@@ -119,12 +127,10 @@ func TestTaskService_Create(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockTask(c)
-			projectRepo := mock_repositories.NewMockProject(c)
-			boardRepo := mock_repositories.NewMockBoard(c)
-			test.projectMock(projectRepo, test.input.userId, test.input.projectId)
-			test.boardMock(boardRepo, test.input.userId, test.input.boardId)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.boardId, test.input.listId)
 			test.mock(repo, test.input.task)
-			s := &TaskService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &TaskService{repo: repo, access: NewAccessResolver(accessRepo)}
 
 			got := s.Create(test.input.userId, test.input.projectId, test.input.boardId,
 				test.input.listId, test.input.task)
@@ -145,14 +151,12 @@ func TestTaskService_Delete(t *testing.T) {
 		taskId    int
 	}
 	type mockBehavior func(r *mock_repositories.MockTask, taskId int)
-	type projectMockBehavior func(r *mock_repositories.MockProject, userId, projectId int)
-	type boardMockBehavior func(r *mock_repositories.MockBoard, userId, boardId int)
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, boardId, listId int)
 
 	tests := []struct {
 		name                string
 		input               args
-		projectMock         projectMockBehavior
-		boardMock           boardMockBehavior
+		accessMock          accessMockBehavior
 		mock                mockBehavior
 		expectedApiResponse *models.ApiResponse
 	}{
@@ -164,11 +168,9 @@ func TestTaskService_Delete(t *testing.T) {
 				boardId:   1,
 				taskId:    1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(false, nil)
 			},
 			mock: func(r *mock_repositories.MockTask, taskId int) {
 				r.EXPECT().Delete(taskId).Return(nil)
@@ -179,18 +181,17 @@ func TestTaskService_Delete(t *testing.T) {
 			},
 		},
 		{
-			name: "Project Perm Failed",
+			name: "Not Project Member",
 			input: args{
 				userId:    1,
 				projectId: 1,
 				boardId:   1,
 				taskId:    1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
 			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {},
-			mock:      func(r *mock_repositories.MockTask, taskId int) {},
+			mock: func(r *mock_repositories.MockTask, taskId int) {},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusForbidden,
 			},
@@ -203,11 +204,8 @@ func TestTaskService_Delete(t *testing.T) {
 				boardId:   1,
 				taskId:    1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(nil, errors.New("Forbidden"))
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(nil, errors.New("Forbidden"))
 			},
 			mock: func(r *mock_repositories.MockTask, taskId int) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -222,11 +220,9 @@ func TestTaskService_Delete(t *testing.T) {
 				boardId:   1,
 				taskId:    1,
 			},
-			projectMock: func(r *mock_repositories.MockProject, userId, projectId int) {
-				r.EXPECT().GetPermissions(userId, projectId).Return(&models.Permission{true, true, true}, nil)
-			},
-			boardMock: func(r *mock_repositories.MockBoard, userId, boardId int) {
-				r.EXPECT().GetPermissions(userId, boardId).Return(&models.Permission{true, true, true}, nil)
+			accessMock: func(r *mock_repositories.MockAccess, userId, boardId, listId int) {
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{true, true, true}), nil)
+				r.EXPECT().IsListArchived(listId).Return(false, nil)
 			},
 			mock: func(r *mock_repositories.MockTask, taskId int) {
 				r.EXPECT().Delete(taskId).Return(errors.New("repo error"))
@@ -243,12 +239,10 @@ func TestTaskService_Delete(t *testing.T) {
 			defer c.Finish()
 
 			repo := mock_repositories.NewMockTask(c)
-			projectRepo := mock_repositories.NewMockProject(c)
-			boardRepo := mock_repositories.NewMockBoard(c)
-			test.projectMock(projectRepo, test.input.userId, test.input.projectId)
-			test.boardMock(boardRepo, test.input.userId, test.input.boardId)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.boardId, test.input.listId)
 			test.mock(repo, test.input.taskId)
-			s := &TaskService{repo: repo, projectRepo: projectRepo, boardRepo: boardRepo}
+			s := &TaskService{repo: repo, access: NewAccessResolver(accessRepo)}
 
 			got := s.Delete(test.input.userId, test.input.projectId, test.input.boardId,
 				test.input.listId, test.input.taskId)
@@ -259,3 +253,366 @@ func TestTaskService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskService_Bulk(t *testing.T) {
+	type args struct {
+		userId  int
+		boardId int
+		input   *models.BulkTasks
+	}
+	type mockBehavior func(r *mock_repositories.MockTask, a *mock_repositories.MockAccess, input args)
+
+	position := 0
+	operations := []*models.BulkTaskOperation{
+		{Op: models.BulkMove, TaskId: 1, ListId: 2, Position: &position},
+		{Op: models.BulkAddLabel, TaskId: 1, LabelId: 3},
+	}
+	write := &models.Permission{Read: true, Write: true}
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:  "Ok",
+			input: args{userId: 1, boardId: 2, input: &models.BulkTasks{Operations: operations}},
+			mock: func(r *mock_repositories.MockTask, a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+				r.EXPECT().Bulk(input.boardId, operations).Return([]*models.BulkTaskResult{
+					{TaskId: 1, Status: models.BulkStatusOk},
+					{TaskId: 1, Status: models.BulkStatusOk},
+				}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"results": []*models.BulkTaskResult{
+					{TaskId: 1, Status: models.BulkStatusOk},
+					{TaskId: 1, Status: models.BulkStatusOk},
+				}},
+			},
+		},
+		{
+			name:  "Failed Operation",
+			input: args{userId: 1, boardId: 2, input: &models.BulkTasks{Operations: operations}},
+			mock: func(r *mock_repositories.MockTask, a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+				r.EXPECT().Bulk(input.boardId, operations).Return([]*models.BulkTaskResult{
+					{TaskId: 1, Status: models.BulkStatusFailed, Error: "List not found"},
+					{TaskId: 1, Status: models.BulkStatusSkipped},
+				}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name: "Unknown Operation",
+			input: args{userId: 1, boardId: 2, input: &models.BulkTasks{
+				Operations: []*models.BulkTaskOperation{{Op: "rename", TaskId: 1}}}},
+			mock: func(r *mock_repositories.MockTask, a *mock_repositories.MockAccess, input args) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name: "Move Without List",
+			input: args{userId: 1, boardId: 2, input: &models.BulkTasks{
+				Operations: []*models.BulkTaskOperation{{Op: models.BulkMove, TaskId: 1}}}},
+			mock: func(r *mock_repositories.MockTask, a *mock_repositories.MockAccess, input args) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name:  "Read Only",
+			input: args{userId: 1, boardId: 2, input: &models.BulkTasks{Operations: operations}},
+			mock: func(r *mock_repositories.MockTask, a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).
+					Return(boardSources(&models.Permission{Read: true}), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name:  "Repo Error",
+			input: args{userId: 1, boardId: 2, input: &models.BulkTasks{Operations: operations}},
+			mock: func(r *mock_repositories.MockTask, a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+				r.EXPECT().Bulk(input.boardId, operations).Return(nil, errors.New("repo error"))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTask(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo, test.input)
+			s := &TaskService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Bulk(test.input.userId, 1, test.input.boardId, test.input.input)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
+
+func TestTaskService_Move(t *testing.T) {
+	type args struct {
+		userId  int
+		boardId int
+		listId  int
+		taskId  int
+		input   *models.MoveTask
+	}
+	type mockBehavior func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+		a *mock_repositories.MockAccess, input args)
+
+	write := &models.Permission{Read: true, Write: true}
+	target := &models.TaskList{Id: 5, BoardId: 7}
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:  "Ok",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, input: &models.MoveTask{ListId: 5}},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+				a.EXPECT().IsListArchived(input.listId).Return(false, nil)
+				l.EXPECT().GetById(input.input.ListId).Return(target, nil)
+				a.EXPECT().GetBoardSources(input.userId, target.BoardId).Return(boardSources(write), nil)
+				a.EXPECT().IsListArchived(target.Id).Return(false, nil)
+				alice, bob := 8, 9
+				r.EXPECT().GetUsers(input.taskId).Return([]int{alice, bob}, nil)
+				a.EXPECT().GetBoardSources(alice, target.BoardId).
+					Return(boardSources(&models.Permission{Read: true}), nil)
+				a.EXPECT().GetBoardSources(bob, target.BoardId).Return(boardSources(nil), nil)
+				r.EXPECT().Move(input.taskId, target.Id, nil, []int{bob}).Return([]string{"bug"}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"droppedLabels": []string{"bug"}},
+			},
+		},
+		{
+			name:  "No Write On Target",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, input: &models.MoveTask{ListId: 5}},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+				a.EXPECT().IsListArchived(input.listId).Return(false, nil)
+				l.EXPECT().GetById(input.input.ListId).Return(target, nil)
+				a.EXPECT().GetBoardSources(input.userId, target.BoardId).
+					Return(boardSources(&models.Permission{Read: true}), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name:  "No Write On Source",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, input: &models.MoveTask{ListId: 5}},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(nil), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name:  "Target Not Found",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, input: &models.MoveTask{ListId: 5}},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+				a.EXPECT().IsListArchived(input.listId).Return(false, nil)
+				l.EXPECT().GetById(input.input.ListId).Return(nil, errors.New(DbResultNotFound))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusNotFound,
+			},
+		},
+		{
+			name:  "Out Of Bounds",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, input: &models.MoveTask{ListId: 5}},
+			mock: func(r *mock_repositories.MockTask, l *mock_repositories.MockTaskList,
+				a *mock_repositories.MockAccess, input args) {
+				a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+				a.EXPECT().IsListArchived(input.listId).Return(false, nil)
+				l.EXPECT().GetById(input.input.ListId).Return(target, nil)
+				a.EXPECT().GetBoardSources(input.userId, target.BoardId).Return(boardSources(write), nil)
+				a.EXPECT().IsListArchived(target.Id).Return(false, nil)
+				r.EXPECT().GetUsers(input.taskId).Return([]int{}, nil)
+				r.EXPECT().Move(input.taskId, target.Id, nil, []int{}).Return(nil, errors.New(TaskOutOfBounds))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTask(c)
+			listRepo := mock_repositories.NewMockTaskList(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, listRepo, accessRepo, test.input)
+			s := &TaskService{repo: repo, listRepo: listRepo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Move(test.input.userId, 1, test.input.boardId, test.input.listId,
+				test.input.taskId, test.input.input)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
+
+func TestTaskService_Update_Assignee(t *testing.T) {
+	type args struct {
+		userId     int
+		boardId    int
+		listId     int
+		taskId     int
+		assigneeId int
+	}
+	type mockBehavior func(r *mock_repositories.MockTask, n *mock_repositories.MockNotification,
+		a *mock_repositories.MockAccess, input args)
+
+	write := &models.Permission{Read: true, Write: true}
+	canWrite := func(a *mock_repositories.MockAccess, input args) {
+		a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(write), nil)
+		a.EXPECT().IsListArchived(input.listId).Return(false, nil)
+	}
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:  "Assigned",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, assigneeId: 5},
+			mock: func(r *mock_repositories.MockTask, n *mock_repositories.MockNotification,
+				a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				a.EXPECT().GetBoardSources(input.assigneeId, input.boardId).
+					Return(boardSources(&models.Permission{Read: true}), nil)
+				r.EXPECT().GetById(input.taskId).Return(&models.Task{Id: input.taskId}, nil)
+				r.EXPECT().Update(input.taskId, gomock.Any()).Return(nil)
+				n.EXPECT().Watch(input.assigneeId,
+					&models.Watch{ObjectType: models.WatchTask, ObjectId: input.taskId}).Return(nil)
+				n.EXPECT().GetRecipients(&models.Event{Type: models.EventTaskAssigned, ActorId: 1, UserId: input.assigneeId,
+					ProjectId: 1, BoardId: 2, ListId: 3, TaskId: 4}).Return([]int{}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+			},
+		},
+		{
+			name:  "Already Assigned",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, assigneeId: 5},
+			mock: func(r *mock_repositories.MockTask, n *mock_repositories.MockNotification,
+				a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				a.EXPECT().GetBoardSources(input.assigneeId, input.boardId).
+					Return(boardSources(&models.Permission{Read: true}), nil)
+				r.EXPECT().GetById(input.taskId).Return(&models.Task{Id: input.taskId, AssigneeId: 5}, nil)
+				r.EXPECT().Update(input.taskId, gomock.Any()).Return(nil)
+				n.EXPECT().GetRecipients(&models.Event{Type: models.EventTaskUpdated, ActorId: 1,
+					ProjectId: 1, BoardId: 2, ListId: 3, TaskId: 4}).Return([]int{}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+			},
+		},
+		{
+			name:  "Assignee Without Access",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, assigneeId: 5},
+			mock: func(r *mock_repositories.MockTask, n *mock_repositories.MockNotification,
+				a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+				a.EXPECT().GetBoardSources(input.assigneeId, input.boardId).Return(boardSources(nil), nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name:  "Guest Assignee",
+			input: args{userId: 1, boardId: 2, listId: 3, taskId: 4, assigneeId: -5},
+			mock: func(r *mock_repositories.MockTask, n *mock_repositories.MockNotification,
+				a *mock_repositories.MockAccess, input args) {
+				canWrite(a, input)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTask(c)
+			notificationRepo := mock_repositories.NewMockNotification(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, notificationRepo, accessRepo, test.input)
+			access := NewAccessResolver(accessRepo)
+			s := &TaskService{repo: repo, access: access, notifier: NewNotifier(notificationRepo, access, nil)}
+
+			got := s.Update(test.input.userId, 1, test.input.boardId, test.input.listId,
+				test.input.taskId, &models.UpdateTask{AssigneeId: &test.input.assigneeId})
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+		})
+	}
+}
+
+func TestTaskService_Update_Invalid(t *testing.T) {
+	points, due := -1, int64(-1)
+	tests := []struct {
+		name  string
+		input *models.UpdateTask
+	}{
+		{name: "Negative Points", input: &models.UpdateTask{Points: &points}},
+		{name: "Negative Due", input: &models.UpdateTask{Due: &due}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTask(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			s := &TaskService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Update(1, 1, 2, 3, 4, test.input)
+			assert.Equal(t, StatusBadRequest, got.Code)
+		})
+	}
+}
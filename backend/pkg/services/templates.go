@@ -0,0 +1,138 @@
+package services
+
+import (
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+type TemplateService struct {
+	repo      repositories.Template
+	boardRepo repositories.Board
+	access    *AccessResolver
+}
+
+func NewTemplateService(repo repositories.Template, boardRepo repositories.Board,
+	access *AccessResolver) *TemplateService {
+	return &TemplateService{repo: repo, boardRepo: boardRepo, access: access}
+}
+
+// Create saves the lists and labels of the board as a template of the project.
+func (s *TemplateService) Create(userId, projectId, boardId int, input *models.SaveTemplate) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	permissions, err = s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	content, err := s.boardRepo.GetContent(boardId, false)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	template := &models.BoardTemplate{
+		ProjectId: projectId,
+		OwnerId:   userId,
+		Title:     input.Title,
+		Created:   time.Now().Unix(),
+		Content:   content,
+	}
+	templateId, err := s.repo.Create(template)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"templateId": templateId})
+	return r
+}
+
+func (s *TemplateService) GetAll(userId, projectId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	templates, err := s.repo.GetAll(projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"templates": templates})
+	return r
+}
+
+func (s *TemplateService) GetById(userId, projectId, templateId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	template, err := s.repo.GetById(templateId)
+	if err == nil && template.ProjectId != projectId {
+		r.Error(StatusNotFound, "Template not found")
+		return r
+	}
+	if err != nil {
+		templateError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"template": template})
+	return r
+}
+
+// Delete is allowed to the project admins and to the author of the template.
+func (s *TemplateService) Delete(userId, projectId, templateId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	template, err := s.repo.GetById(templateId)
+	if err == nil && template.ProjectId != projectId {
+		r.Error(StatusNotFound, "Template not found")
+		return r
+	}
+	if err != nil {
+		templateError(r, err)
+		return r
+	}
+
+	if permissions.Admin == false && template.OwnerId != userId {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err = s.repo.Delete(templateId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func templateError(r *models.ApiResponse, err error) {
+	if err.Error() == DbResultNotFound {
+		r.Error(StatusNotFound, "Template not found")
+		return
+	}
+	r.Error(StatusInternalServerError, err.Error())
+}
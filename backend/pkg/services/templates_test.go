@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateService_Create(t *testing.T) {
+	type args struct {
+		userId    int
+		projectId int
+		boardId   int
+		input     *models.SaveTemplate
+	}
+	type accessMockBehavior func(r *mock_repositories.MockAccess, userId, projectId, boardId int)
+	type mockBehavior func(r *mock_repositories.MockTemplate, b *mock_repositories.MockBoard, boardId int)
+
+	content := &models.BoardContent{
+		Lists:  []*models.ListContent{{Title: "To do"}, {Title: "Done"}},
+		Labels: []*models.LabelContent{{Id: 1, Name: "bug", Color: 0xff0000}},
+	}
+
+	tests := []struct {
+		name                string
+		input               args
+		accessMock          accessMockBehavior
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:  "Ok",
+			input: args{userId: 1, projectId: 1, boardId: 2, input: &models.SaveTemplate{Title: "Sprint"}},
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId, boardId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{Read: true, Write: true}), nil)
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{Read: true}), nil)
+			},
+			mock: func(r *mock_repositories.MockTemplate, b *mock_repositories.MockBoard, boardId int) {
+				b.EXPECT().GetContent(boardId, false).Return(content, nil)
+				r.EXPECT().Create(gomock.Any()).DoAndReturn(func(template *models.BoardTemplate) (int, error) {
+					assert.Equal(t, 1, template.ProjectId)
+					assert.Equal(t, "Sprint", template.Title)
+					assert.Equal(t, content, template.Content)
+					return 3, nil
+				})
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"templateId": 3},
+			},
+		},
+		{
+			name:  "No project write",
+			input: args{userId: 1, projectId: 1, boardId: 2, input: &models.SaveTemplate{Title: "Sprint"}},
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId, boardId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{Read: true}), nil)
+			},
+			mock: func(r *mock_repositories.MockTemplate, b *mock_repositories.MockBoard, boardId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name:  "No board read",
+			input: args{userId: 1, projectId: 1, boardId: 2, input: &models.SaveTemplate{Title: "Sprint"}},
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId, boardId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{Read: true, Write: true}), nil)
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(nil), nil)
+			},
+			mock: func(r *mock_repositories.MockTemplate, b *mock_repositories.MockBoard, boardId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name:  "Repo error for GetContent",
+			input: args{userId: 1, projectId: 1, boardId: 2, input: &models.SaveTemplate{Title: "Sprint"}},
+			accessMock: func(r *mock_repositories.MockAccess, userId, projectId, boardId int) {
+				r.EXPECT().GetProjectSources(userId, projectId).Return(projectSources(&models.Permission{Read: true, Write: true}), nil)
+				r.EXPECT().GetBoardSources(userId, boardId).Return(boardSources(&models.Permission{Read: true}), nil)
+			},
+			mock: func(r *mock_repositories.MockTemplate, b *mock_repositories.MockBoard, boardId int) {
+				b.EXPECT().GetContent(boardId, false).Return(nil, errors.New("repo error"))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTemplate(c)
+			boardRepo := mock_repositories.NewMockBoard(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.accessMock(accessRepo, test.input.userId, test.input.projectId, test.input.boardId)
+			test.mock(repo, boardRepo, test.input.boardId)
+			s := &TemplateService{repo: repo, boardRepo: boardRepo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Create(test.input.userId, test.input.projectId, test.input.boardId, test.input.input)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+const timeReportPeriod = 30 * 24 * time.Hour
+
+var timeReportCsvHeader = []string{
+	"date", "user", "project", "board", "task", "hours", "note",
+}
+
+type TimeService struct {
+	repo   repositories.Time
+	access *AccessResolver
+}
+
+func NewTimeService(repo repositories.Time, access *AccessResolver) *TimeService {
+	return &TimeService{repo: repo, access: access}
+}
+
+// Get returns the estimate of the task with the time logged on it.
+func (s *TimeService) Get(userId, projectId, boardId, listId, taskId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	estimate, err := s.repo.GetEstimate(taskId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	logs, err := s.repo.GetLogs(taskId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	var spent int64
+	for _, log := range logs {
+		spent += log.Duration
+	}
+
+	r.Set(StatusOK, "OK", Map{"estimate": estimate, "logs": logs, "spent": spent})
+	return r
+}
+
+func (s *TimeService) UpdateEstimate(userId, projectId, boardId, listId, taskId int, input *models.UpdateTimeEstimate) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if (input.Original != nil && *input.Original < 0) || (input.Remaining != nil && *input.Remaining < 0) {
+		r.Error(StatusBadRequest, "Invalid estimate")
+		return r
+	}
+
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err := s.repo.UpdateEstimate(taskId, input); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// CreateLog logs time on the task for today unless the input has a date.
+func (s *TimeService) CreateLog(userId, projectId, boardId, listId, taskId int, input *models.CreateTimeLog) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	if input.Duration <= 0 {
+		r.Error(StatusBadRequest, "Invalid duration")
+		return r
+	}
+
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	log := &models.TimeLog{
+		TaskId:   taskId,
+		UserId:   userId,
+		Duration: input.Duration,
+		Date:     input.Date,
+		Note:     input.Note,
+	}
+	if log.Date == 0 {
+		log.Date = time.Now().Unix()
+	}
+
+	logId, err := s.repo.CreateLog(log)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"logId": logId})
+	return r
+}
+
+// DeleteLog lets the users delete their own logs, and board admins any.
+func (s *TimeService) DeleteLog(userId, projectId, boardId, listId, taskId, logId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	log, err := s.repo.GetLog(logId)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "Time log not found")
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+	if log.TaskId != taskId {
+		r.Error(StatusNotFound, "Time log not found")
+		return r
+	}
+
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false || (log.UserId != userId && permissions.Admin == false) {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err := s.repo.DeleteLog(logId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// GetTimer returns the running timer of the user, null if there is none.
+func (s *TimeService) GetTimer(userId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	timer, err := s.repo.GetTimer(userId)
+	if err != nil && err.Error() != DbResultNotFound {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"timer": timer})
+	return r
+}
+
+// StartTimer starts timing the task, the running timer of the user is
+// stopped and its time logged.
+func (s *TimeService) StartTimer(userId, projectId, boardId, listId, taskId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ListPermissions(userId, boardId, listId)
+	if err != nil || permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	now := time.Now().Unix()
+	stopped, err := s.repo.StartTimer(userId, taskId, now)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"timer": &models.Timer{TaskId: taskId, Started: now}, "stopped": stopped})
+	return r
+}
+
+func (s *TimeService) StopTimer(userId int, input *models.StopTimer) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	log, err := s.repo.StopTimer(userId, time.Now().Unix(), input.Note)
+	if err != nil {
+		if err.Error() == DbResultNotFound {
+			r.Error(StatusNotFound, "No timer running")
+		} else {
+			r.Error(StatusInternalServerError, err.Error())
+		}
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"log": log})
+	return r
+}
+
+// Report returns the time logged on the boards the user can read, of the
+// project or the board in the query if it has one, with the total.
+func (s *TimeService) Report(userId int, query *models.TimeReportQuery) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	entries, ok := s.report(r, userId, query)
+	if !ok {
+		return r
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Duration
+	}
+
+	r.Set(StatusOK, "OK", Map{"entries": entries, "total": total, "from": query.From, "to": query.To})
+	return r
+}
+
+// ExportReport returns the report as CSV text with the time in hours.
+func (s *TimeService) ExportReport(userId int, query *models.TimeReportQuery) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	entries, ok := s.report(r, userId, query)
+	if !ok {
+		return r
+	}
+
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	writer.Write(timeReportCsvHeader)
+	for _, entry := range entries {
+		writer.Write([]string{
+			formatCsvTime(entry.Date),
+			entry.Nickname,
+			entry.Project,
+			entry.Board,
+			entry.Task,
+			strconv.FormatFloat(float64(entry.Duration)/3600, 'f', 2, 64),
+			entry.Note,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", buf.String())
+	return r
+}
+
+// report fills the date range of the query, the last 30 days by default,
+// and sets the error on r when it fails.
+func (s *TimeService) report(r *models.ApiResponse, userId int, query *models.TimeReportQuery) ([]*models.TimeReportEntry, bool) {
+	if query.To == 0 {
+		query.To = time.Now().Unix()
+	}
+	if query.From == 0 {
+		query.From = query.To - int64(timeReportPeriod/time.Second)
+	}
+	if query.From > query.To {
+		r.Error(StatusBadRequest, "Invalid date range")
+		return nil, false
+	}
+
+	_, boardIds, err := s.access.ReadableBoards(userId, query.ProjectId, query.BoardId)
+	if err != nil {
+		accessError(r, err)
+		return nil, false
+	}
+
+	entries, err := s.repo.GetReport(query, boardIds)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return entries, true
+}
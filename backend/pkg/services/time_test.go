@@ -0,0 +1,107 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeService_DeleteLog(t *testing.T) {
+	type args struct {
+		userId  int
+		boardId int
+		listId  int
+		taskId  int
+		logId   int
+	}
+	type mockBehavior func(r *mock_repositories.MockTime, a *mock_repositories.MockAccess, input args)
+
+	permissions := func(a *mock_repositories.MockAccess, input args, perms *models.Permission) {
+		a.EXPECT().GetBoardSources(input.userId, input.boardId).Return(boardSources(perms), nil)
+		a.EXPECT().IsListArchived(input.listId).Return(false, nil)
+	}
+	input := args{userId: 1, boardId: 2, listId: 3, taskId: 4, logId: 5}
+
+	tests := []struct {
+		name         string
+		mock         mockBehavior
+		expectedCode int
+	}{
+		{
+			name: "Own Log",
+			mock: func(r *mock_repositories.MockTime, a *mock_repositories.MockAccess, input args) {
+				r.EXPECT().GetLog(input.logId).Return(&models.TimeLog{Id: 5, TaskId: 4, UserId: 1}, nil)
+				permissions(a, input, &models.Permission{Read: true, Write: true})
+				r.EXPECT().DeleteLog(input.logId).Return(nil)
+			},
+			expectedCode: StatusOK,
+		},
+		{
+			name: "Other's Log",
+			mock: func(r *mock_repositories.MockTime, a *mock_repositories.MockAccess, input args) {
+				r.EXPECT().GetLog(input.logId).Return(&models.TimeLog{Id: 5, TaskId: 4, UserId: 6}, nil)
+				permissions(a, input, &models.Permission{Read: true, Write: true})
+			},
+			expectedCode: StatusForbidden,
+		},
+		{
+			name: "Other's Log As Admin",
+			mock: func(r *mock_repositories.MockTime, a *mock_repositories.MockAccess, input args) {
+				r.EXPECT().GetLog(input.logId).Return(&models.TimeLog{Id: 5, TaskId: 4, UserId: 6}, nil)
+				permissions(a, input, &models.Permission{Read: true, Write: true, Admin: true})
+				r.EXPECT().DeleteLog(input.logId).Return(nil)
+			},
+			expectedCode: StatusOK,
+		},
+		{
+			name: "Log Of Another Task",
+			mock: func(r *mock_repositories.MockTime, a *mock_repositories.MockAccess, input args) {
+				r.EXPECT().GetLog(input.logId).Return(&models.TimeLog{Id: 5, TaskId: 7, UserId: 1}, nil)
+			},
+			expectedCode: StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTime(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			test.mock(repo, accessRepo, input)
+			s := &TimeService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+			got := s.DeleteLog(input.userId, 1, input.boardId, input.listId, input.taskId, input.logId)
+			assert.Equal(t, test.expectedCode, got.Code)
+		})
+	}
+}
+
+func TestTimeService_ExportReport(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repositories.NewMockTime(c)
+	accessRepo := mock_repositories.NewMockAccess(c)
+	query := &models.TimeReportQuery{BoardId: 2, From: 1609459200, To: 1612137600}
+	accessRepo.EXPECT().GetBoardSources(1, 2).Return(boardSources(&models.Permission{Read: true}), nil)
+	repo.EXPECT().GetReport(query, []int{2}).Return([]*models.TimeReportEntry{
+		{Date: 1609502400, Nickname: "alice", Project: "Site", Board: "Dev", Task: "Login",
+			Duration: 5400, Note: "forms, validation"},
+	}, nil)
+	s := &TimeService{repo: repo, access: NewAccessResolver(accessRepo)}
+
+	got := s.ExportReport(1, query)
+	assert.Equal(t, StatusOK, got.Code)
+	assert.Equal(t, "date,user,project,board,task,hours,note\n"+
+		"2021-01-01T12:00:00Z,alice,Site,Dev,Login,1.50,\"forms, validation\"\n", got.Data)
+
+	got = s.ExportReport(1, &models.TimeReportQuery{BoardId: 2, From: 2, To: 1})
+	assert.Equal(t, StatusBadRequest, got.Code)
+}
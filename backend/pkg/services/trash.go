@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
+
+	"github.com/sirupsen/logrus"
+)
+
+type TrashService struct {
+	repo   repositories.Trash
+	blobs  storage.Storage
+	access *AccessResolver
+}
+
+func NewTrashService(repo repositories.Trash, blobs storage.Storage, access *AccessResolver) *TrashService {
+	return &TrashService{repo: repo, blobs: blobs, access: access}
+}
+
+func (s *TrashService) GetAll(userId, projectId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	items, err := s.repo.GetAll(projectId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"items": items})
+	return r
+}
+
+func (s *TrashService) GetProjects(userId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	projects, err := s.repo.GetProjects(userId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"projects": projects})
+	return r
+}
+
+func (s *TrashService) Restore(userId, projectId int, itemType string, itemId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	item, err := s.repo.GetById(itemType, itemId)
+	if err != nil {
+		trashError(r, err)
+		return r
+	}
+	if item.ProjectId != projectId {
+		r.Error(StatusNotFound, "Item is not in the trash")
+		return r
+	}
+	if item.ParentDeleted {
+		r.Error(StatusConflict, "Item is inside a deleted object, restore it first")
+		return r
+	}
+
+	if err = s.repo.Restore(itemType, itemId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *TrashService) RestoreProject(userId, projectId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	item, err := s.repo.GetById(models.TrashProject, projectId)
+	if err != nil {
+		trashError(r, err)
+		return r
+	}
+	if item.OwnerId != userId {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err = s.repo.Restore(models.TrashProject, projectId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// Purge deletes the expired items for good along with the blobs of their
+// attachments. The rows are gone at that point, so a blob that fails to be
+// deleted doesn't stop the others.
+func (s *TrashService) Purge(before int64) error {
+	keys, err := s.repo.Purge(before)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, key := range keys {
+		if err := s.blobs.Delete(key); err != nil {
+			logrus.Errorf("failed to delete blob %s: %s", key, err.Error())
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d blobs", failed, len(keys))
+	}
+	return nil
+}
+
+func trashError(r *models.ApiResponse, err error) {
+	if err.Error() == DbResultNotFound {
+		r.Error(StatusNotFound, "Item is not in the trash")
+		return
+	}
+	r.Error(StatusInternalServerError, err.Error())
+}
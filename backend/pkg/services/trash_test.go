@@ -0,0 +1,148 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/storage"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashService_RestoreProject(t *testing.T) {
+	type args struct {
+		userId    int
+		projectId int
+	}
+	type mockBehavior func(r *mock_repositories.MockTrash, projectId int)
+
+	tests := []struct {
+		name                string
+		input               args
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name:  "Ok",
+			input: args{userId: 1, projectId: 2},
+			mock: func(r *mock_repositories.MockTrash, projectId int) {
+				r.EXPECT().GetById(models.TrashProject, projectId).Return(&models.TrashItem{
+					Type: models.TrashProject, Id: projectId, ProjectId: projectId, OwnerId: 1}, nil)
+				r.EXPECT().Restore(models.TrashProject, projectId).Return(nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{},
+			},
+		},
+		{
+			name:  "Project is not in the trash",
+			input: args{userId: 1, projectId: 2},
+			mock: func(r *mock_repositories.MockTrash, projectId int) {
+				r.EXPECT().GetById(models.TrashProject, projectId).Return(nil, errors.New(DbResultNotFound))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusNotFound,
+			},
+		},
+		{
+			name:  "Not project owner",
+			input: args{userId: 1, projectId: 2},
+			mock: func(r *mock_repositories.MockTrash, projectId int) {
+				r.EXPECT().GetById(models.TrashProject, projectId).Return(&models.TrashItem{
+					Type: models.TrashProject, Id: projectId, ProjectId: projectId, OwnerId: 3}, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name:  "Repo error for Restore",
+			input: args{userId: 1, projectId: 2},
+			mock: func(r *mock_repositories.MockTrash, projectId int) {
+				r.EXPECT().GetById(models.TrashProject, projectId).Return(&models.TrashItem{
+					Type: models.TrashProject, Id: projectId, ProjectId: projectId, OwnerId: 1}, nil)
+				r.EXPECT().Restore(models.TrashProject, projectId).Return(errors.New("some error"))
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTrash(c)
+			test.mock(repo, test.input.projectId)
+			s := &TrashService{repo: repo}
+
+			got := s.RestoreProject(test.input.userId, test.input.projectId)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
+
+// deleteRecorder is a storage that remembers the deleted keys and fails to
+// delete the ones in failing.
+type deleteRecorder struct {
+	storage.Storage
+	failing map[string]bool
+	deleted []string
+}
+
+func (s *deleteRecorder) Delete(key string) error {
+	s.deleted = append(s.deleted, key)
+	if s.failing[key] {
+		return errors.New("storage error")
+	}
+	return nil
+}
+
+func TestTrashService_Purge(t *testing.T) {
+	keys := []string{"attachments/1/a", "attachments/1/b", "attachments/2/c"}
+
+	tests := []struct {
+		name    string
+		failing map[string]bool
+		wantErr bool
+	}{
+		{
+			name: "Ok",
+		},
+		{
+			name:    "Failed blob",
+			failing: map[string]bool{"attachments/1/a": true},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockTrash(c)
+			repo.EXPECT().Purge(int64(100)).Return(keys, nil)
+			blobs := &deleteRecorder{failing: test.failing}
+			s := &TrashService{repo: repo, blobs: blobs}
+
+			err := s.Purge(100)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, keys, blobs.deleted)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+)
+
+const (
+	boardTitleLength = 50
+	listTitleLength  = 30
+	taskTitleLength  = 30
+	labelNameLength  = 30
+)
+
+// trelloColors follows the palette of the Trello labels; the _dark and _light
+// shades are mapped onto the base color.
+var trelloColors = map[string]uint32{
+	"green":  0x61bd4f,
+	"yellow": 0xf2d600,
+	"orange": 0xff9f1a,
+	"red":    0xeb5a46,
+	"purple": 0xc377e0,
+	"blue":   0x0079bf,
+	"sky":    0x00c2e0,
+	"lime":   0x51e898,
+	"pink":   0xff78cb,
+	"black":  0x344563,
+}
+
+// ImportTrello creates a board from a Trello board export. With dryRun set
+// nothing is created and only the report is returned.
+func (s *BoardService) ImportTrello(userId, projectId int, title string,
+	export *models.TrelloBoard, dryRun bool) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.ProjectPermissions(userId, projectId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if title == "" {
+		title = export.Name
+	}
+	content, report := convertTrelloBoard(export)
+	report.Title = truncateTitle(title, boardTitleLength, report)
+	if report.Title == "" {
+		r.Error(StatusBadRequest, "Empty board title")
+		return r
+	}
+
+	if dryRun {
+		r.Set(StatusOK, "OK", Map{"report": report})
+		return r
+	}
+
+	curTime := time.Now().Unix()
+	board := &models.Board{
+		ProjectId: projectId,
+		OwnerId:   userId,
+		DefaultPermissions: &models.Permission{
+			Read:  true,
+			Write: true,
+			Admin: false,
+		},
+		Datetimes: &models.Datetimes{
+			Created:  curTime,
+			Updated:  curTime,
+			Accessed: curTime,
+		},
+		Title: report.Title,
+	}
+
+	boardId, err := s.repo.CreateWithContent(userId, board, content)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"boardId": boardId, "report": report})
+	return r
+}
+
+// convertTrelloBoard leaves out closed lists and cards. Card due dates have
+// no place in a task, so they are appended to the description.
+func convertTrelloBoard(export *models.TrelloBoard) (*models.BoardContent, *models.TrelloImportReport) {
+	report := &models.TrelloImportReport{}
+	content := &models.BoardContent{
+		Lists:  make([]*models.ListContent, 0),
+		Labels: make([]*models.LabelContent, 0),
+	}
+
+	labelIds := make(map[string]int, len(export.Labels))
+	for i, label := range export.Labels {
+		color := strings.SplitN(label.Color, "_", 2)[0]
+		name := strings.TrimSpace(label.Name)
+		if name == "" {
+			name = color
+		}
+		labelIds[label.Id] = i + 1
+		content.Labels = append(content.Labels, &models.LabelContent{
+			Id:    i + 1,
+			Name:  truncateTitle(name, labelNameLength, report),
+			Color: trelloColors[color],
+		})
+	}
+	report.Labels = len(content.Labels)
+
+	lists := make([]*models.TrelloList, 0, len(export.Lists))
+	for _, list := range export.Lists {
+		if list.Closed {
+			report.SkippedLists++
+			continue
+		}
+		lists = append(lists, list)
+	}
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+
+	listsById := make(map[string]*models.ListContent, len(lists))
+	for _, list := range lists {
+		listContent := &models.ListContent{Title: truncateTitle(list.Name, listTitleLength, report)}
+		listsById[list.Id] = listContent
+		content.Lists = append(content.Lists, listContent)
+	}
+	report.Lists = len(content.Lists)
+
+	cards := make([]*models.TrelloCard, len(export.Cards))
+	copy(cards, export.Cards)
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+
+	for _, card := range cards {
+		list, ok := listsById[card.IdList]
+		if card.Closed || !ok {
+			report.SkippedCards++
+			continue
+		}
+
+		task := &models.TaskContent{
+			Title:       truncateTitle(card.Name, taskTitleLength, report),
+			Description: card.Desc,
+		}
+		if task.Title != strings.TrimSpace(card.Name) {
+			task.Description = joinParagraphs(card.Name, task.Description)
+		}
+		if due, err := time.Parse(time.RFC3339, card.Due); err == nil {
+			task.Description = joinParagraphs(task.Description,
+				"Due: "+due.UTC().Format("2006-01-02 15:04 UTC"))
+			report.DueDates++
+		}
+		for _, labelId := range card.IdLabels {
+			if id, ok := labelIds[labelId]; ok {
+				task.LabelIds = append(task.LabelIds, id)
+			}
+		}
+
+		list.Tasks = append(list.Tasks, task)
+		report.Tasks++
+	}
+
+	return content, report
+}
+
+// truncateTitle cuts the title to the length of its column and records the
+// original in the report when it does not fit.
+func truncateTitle(title string, length int, report *models.TrelloImportReport) string {
+	title = strings.TrimSpace(title)
+	runes := []rune(title)
+	if len(runes) <= length {
+		return title
+	}
+	report.TruncatedTitles = append(report.TruncatedTitles, title)
+	return strings.TrimSpace(string(runes[:length]))
+}
+
+func joinParagraphs(first, second string) string {
+	if first == "" {
+		return second
+	}
+	if second == "" {
+		return first
+	}
+	return first + "\n\n" + second
+}
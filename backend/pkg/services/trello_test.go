@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertTrelloBoard(t *testing.T) {
+	export := &models.TrelloBoard{
+		Name: "Roadmap",
+		Lists: []*models.TrelloList{
+			{Id: "l2", Name: "Done", Pos: 200},
+			{Id: "l1", Name: "To do", Pos: 100},
+			{Id: "l3", Name: "Old", Pos: 300, Closed: true},
+		},
+		Cards: []*models.TrelloCard{
+			{Id: "c2", IdList: "l1", Name: "Second", Pos: 20, IdLabels: []string{"b"}},
+			{Id: "c1", IdList: "l1", Name: "First", Desc: "Details", Pos: 10,
+				Due: "2021-03-04T12:30:00.000Z", IdLabels: []string{"a", "unknown"}},
+			{Id: "c3", IdList: "l2", Name: "A card title that is far too long for a task", Pos: 5},
+			{Id: "c4", IdList: "l3", Name: "In a closed list", Pos: 1},
+			{Id: "c5", IdList: "l1", Name: "Closed", Pos: 1, Closed: true},
+		},
+		Labels: []*models.TrelloLabel{
+			{Id: "a", Name: "Bug", Color: "red"},
+			{Id: "b", Name: "", Color: "green_dark"},
+		},
+	}
+
+	content, report := convertTrelloBoard(export)
+
+	assert.Equal(t, []*models.LabelContent{
+		{Id: 1, Name: "Bug", Color: 0xeb5a46},
+		{Id: 2, Name: "green", Color: 0x61bd4f},
+	}, content.Labels)
+
+	assert.Len(t, content.Lists, 2)
+	assert.Equal(t, "To do", content.Lists[0].Title)
+	assert.Equal(t, []*models.TaskContent{
+		{Title: "First", Description: "Details\n\nDue: 2021-03-04 12:30 UTC", LabelIds: []int{1}},
+		{Title: "Second", LabelIds: []int{2}},
+	}, content.Lists[0].Tasks)
+
+	assert.Equal(t, "Done", content.Lists[1].Title)
+	assert.Equal(t, "A card title that is far too l", content.Lists[1].Tasks[0].Title)
+	assert.Equal(t, "A card title that is far too long for a task", content.Lists[1].Tasks[0].Description)
+
+	assert.Equal(t, &models.TrelloImportReport{
+		Lists:           2,
+		Tasks:           3,
+		Labels:          2,
+		SkippedLists:    1,
+		SkippedCards:    2,
+		DueDates:        1,
+		TruncatedTitles: []string{"A card title that is far too long for a task"},
+	}, report)
+}
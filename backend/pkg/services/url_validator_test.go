@@ -35,13 +35,13 @@ func TestUrlValidatorService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
 			listMock: func(r *mock_repositories.MockTaskList, listId int) {
-				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 1, "title", 1}, nil)
+				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 1, "title", 1, false, false}, nil)
 			},
 			taskMock: func(r *mock_repositories.MockTask, taskId int) {
-				r.EXPECT().GetById(taskId).Return(&models.Task{1, 1, "title", "description", &models.Datetimes{1, 1, 1}, 1}, nil)
+				r.EXPECT().GetById(taskId).Return(&models.Task{1, 1, "title", "description", &models.Datetimes{1, 1, 1}, 1, false, false, 0, 0, 0, 0, nil, nil}, nil)
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusOK,
@@ -83,7 +83,7 @@ func TestUrlValidatorService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 2, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
 			listMock: func(r *mock_repositories.MockTaskList, listId int) {},
 			taskMock: func(r *mock_repositories.MockTask, taskId int) {},
@@ -98,7 +98,7 @@ func TestUrlValidatorService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
 			listMock: func(r *mock_repositories.MockTaskList, listId int) {
 				r.EXPECT().GetById(listId).Return(nil, errors.New(DbResultNotFound))
@@ -115,7 +115,7 @@ func TestUrlValidatorService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
 			listMock: func(r *mock_repositories.MockTaskList, listId int) {
 				r.EXPECT().GetById(listId).Return(nil, errors.New("Some error"))
@@ -132,10 +132,10 @@ func TestUrlValidatorService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
 			listMock: func(r *mock_repositories.MockTaskList, listId int) {
-				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 2, "title", 1}, nil)
+				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 2, "title", 1, false, false}, nil)
 			},
 			taskMock: func(r *mock_repositories.MockTask, taskId int) {},
 			expectedApiResponse: &models.ApiResponse{
@@ -149,10 +149,10 @@ func TestUrlValidatorService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
 			listMock: func(r *mock_repositories.MockTaskList, listId int) {
-				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 1, "title", 1}, nil)
+				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 1, "title", 1, false, false}, nil)
 			},
 			taskMock: func(r *mock_repositories.MockTask, taskId int) {
 				r.EXPECT().GetById(taskId).Return(nil, errors.New(DbResultNotFound))
@@ -168,10 +168,10 @@ func TestUrlValidatorService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
 			listMock: func(r *mock_repositories.MockTaskList, listId int) {
-				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 1, "title", 1}, nil)
+				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 1, "title", 1, false, false}, nil)
 			},
 			taskMock: func(r *mock_repositories.MockTask, taskId int) {
 				r.EXPECT().GetById(taskId).Return(nil, errors.New("Some error"))
@@ -187,13 +187,13 @@ func TestUrlValidatorService_Create(t *testing.T) {
 			},
 			boardMock: func(r *mock_repositories.MockBoard, boardId int) {
 				r.EXPECT().GetById(boardId).Return(&models.Board{1, 1, 1, &models.Permission{true, true, false},
-					&models.Datetimes{1, 1, 1}, "title"}, nil)
+					&models.Datetimes{1, 1, 1}, "title", false, 0}, nil)
 			},
 			listMock: func(r *mock_repositories.MockTaskList, listId int) {
-				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 1, "title", 1}, nil)
+				r.EXPECT().GetById(listId).Return(&models.TaskList{1, 1, "title", 1, false, false}, nil)
 			},
 			taskMock: func(r *mock_repositories.MockTask, taskId int) {
-				r.EXPECT().GetById(taskId).Return(&models.Task{1, 2, "title", "description", &models.Datetimes{1, 1, 1}, 1}, nil)
+				r.EXPECT().GetById(taskId).Return(&models.Task{1, 2, "title", "description", &models.Datetimes{1, 1, 1}, 1, false, false, 0, 0, 0, 0, nil, nil}, nil)
 			},
 			expectedApiResponse: &models.ApiResponse{
 				Code: StatusNotFound,
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/architectv/networking-course-project/backend/pkg/filter"
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+	"github.com/architectv/networking-course-project/backend/pkg/repositories"
+)
+
+type ViewService struct {
+	repo     repositories.View
+	listRepo repositories.TaskList
+	access   *AccessResolver
+	fields   *CustomFieldService
+}
+
+func NewViewService(repo repositories.View, listRepo repositories.TaskList, access *AccessResolver,
+	fields *CustomFieldService) *ViewService {
+	return &ViewService{repo: repo, listRepo: listRepo, access: access, fields: fields}
+}
+
+func (s *ViewService) GetAll(userId, projectId, boardId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	views, err := s.repo.GetAll(boardId, userId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"views": views})
+	return r
+}
+
+func (s *ViewService) GetById(userId, projectId, boardId, viewId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	view, err := s.getBoardView(userId, boardId, viewId)
+	if err != nil {
+		viewError(r, err)
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"view": view})
+	return r
+}
+
+// Create lets every reader of the board save private views, while shared
+// ones need the write permission.
+func (s *ViewService) Create(userId, projectId, boardId int, view *models.BoardView) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false || (view.Shared && permissions.Write == false) {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if view.ListIds == nil {
+		view.ListIds = make([]int, 0)
+	}
+	if !s.validate(r, boardId, &view.Filter, &view.Sort, &view.ListIds) {
+		return r
+	}
+
+	view.BoardId = boardId
+	view.OwnerId = userId
+	view.Created = time.Now().Unix()
+	viewId, err := s.repo.Create(view)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{"viewId": viewId})
+	return r
+}
+
+// Update is allowed to the owner of the view and, for shared views, to the
+// admins of the board.
+func (s *ViewService) Update(userId, projectId, boardId, viewId int, input *models.UpdateBoardView) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	view, err := s.getBoardView(userId, boardId, viewId)
+	if err != nil {
+		viewError(r, err)
+		return r
+	}
+
+	if view.OwnerId != userId && permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+	if input.Shared != nil && *input.Shared && permissions.Write == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if !s.validate(r, boardId, input.Filter, input.Sort, input.ListIds) {
+		return r
+	}
+
+	if err = s.repo.Update(viewId, input); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *ViewService) Delete(userId, projectId, boardId, viewId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	view, err := s.getBoardView(userId, boardId, viewId)
+	if err != nil {
+		viewError(r, err)
+		return r
+	}
+
+	if view.OwnerId != userId && permissions.Admin == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err = s.repo.Delete(viewId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *ViewService) SetDefault(userId, projectId, boardId, viewId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if _, err = s.getBoardView(userId, boardId, viewId); err != nil {
+		viewError(r, err)
+		return r
+	}
+
+	if err = s.repo.SetDefault(boardId, userId, viewId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+func (s *ViewService) DeleteDefault(userId, projectId, boardId int) *models.ApiResponse {
+	r := &models.ApiResponse{}
+	permissions, err := s.access.BoardPermissions(userId, boardId)
+	if err != nil || permissions.Read == false {
+		r.Error(StatusForbidden, "Forbidden")
+		return r
+	}
+
+	if err = s.repo.DeleteDefault(boardId, userId); err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return r
+	}
+
+	r.Set(StatusOK, "OK", Map{})
+	return r
+}
+
+// getBoardView hides the views of other boards and the private views of
+// other users.
+func (s *ViewService) getBoardView(userId, boardId, viewId int) (*models.BoardView, error) {
+	view, err := s.repo.GetById(viewId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if view.BoardId != boardId || (!view.Shared && view.OwnerId != userId) {
+		return nil, errors.New(DbResultNotFound)
+	}
+	return view, nil
+}
+
+// validate checks the given fields of a view and sets the response on error.
+func (s *ViewService) validate(r *models.ApiResponse, boardId int, filterQuery, sort *string, listIds *[]int) bool {
+	query := &filter.Query{}
+	if filterQuery != nil {
+		var err error
+		if query, err = filter.Parse(*filterQuery); err != nil {
+			filterError(r, err)
+			return false
+		}
+	}
+
+	if sort != nil {
+		var err error
+		if query.Sort, err = filter.ParseSort(*sort); err != nil {
+			r.Error(StatusBadRequest, "Invalid sort")
+			return false
+		}
+	}
+
+	if !s.fields.resolve(r, boardId, query) {
+		return false
+	}
+
+	if listIds == nil || len(*listIds) == 0 {
+		return true
+	}
+
+	lists, err := s.listRepo.GetAll(boardId)
+	if err != nil {
+		r.Error(StatusInternalServerError, err.Error())
+		return false
+	}
+	boardLists := make(map[int]bool, len(lists))
+	for _, list := range lists {
+		boardLists[list.Id] = true
+	}
+	for _, listId := range *listIds {
+		if !boardLists[listId] {
+			r.Error(StatusBadRequest, fmt.Sprintf("List %d is not on the board", listId))
+			return false
+		}
+	}
+	return true
+}
+
+func viewError(r *models.ApiResponse, err error) {
+	if err.Error() == DbResultNotFound {
+		r.Error(StatusNotFound, "View not found")
+		return
+	}
+	r.Error(StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/architectv/networking-course-project/backend/pkg/models"
+
+	mock_repositories "github.com/architectv/networking-course-project/backend/pkg/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewService_Create(t *testing.T) {
+	type args struct {
+		userId  int
+		boardId int
+		view    *models.BoardView
+	}
+	type mockBehavior func(r *mock_repositories.MockView, l *mock_repositories.MockTaskList, boardId int)
+
+	lists := []*models.TaskList{{Id: 1, BoardId: 2, Title: "To do"}}
+
+	tests := []struct {
+		name                string
+		input               args
+		perms               *models.Permission
+		mock                mockBehavior
+		expectedApiResponse *models.ApiResponse
+	}{
+		{
+			name: "Ok",
+			input: args{userId: 1, boardId: 2, view: &models.BoardView{
+				Title: "Bugs", Filter: "label:bug", Sort: "-updated", ListIds: []int{1}}},
+			perms: &models.Permission{Read: true},
+			mock: func(r *mock_repositories.MockView, l *mock_repositories.MockTaskList, boardId int) {
+				l.EXPECT().GetAll(boardId).Return(lists, nil)
+				r.EXPECT().Create(gomock.Any()).DoAndReturn(func(view *models.BoardView) (int, error) {
+					assert.Equal(t, boardId, view.BoardId)
+					assert.Equal(t, 1, view.OwnerId)
+					return 3, nil
+				})
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusOK,
+				Data: Map{"viewId": 3},
+			},
+		},
+		{
+			name:  "Shared without write",
+			input: args{userId: 1, boardId: 2, view: &models.BoardView{Title: "Bugs", Shared: true}},
+			perms: &models.Permission{Read: true},
+			mock:  func(r *mock_repositories.MockView, l *mock_repositories.MockTaskList, boardId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusForbidden,
+			},
+		},
+		{
+			name:  "Invalid filter",
+			input: args{userId: 1, boardId: 2, view: &models.BoardView{Title: "Bugs", Filter: "owner:alex"}},
+			perms: &models.Permission{Read: true},
+			mock:  func(r *mock_repositories.MockView, l *mock_repositories.MockTaskList, boardId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name:  "Invalid sort",
+			input: args{userId: 1, boardId: 2, view: &models.BoardView{Title: "Bugs", Sort: "owner"}},
+			perms: &models.Permission{Read: true},
+			mock:  func(r *mock_repositories.MockView, l *mock_repositories.MockTaskList, boardId int) {},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+		{
+			name:  "List of another board",
+			input: args{userId: 1, boardId: 2, view: &models.BoardView{Title: "Bugs", ListIds: []int{5}}},
+			perms: &models.Permission{Read: true},
+			mock: func(r *mock_repositories.MockView, l *mock_repositories.MockTaskList, boardId int) {
+				l.EXPECT().GetAll(boardId).Return(lists, nil)
+			},
+			expectedApiResponse: &models.ApiResponse{
+				Code: StatusBadRequest,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_repositories.NewMockView(c)
+			listRepo := mock_repositories.NewMockTaskList(c)
+			accessRepo := mock_repositories.NewMockAccess(c)
+			accessRepo.EXPECT().GetBoardSources(test.input.userId, test.input.boardId).
+				Return(boardSources(test.perms), nil)
+			test.mock(repo, listRepo, test.input.boardId)
+			s := &ViewService{repo: repo, listRepo: listRepo, access: NewAccessResolver(accessRepo)}
+
+			got := s.Create(test.input.userId, 1, test.input.boardId, test.input.view)
+			assert.Equal(t, test.expectedApiResponse.Code, got.Code)
+			if test.expectedApiResponse.Code == StatusOK {
+				assert.Equal(t, test.expectedApiResponse.Data, got.Data)
+			}
+		})
+	}
+}
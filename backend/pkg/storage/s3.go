@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	amzDateLayout   = "20060102T150405Z"
+)
+
+// S3 talks to an S3-compatible service with path-style URLs and signature
+// version 4. Payloads are not signed so that uploads can be streamed.
+type S3 struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3(cfg Config) *S3 {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil {
+		endpoint = &url.URL{}
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3{
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		region:    region,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		client:    &http.Client{},
+		now:       time.Now,
+	}
+}
+
+func (s *S3) Put(key string, content io.Reader, size int64, contentType string) error {
+	resp, err := s.do(http.MethodPut, key, content, size, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return s.check(resp, http.MethodPut, key)
+}
+
+func (s *S3) Get(key string) (io.ReadCloser, error) {
+	resp, err := s.do(http.MethodGet, key, nil, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.check(resp, http.MethodGet, key); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3) Delete(key string) error {
+	resp, err := s.do(http.MethodDelete, key, nil, 0, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := s.check(resp, http.MethodDelete, key); err != nil && err != ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+func (s *S3) do(method, key string, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	target := *s.endpoint
+	target.Path = s.endpoint.Path + "/" + s.bucket + "/" + key
+
+	req, err := http.NewRequest(method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, s.now().UTC())
+	return s.client.Do(req)
+}
+
+func (s *S3) check(resp *http.Response, method, key string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return fmt.Errorf("s3: %s %s: %s", method, key, resp.Status)
+}
+
+func (s *S3) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format(amzDateLayout)
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + unsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
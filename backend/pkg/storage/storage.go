@@ -0,0 +1,39 @@
+// Package storage keeps blobs such as task attachments outside of the
+// database, on the local filesystem or in an S3-compatible bucket.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrNotFound = errors.New("Blob not found")
+
+type Storage interface {
+	Put(key string, content io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	// Delete succeeds for a missing blob as well.
+	Delete(key string) error
+}
+
+type Config struct {
+	Driver    string
+	Path      string
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocal(cfg.Path), nil
+	case "s3":
+		return NewS3(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 stands in for an S3-compatible service: it keeps the objects in
+// memory and rejects requests whose signature doesn't match.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	signer  *S3
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	now, err := time.Parse(amzDateLayout, req.Header.Get("X-Amz-Date"))
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	expected := req.Clone(req.Context())
+	expected.URL.Host = req.Host
+	f.signer.sign(expected, now)
+	if expected.Header.Get("Authorization") != req.Header.Get("Authorization") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch req.Method {
+	case http.MethodPut:
+		body, _ := ioutil.ReadAll(req.Body)
+		f.objects[req.URL.Path] = string(body)
+	case http.MethodGet:
+		object, ok := f.objects[req.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(object))
+	case http.MethodDelete:
+		delete(f.objects, req.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func testStorage(t *testing.T, s Storage) {
+	content := "screenshot"
+	require.NoError(t, s.Put("attachments/1/abc", strings.NewReader(content),
+		int64(len(content)), "image/png"))
+
+	blob, err := s.Get("attachments/1/abc")
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(blob)
+	blob.Close()
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	require.NoError(t, s.Delete("attachments/1/abc"))
+	_, err = s.Get("attachments/1/abc")
+	assert.Equal(t, ErrNotFound, err)
+	assert.NoError(t, s.Delete("attachments/1/abc"))
+}
+
+func TestLocal(t *testing.T) {
+	root, err := ioutil.TempDir("", "storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	s := NewLocal(root)
+	testStorage(t, s)
+
+	err = s.Put("../outside", strings.NewReader(""), 0, "")
+	assert.Error(t, err)
+}
+
+func TestS3(t *testing.T) {
+	cfg := Config{Bucket: "yak", AccessKey: "key", SecretKey: "secret"}
+	fake := &fakeS3{objects: make(map[string]string), signer: NewS3(cfg)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	cfg.Endpoint = server.URL
+	testStorage(t, NewS3(cfg))
+
+	cfg.SecretKey = "wrong"
+	err := NewS3(cfg).Put("attachments/1/abc", strings.NewReader(""), 0, "")
+	assert.Error(t, err)
+}
@@ -1,7 +1,33 @@
+DROP TABLE IF EXISTS task_field_values CASCADE;
+DROP TABLE IF EXISTS field_options CASCADE;
+DROP TABLE IF EXISTS custom_fields CASCADE;
+DROP TABLE IF EXISTS timers CASCADE;
+DROP TABLE IF EXISTS time_logs CASCADE;
+DROP TABLE IF EXISTS time_estimates CASCADE;
+DROP TABLE IF EXISTS mentions CASCADE;
+DROP TABLE IF EXISTS mail_settings CASCADE;
+DROP TABLE IF EXISTS notification_preferences CASCADE;
+DROP TABLE IF EXISTS notifications CASCADE;
+DROP TABLE IF EXISTS watchers CASCADE;
+DROP TABLE IF EXISTS attachments CASCADE;
+DROP TABLE IF EXISTS task_relations CASCADE;
 DROP TABLE IF EXISTS task_labels CASCADE;
 DROP TABLE IF EXISTS labels CASCADE;
+DROP TABLE IF EXISTS task_history CASCADE;
 DROP TABLE IF EXISTS tasks CASCADE;
+DROP TABLE IF EXISTS sprints CASCADE;
 DROP TABLE IF EXISTS task_lists CASCADE;
+DROP TABLE IF EXISTS board_default_views CASCADE;
+DROP TABLE IF EXISTS board_views CASCADE;
+DROP TABLE IF EXISTS board_templates CASCADE;
+DROP TABLE IF EXISTS ownership_transfers CASCADE;
+DROP TABLE IF EXISTS board_shares CASCADE;
+DROP TABLE IF EXISTS invitations CASCADE;
+DROP TABLE IF EXISTS board_groups CASCADE;
+DROP TABLE IF EXISTS project_groups CASCADE;
+DROP TABLE IF EXISTS group_users CASCADE;
+DROP TABLE IF EXISTS groups CASCADE;
+DROP TABLE IF EXISTS board_denials CASCADE;
 DROP TABLE IF EXISTS board_users CASCADE;
 DROP TABLE IF EXISTS boards CASCADE;
 DROP TABLE IF EXISTS project_users CASCADE;
@@ -38,7 +64,8 @@ CREATE TABLE IF NOT EXISTS projects (
     default_permissions_id int REFERENCES permissions (id) ON DELETE CASCADE NOT NULL,
     datetimes_id int REFERENCES datetimes (id) ON DELETE CASCADE NOT NULL,
     title varchar(50) NOT NULL,
-    description text
+    description text,
+    deleted_at bigint
 );
 CREATE TABLE IF NOT EXISTS project_users (
     id serial PRIMARY KEY,
@@ -52,7 +79,9 @@ CREATE TABLE IF NOT EXISTS boards (
     owner_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
     default_permissions_id int REFERENCES permissions (id) ON DELETE CASCADE NOT NULL,
     datetimes_id int references datetimes (id) ON DELETE CASCADE NOT NULL,
-    title varchar(50) NOT NULL
+    title varchar(50) NOT NULL,
+    archived boolean NOT NULL DEFAULT false,
+    deleted_at bigint
 );
 CREATE TABLE IF NOT EXISTS board_users (
     id serial PRIMARY KEY,
@@ -60,20 +89,140 @@ CREATE TABLE IF NOT EXISTS board_users (
     board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
     permissions_id int REFERENCES permissions (id) ON DELETE CASCADE NOT NULL
 );
+CREATE TABLE IF NOT EXISTS board_denials (
+    id serial PRIMARY KEY,
+    user_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
+    permissions_id int REFERENCES permissions (id) ON DELETE CASCADE NOT NULL
+);
+CREATE TABLE IF NOT EXISTS groups (
+    id serial PRIMARY KEY,
+    project_id int REFERENCES projects (id) ON DELETE CASCADE NOT NULL,
+    owner_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    title varchar(50) NOT NULL,
+    description text
+);
+CREATE TABLE IF NOT EXISTS group_users (
+    id serial PRIMARY KEY,
+    group_id int REFERENCES groups (id) ON DELETE CASCADE NOT NULL,
+    user_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    UNIQUE (group_id, user_id)
+);
+CREATE TABLE IF NOT EXISTS project_groups (
+    id serial PRIMARY KEY,
+    group_id int REFERENCES groups (id) ON DELETE CASCADE NOT NULL,
+    project_id int REFERENCES projects (id) ON DELETE CASCADE NOT NULL,
+    permissions_id int REFERENCES permissions (id) ON DELETE CASCADE NOT NULL
+);
+CREATE TABLE IF NOT EXISTS board_groups (
+    id serial PRIMARY KEY,
+    group_id int REFERENCES groups (id) ON DELETE CASCADE NOT NULL,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
+    permissions_id int REFERENCES permissions (id) ON DELETE CASCADE NOT NULL
+);
+CREATE TABLE IF NOT EXISTS invitations (
+    id serial PRIMARY KEY,
+    project_id int REFERENCES projects (id) ON DELETE CASCADE NOT NULL,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE,
+    group_id int REFERENCES groups (id) ON DELETE CASCADE,
+    inviter_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    invitee_id int REFERENCES users (id) ON DELETE CASCADE,
+    email varchar(32),
+    token varchar(64) UNIQUE,
+    permissions_id int REFERENCES permissions (id) ON DELETE CASCADE NOT NULL,
+    created bigint NOT NULL,
+    expires bigint NOT NULL
+);
+CREATE TABLE IF NOT EXISTS board_shares (
+    id serial PRIMARY KEY,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
+    owner_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    token varchar(64) UNIQUE NOT NULL,
+    password text NOT NULL DEFAULT '',
+    created bigint NOT NULL,
+    expires bigint NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS ownership_transfers (
+    id serial PRIMARY KEY,
+    project_id int REFERENCES projects (id) ON DELETE CASCADE NOT NULL,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE,
+    old_owner_id int REFERENCES users (id) ON DELETE SET NULL,
+    new_owner_id int REFERENCES users (id) ON DELETE SET NULL,
+    author_id int REFERENCES users (id) ON DELETE SET NULL,
+    created bigint NOT NULL
+);
+CREATE TABLE IF NOT EXISTS board_templates (
+    id serial PRIMARY KEY,
+    project_id int REFERENCES projects (id) ON DELETE CASCADE NOT NULL,
+    owner_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    title varchar(50) NOT NULL,
+    content jsonb NOT NULL,
+    created bigint NOT NULL
+);
+CREATE TABLE IF NOT EXISTS board_views (
+    id serial PRIMARY KEY,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
+    owner_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    title varchar(50) NOT NULL,
+    filter text NOT NULL DEFAULT '',
+    sort varchar(16) NOT NULL DEFAULT '',
+    list_ids int [],
+    shared boolean NOT NULL DEFAULT false,
+    created bigint NOT NULL
+);
+CREATE TABLE IF NOT EXISTS board_default_views (
+    id serial PRIMARY KEY,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
+    user_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    view_id int REFERENCES board_views (id) ON DELETE CASCADE NOT NULL,
+    UNIQUE (board_id, user_id)
+);
 CREATE TABLE IF NOT EXISTS task_lists (
     id serial PRIMARY KEY,
     board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
     title varchar(30) NOT NULL,
-    position int NOT NULL
+    position int NOT NULL,
+    archived boolean NOT NULL DEFAULT false,
+    done boolean NOT NULL DEFAULT false,
+    deleted_at bigint
+);
+CREATE TABLE IF NOT EXISTS sprints (
+    id serial PRIMARY KEY,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
+    name varchar(64) NOT NULL,
+    goal text NOT NULL DEFAULT '',
+    start_date bigint NOT NULL,
+    end_date bigint NOT NULL,
+    state varchar(16) NOT NULL DEFAULT 'planned',
+    started bigint NOT NULL DEFAULT 0,
+    completed bigint NOT NULL DEFAULT 0
 );
+CREATE UNIQUE INDEX IF NOT EXISTS sprints_active_idx ON sprints (board_id) WHERE state = 'active';
 CREATE TABLE IF NOT EXISTS tasks (
     id serial PRIMARY KEY,
     list_id int REFERENCES task_lists (id) ON DELETE CASCADE NOT NULL,
     title varchar(30) NOT NULL,
     description text,
     datetimes_id int REFERENCES datetimes (id) ON DELETE CASCADE NOT NULL,
-    position smallint NOT NULL
+    position smallint NOT NULL,
+    archived boolean NOT NULL DEFAULT false,
+    assignee_id int REFERENCES users (id) ON DELETE SET NULL,
+    sprint_id int REFERENCES sprints (id) ON DELETE SET NULL,
+    points int NOT NULL DEFAULT 0 CHECK (points >= 0),
+    due bigint,
+    due_reminded boolean NOT NULL DEFAULT false,
+    deleted_at bigint
 );
+CREATE TABLE IF NOT EXISTS task_history (
+    id serial PRIMARY KEY,
+    task_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
+    sprint_id int REFERENCES sprints (id) ON DELETE CASCADE,
+    points int NOT NULL,
+    done boolean NOT NULL,
+    created bigint NOT NULL
+);
+CREATE INDEX IF NOT EXISTS task_history_task_idx ON task_history (task_id, id);
+CREATE INDEX IF NOT EXISTS task_history_sprint_idx ON task_history (sprint_id);
 CREATE TABLE IF NOT EXISTS tokens (
     id serial PRIMARY KEY,
     jwt text NOT NULL
@@ -89,6 +238,118 @@ CREATE TABLE IF NOT EXISTS task_labels (
     task_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
     label_id int REFERENCES labels (id) ON DELETE CASCADE NOT NULL
 );
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id serial PRIMARY KEY,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE NOT NULL,
+    name varchar(30) NOT NULL,
+    type varchar(16) NOT NULL,
+    position int NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS custom_fields_name_idx ON custom_fields (board_id, lower(name));
+CREATE TABLE IF NOT EXISTS field_options (
+    id serial PRIMARY KEY,
+    field_id int REFERENCES custom_fields (id) ON DELETE CASCADE NOT NULL,
+    name varchar(50) NOT NULL,
+    position int NOT NULL
+);
+CREATE TABLE IF NOT EXISTS task_field_values (
+    task_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
+    field_id int REFERENCES custom_fields (id) ON DELETE CASCADE NOT NULL,
+    text_value text,
+    number_value double precision,
+    date_value bigint,
+    checked boolean,
+    user_id int REFERENCES users (id) ON DELETE CASCADE,
+    option_ids int[],
+    PRIMARY KEY (task_id, field_id)
+);
+CREATE INDEX IF NOT EXISTS task_field_values_field_idx ON task_field_values (field_id);
+CREATE TABLE IF NOT EXISTS task_relations (
+    id serial PRIMARY KEY,
+    task_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
+    related_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
+    kind varchar(16) NOT NULL,
+    UNIQUE (task_id, related_id, kind)
+);
+CREATE TABLE IF NOT EXISTS attachments (
+    id serial PRIMARY KEY,
+    task_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
+    uploader_id int REFERENCES users (id) ON DELETE SET NULL,
+    name varchar(255) NOT NULL,
+    content_type varchar(100) NOT NULL,
+    size bigint NOT NULL,
+    storage_key text NOT NULL,
+    created bigint NOT NULL
+);
+CREATE TABLE IF NOT EXISTS watchers (
+    id serial PRIMARY KEY,
+    user_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    object_type varchar(16) NOT NULL,
+    object_id int NOT NULL,
+    UNIQUE (user_id, object_type, object_id)
+);
+CREATE INDEX IF NOT EXISTS watchers_object_idx ON watchers (object_type, object_id);
+CREATE TABLE IF NOT EXISTS notifications (
+    id serial PRIMARY KEY,
+    user_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    actor_id int REFERENCES users (id) ON DELETE SET NULL,
+    event varchar(32) NOT NULL,
+    project_id int REFERENCES projects (id) ON DELETE CASCADE NOT NULL,
+    board_id int REFERENCES boards (id) ON DELETE CASCADE,
+    list_id int REFERENCES task_lists (id) ON DELETE CASCADE,
+    task_id int REFERENCES tasks (id) ON DELETE CASCADE,
+    created bigint NOT NULL,
+    read boolean NOT NULL DEFAULT false
+);
+CREATE INDEX IF NOT EXISTS notifications_user_idx ON notifications (user_id, read);
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    event varchar(32) NOT NULL,
+    enabled boolean NOT NULL,
+    PRIMARY KEY (user_id, event)
+);
+CREATE TABLE IF NOT EXISTS mail_settings (
+    user_id int PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    notifications boolean NOT NULL DEFAULT true,
+    digest boolean NOT NULL DEFAULT false,
+    digest_sent bigint NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS mentions (
+    id serial PRIMARY KEY,
+    task_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
+    user_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    actor_id int REFERENCES users (id) ON DELETE SET NULL,
+    created bigint NOT NULL,
+    UNIQUE (task_id, user_id)
+);
+CREATE TABLE IF NOT EXISTS time_estimates (
+    task_id int PRIMARY KEY REFERENCES tasks (id) ON DELETE CASCADE,
+    original bigint NOT NULL DEFAULT 0,
+    remaining bigint NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS time_logs (
+    id serial PRIMARY KEY,
+    task_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
+    user_id int REFERENCES users (id) ON DELETE CASCADE NOT NULL,
+    duration bigint NOT NULL CHECK (duration >= 0),
+    deducted bigint NOT NULL DEFAULT 0 CHECK (deducted >= 0),
+    date bigint NOT NULL,
+    note text NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS time_logs_date_idx ON time_logs (date);
+CREATE TABLE IF NOT EXISTS timers (
+    user_id int PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    task_id int REFERENCES tasks (id) ON DELETE CASCADE NOT NULL,
+    started bigint NOT NULL
+);
+CREATE INDEX IF NOT EXISTS projects_search_idx ON projects
+    USING gin (to_tsvector('simple', title || ' ' || COALESCE(description, '')));
+CREATE INDEX IF NOT EXISTS boards_search_idx ON boards
+    USING gin (to_tsvector('simple', title));
+CREATE INDEX IF NOT EXISTS tasks_search_idx ON tasks
+    USING gin (to_tsvector('simple', title || ' ' || COALESCE(description, '')));
+CREATE INDEX IF NOT EXISTS labels_search_idx ON labels
+    USING gin (to_tsvector('simple', name));
 -- USERS
 -- 1
 INSERT INTO users (nickname, email, avatar, password)